@@ -0,0 +1,374 @@
+// Package archive writes and reads database export archives: a tar stream of
+// the exported files preceded by a manifest, compressed and optionally
+// encrypted in the age format (https://age-encryption.org/v1), so that
+// archives can also be decrypted with the age tools.
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// ManifestName is the name of the manifest, the first member of an
+	// archive.
+	ManifestName    = "manifest.json"
+	ManifestVersion = 1
+)
+
+// File types of the members of an archive.
+const (
+	TypeDatabase = "database"
+	TypeWAL      = "wal"
+	TypeMetadata = "metadata"
+)
+
+// Manifest describes the export in an archive.
+type Manifest struct {
+	Version    int    `json:"version"`
+	Database   string `json:"database"`
+	DatabaseID string `json:"database_id"`
+	Group      string `json:"group"`
+	Generation int    `json:"generation"`
+	FrameNo    int    `json:"frame_no"`
+	// ExportStartedAt and ExportedAt are when the export started and
+	// completed.
+	ExportStartedAt time.Time `json:"export_started_at"`
+	ExportedAt      time.Time `json:"exported_at"`
+	Files           []File    `json:"files"`
+}
+
+// File is a member of an archive.
+type File struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// File returns the member of the given type, if any.
+func (m *Manifest) File(fileType string) (File, bool) {
+	for _, file := range m.Files {
+		if file.Type == fileType {
+			return file, true
+		}
+	}
+	return File{}, false
+}
+
+const ageIntro = "age-encryption.org/v1\n"
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type compression int
+
+const (
+	compressionNone compression = iota
+	compressionGzip
+	compressionZstd
+)
+
+// compressionFor returns the compression of an archive from its file name, an
+// ".age" suffix being ignored.
+func compressionFor(path string) (compression, error) {
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(path)), ".age")
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return compressionGzip, nil
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return compressionZstd, nil
+	case strings.HasSuffix(name, ".tar"):
+		return compressionNone, nil
+	default:
+		return 0, fmt.Errorf("unsupported archive name %s, use a .tar.gz, .tar.zst or .tar archive", filepath.Base(path))
+	}
+}
+
+// Create writes an archive to path, compressed according to its extension and
+// encrypted to the recipients, if any. files maps the type of each member to
+// the path of its file, and the Files of manifest are filled in. The archive
+// is written to a temporary file first, so that path is only replaced once it
+// is complete.
+func Create(path string, manifest *Manifest, files map[string]string, recipients ...age.Recipient) error {
+	compression, err := compressionFor(path)
+	if err != nil {
+		return err
+	}
+	manifest.Version = ManifestVersion
+	manifest.Files = nil
+	for _, fileType := range []string{TypeDatabase, TypeWAL, TypeMetadata} {
+		filePath, ok := files[fileType]
+		if !ok {
+			continue
+		}
+		file, err := describeFile(filePath)
+		if err != nil {
+			return err
+		}
+		file.Type = fileType
+		manifest.Files = append(manifest.Files, file)
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	out, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	var w io.Writer = out
+	var closers []io.Closer
+	if len(recipients) > 0 {
+		encrypted, err := age.Encrypt(w, recipients...)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt archive: %w", err)
+		}
+		w = encrypted
+		closers = append(closers, encrypted)
+	}
+	switch compression {
+	case compressionGzip:
+		compressed := gzip.NewWriter(w)
+		w = compressed
+		closers = append(closers, compressed)
+	case compressionZstd:
+		compressed, err := zstd.NewWriter(w)
+		if err != nil {
+			return err
+		}
+		w = compressed
+		closers = append(closers, compressed)
+	}
+	tw := tar.NewWriter(w)
+	closers = append(closers, tw)
+
+	if err := writeMember(tw, ManifestName, manifest.ExportedAt, bytes.NewReader(manifestData), int64(len(manifestData))); err != nil {
+		return err
+	}
+	for _, file := range manifest.Files {
+		if err := copyMember(tw, file, files[file.Type], manifest.ExportedAt); err != nil {
+			return err
+		}
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+	if err := out.Chmod(0o644); err != nil {
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		return fmt.Errorf("failed to sync archive: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), path)
+}
+
+func describeFile(path string) (File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return File{}, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return File{Name: filepath.Base(path), Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+func copyMember(tw *tar.Writer, file File, path string, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// The file must not change after it was hashed
+	return writeMember(tw, file.Name, modTime, io.LimitReader(f, file.Size), file.Size)
+}
+
+func writeMember(tw *tar.Writer, name string, modTime time.Time, r io.Reader, size int64) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// ErrEncrypted is returned by Extract for an encrypted archive when no
+// identity is given.
+var ErrEncrypted = errors.New("archive is encrypted")
+
+// IsArchive reports whether path looks like an archive, rather than a
+// database file.
+func IsArchive(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	header := make([]byte, 512)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	header = header[:n]
+	return bytes.HasPrefix(header, []byte(ageIntro)) ||
+		bytes.HasPrefix(header, gzipMagic) ||
+		bytes.HasPrefix(header, zstdMagic) ||
+		isTarHeader(header), nil
+}
+
+func isTarHeader(header []byte) bool {
+	return len(header) >= 262 && string(header[257:262]) == "ustar"
+}
+
+// Extract extracts the archive at path to dir, decrypting it with the
+// identities if it is encrypted, and returns its manifest. The size and
+// SHA-256 of every member are checked against the manifest.
+func Extract(path, dir string, identities ...age.Identity) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	if magic, _ := r.Peek(len(ageIntro)); string(magic) == ageIntro {
+		if len(identities) == 0 {
+			return nil, ErrEncrypted
+		}
+		decrypted, err := age.Decrypt(r, identities...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt archive: %w", err)
+		}
+		r = bufio.NewReader(decrypted)
+	}
+	var stream io.Reader = r
+	magic, _ := r.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		defer gr.Close()
+		stream = gr
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		defer zr.Close()
+		stream = zr
+	}
+	tr := tar.NewReader(stream)
+
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	if header.Name != ManifestName {
+		return nil, fmt.Errorf("invalid archive: the first member is %s instead of %s", header.Name, ManifestName)
+	}
+	manifest := &Manifest{}
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest: %w", err)
+	}
+	if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("archive manifest version %d is not supported, update the CLI", manifest.Version)
+	}
+	files := map[string]File{}
+	for _, file := range manifest.Files {
+		if file.Name != filepath.Base(file.Name) || file.Name == "." || file.Name == ".." || strings.ContainsAny(file.Name, `/\`) {
+			return nil, fmt.Errorf("invalid archive manifest: invalid file name %q", file.Name)
+		}
+		files[file.Name] = file
+	}
+
+	extracted := map[string]bool{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		file, ok := files[header.Name]
+		if !ok || header.Typeflag != tar.TypeReg || extracted[header.Name] {
+			return nil, fmt.Errorf("invalid archive: unexpected member %s", header.Name)
+		}
+		if err := extractMember(tr, file, filepath.Join(dir, file.Name)); err != nil {
+			return nil, err
+		}
+		extracted[header.Name] = true
+	}
+	for _, file := range manifest.Files {
+		if !extracted[file.Name] {
+			return nil, fmt.Errorf("invalid archive: missing member %s", file.Name)
+		}
+	}
+	// Read up to the end, so that the gzip or zstd checksum and the last
+	// encrypted chunk are checked
+	if _, err := io.Copy(io.Discard, stream); err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	return manifest, nil
+}
+
+func extractMember(r io.Reader, file File, path string) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+	}
+	defer out.Close()
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), r)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+	}
+	if size != file.Size {
+		return fmt.Errorf("invalid archive: %s has %d bytes, but the manifest records %d", file.Name, size, file.Size)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != file.SHA256 {
+		return fmt.Errorf("invalid archive: SHA-256 of %s is %s, but the manifest records %s", file.Name, sum, file.SHA256)
+	}
+	return out.Close()
+}
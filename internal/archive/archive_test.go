@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+)
+
+// testScrypt returns a passphrase recipient and identity with a low work
+// factor, to keep tests fast.
+func testScrypt(t *testing.T, passphrase string) (*age.ScryptRecipient, *age.ScryptIdentity) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	require.NoError(t, err)
+	recipient.SetWorkFactor(10)
+	identity, err := age.NewScryptIdentity(passphrase)
+	require.NoError(t, err)
+	return recipient, identity
+}
+
+// testExport writes the files of an export to dir and returns their paths by
+// type.
+func testExport(t *testing.T, dir string) map[string]string {
+	files := map[string]string{
+		TypeDatabase: filepath.Join(dir, "db.db"),
+		TypeWAL:      filepath.Join(dir, "db.db-wal"),
+		TypeMetadata: filepath.Join(dir, "db.db-info"),
+	}
+	require.NoError(t, os.WriteFile(files[TypeDatabase], []byte("SQLite format 3\x00database"), 0o644))
+	require.NoError(t, os.WriteFile(files[TypeWAL], make([]byte, 100_000), 0o644))
+	require.NoError(t, os.WriteFile(files[TypeMetadata], []byte(`{"generation":1}`), 0o644))
+	return files
+}
+
+func testManifest() *Manifest {
+	return &Manifest{
+		Database:        "db",
+		DatabaseID:      "0a1b2c",
+		Group:           "default",
+		Generation:      2,
+		FrameNo:         42,
+		ExportStartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		ExportedAt:      time.Date(2024, 5, 1, 10, 1, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateExtract(t *testing.T) {
+	x25519, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	scryptRecipient, scryptIdentity := testScrypt(t, "correct horse")
+
+	tests := []struct {
+		name       string
+		archive    string
+		recipients []age.Recipient
+		identities []age.Identity
+	}{
+		{name: "gzip", archive: "export.tar.gz"},
+		{name: "zstd", archive: "export.tar.zst"},
+		{name: "tar", archive: "export.tar"},
+		{name: "passphrase", archive: "export.tgz.age", recipients: []age.Recipient{scryptRecipient}, identities: []age.Identity{scryptIdentity}},
+		{name: "public key", archive: "export.tar.gz", recipients: []age.Recipient{x25519.Recipient()}, identities: []age.Identity{x25519}},
+		{name: "zstd public key", archive: "export.tzst.age", recipients: []age.Recipient{x25519.Recipient()}, identities: []age.Identity{x25519}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := testExport(t, dir)
+			path := filepath.Join(dir, tt.archive)
+			manifest := testManifest()
+			require.NoError(t, Create(path, manifest, files, tt.recipients...))
+			require.Len(t, manifest.Files, 3)
+
+			isArchive, err := IsArchive(path)
+			require.NoError(t, err)
+			require.True(t, isArchive)
+
+			out := t.TempDir()
+			extracted, err := Extract(path, out, tt.identities...)
+			require.NoError(t, err)
+			require.Equal(t, manifest, extracted)
+			for fileType, file := range files {
+				member, ok := extracted.File(fileType)
+				require.True(t, ok)
+				want, err := os.ReadFile(file)
+				require.NoError(t, err)
+				got, err := os.ReadFile(filepath.Join(out, member.Name))
+				require.NoError(t, err)
+				require.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestCreate_UnsupportedName(t *testing.T) {
+	dir := t.TempDir()
+	files := testExport(t, dir)
+	for _, name := range []string{"export.tar.xz", "export.zip", "export.db"} {
+		err := Create(filepath.Join(dir, name), testManifest(), files)
+		require.Error(t, err, name)
+		require.NoFileExists(t, filepath.Join(dir, name))
+	}
+}
+
+func TestExtract_Encrypted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.tar.gz")
+	recipient, _ := testScrypt(t, "correct horse")
+	require.NoError(t, Create(path, testManifest(), testExport(t, dir), recipient))
+
+	_, err := Extract(path, t.TempDir())
+	require.ErrorIs(t, err, ErrEncrypted)
+
+	_, wrong := testScrypt(t, "wrong horse")
+	_, err = Extract(path, t.TempDir(), wrong)
+	require.ErrorContains(t, err, "incorrect passphrase")
+}
+
+func TestExtract_Tampered(t *testing.T) {
+	dir := t.TempDir()
+	files := testExport(t, dir)
+	manifest := testManifest()
+	require.NoError(t, Create(filepath.Join(dir, "export.tar"), manifest, files))
+
+	// Rewrite the archive with the same manifest, but a modified WAL
+	require.NoError(t, os.WriteFile(files[TypeWAL], append(make([]byte, 99_999), 1), 0o644))
+	path := filepath.Join(dir, "tampered.tar.gz")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	original, err := os.ReadFile(filepath.Join(dir, "export.tar"))
+	require.NoError(t, err)
+	tr := tar.NewReader(bytes.NewReader(original))
+	header, err := tr.Next()
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(header))
+	_, err = io.Copy(tw, tr)
+	require.NoError(t, err)
+	for _, file := range manifest.Files {
+		require.NoError(t, copyMember(tw, file, files[file.Type], manifest.ExportedAt))
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	require.NoError(t, out.Close())
+
+	_, err = Extract(path, t.TempDir())
+	require.ErrorContains(t, err, "SHA-256 of db.db-wal")
+}
+
+func TestIsArchive(t *testing.T) {
+	dir := t.TempDir()
+	files := testExport(t, dir)
+	isArchive, err := IsArchive(files[TypeDatabase])
+	require.NoError(t, err)
+	require.False(t, isArchive)
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o644))
+	isArchive, err = IsArchive(empty)
+	require.NoError(t, err)
+	require.False(t, isArchive)
+}
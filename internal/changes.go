@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// OnOffChange describes the change of a setting from one value to another,
+// as "on → off".
+func OnOffChange(from, to bool) string {
+	return fmt.Sprintf("%s → %s", onOff(from), onOff(to))
+}
+
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+// ListOrNone joins values with commas, or returns "none" when there are none.
+func ListOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+// SameSet reports whether a and b hold the same values, in any order.
+func SameSet(a, b []string) bool {
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			return false
+		}
+	}
+	return true
+}
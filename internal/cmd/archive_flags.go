@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+)
+
+var archivePassphraseFileFlag string
+var archiveRecipientFlag []string
+var archiveIdentityFlag string
+
+func addArchivePassphraseFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&archivePassphraseFileFlag, "passphrase-file", "", "File holding the passphrase of the archive")
+}
+
+func addArchiveRecipientFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&archiveRecipientFlag, "recipient", nil, "Encrypt the archive to an age public key (age1...), can be repeated")
+}
+
+func addArchiveIdentityFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&archiveIdentityFlag, "identity", "", "File holding the age secret key (AGE-SECRET-KEY-1...) to decrypt the archive")
+}
+
+// readPassphraseFile returns the first line of the passphrase file.
+func readPassphraseFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("could not read passphrase file: %w", err)
+	}
+	passphrase, _, _ := strings.Cut(string(data), "\n")
+	passphrase = strings.TrimSuffix(passphrase, "\r")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+// archiveRecipients returns who to encrypt an archive to, from the
+// --passphrase-file and --recipient flags.
+func archiveRecipients() ([]age.Recipient, error) {
+	if archivePassphraseFileFlag != "" && len(archiveRecipientFlag) > 0 {
+		return nil, errors.New("--passphrase-file and --recipient can't be used together")
+	}
+	if archivePassphraseFileFlag != "" {
+		passphrase, err := readPassphraseFile(archivePassphraseFileFlag)
+		if err != nil {
+			return nil, err
+		}
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return []age.Recipient{recipient}, nil
+	}
+	var recipients []age.Recipient
+	for _, flag := range archiveRecipientFlag {
+		recipient, err := age.ParseX25519Recipient(flag)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// archiveIdentities returns the identities to decrypt an archive with, from
+// the --passphrase-file and --identity flags.
+func archiveIdentities() ([]age.Identity, error) {
+	var identities []age.Identity
+	if archivePassphraseFileFlag != "" {
+		passphrase, err := readPassphraseFile(archivePassphraseFileFlag)
+		if err != nil {
+			return nil, err
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	if archiveIdentityFlag != "" {
+		file, err := os.Open(archiveIdentityFlag)
+		if err != nil {
+			return nil, fmt.Errorf("could not read identity file: %w", err)
+		}
+		defer file.Close()
+		parsed, err := age.ParseIdentities(file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity file %s: %w", archiveIdentityFlag, err)
+		}
+		identities = append(identities, parsed...)
+	}
+	return identities, nil
+}
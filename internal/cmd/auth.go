@@ -73,13 +73,17 @@ var tokenCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		settings, err := settings.ReadSettings()
 		if err != nil {
 			return fmt.Errorf("could not retrieve local config: %w", err)
 		}
-		token := settings.GetToken()
-		if !isJwtTokenValid(token) {
+		token, err := settings.GetToken()
+		if err != nil {
+			return fmt.Errorf("could not read token: %w", err)
+		}
+		if !isJwtTokenValid(ctx, token) {
 			return fmt.Errorf("no user logged in. Run %s to log in and get a token", internal.Emph("turso auth login"))
 		}
 
@@ -112,7 +116,7 @@ func init() {
 	flags.AddAll(logoutCmd, "Invalidate all sessions for the current user")
 }
 
-func isJwtTokenValid(token string) bool {
+func isJwtTokenValid(ctx context.Context, token string) bool {
 	if len(token) == 0 {
 		return false
 	}
@@ -123,7 +127,7 @@ func isJwtTokenValid(token string) bool {
 	if err != nil {
 		return false
 	}
-	exp, err := client.Tokens.Validate(token)
+	exp, err := client.Tokens.Validate(ctx, token)
 	if err != nil {
 		return false
 	}
@@ -153,13 +157,18 @@ func exitOnValidAuth(settings *settings.Settings, path string) {
 }
 
 func auth(cmd *cobra.Command, path string) error {
+	ctx := cmd.Context()
 	cmd.SilenceUsage = true
 	settings, err := settings.ReadSettings()
 	if err != nil {
 		return fmt.Errorf("could not retrieve local config: %w", err)
 	}
 
-	if isJwtTokenValid(settings.GetToken()) {
+	token, err := settings.GetToken()
+	if err != nil {
+		return fmt.Errorf("could not read token: %w", err)
+	}
+	if isJwtTokenValid(ctx, token) {
 		exitOnValidAuth(settings, path)
 		return nil
 	}
@@ -168,7 +177,7 @@ func auth(cmd *cobra.Command, path string) error {
 
 	if autoUpdateDisabled && version != "dev" {
 		go func() {
-			latestVersion, err := fetchLatestVersion()
+			latestVersion, err := fetchLatestVersion(ctx)
 			if err != nil {
 				// On error we just behave as the version check has never happend
 				versionChannel <- version
@@ -206,17 +215,19 @@ func auth(cmd *cobra.Command, path string) error {
 	if err != nil {
 		return suggestHeadless(cmd, err)
 	}
-	username, err := validateToken(jwt)
+	username, err := validateToken(ctx, jwt)
 	if err != nil {
 		return suggestHeadless(cmd, err)
 	}
 
-	settings.SetToken(jwt)
+	if err := settings.SetToken(jwt); err != nil {
+		return fmt.Errorf("could not store token: %w", err)
+	}
 	settings.SetUsername(username)
 
 	fmt.Printf("✔  Success! Logged in as %s\n", username)
 
-	signupHint(settings)
+	signupHint(ctx, settings)
 
 	if autoUpdateDisabled && version != "dev" {
 		latestVersion := <-versionChannel
@@ -232,13 +243,13 @@ func auth(cmd *cobra.Command, path string) error {
 	return nil
 }
 
-func validateToken(token string) (string, error) {
+func validateToken(ctx context.Context, token string) (string, error) {
 	client, err := tursoClient(token)
 	if err != nil {
 		return "", fmt.Errorf("could not create client to validate token: %w", err)
 	}
 
-	user, err := client.Users.GetUser()
+	user, err := client.Users.GetUser(ctx)
 	if err != nil {
 		return "", fmt.Errorf("could not validate token: %w", err)
 
@@ -265,8 +276,8 @@ func printHeadlessLoginInstructions(path string) error {
 	return nil
 }
 
-func signupHint(config *settings.Settings) {
-	client, err := authedTursoClient()
+func signupHint(ctx context.Context, config *settings.Settings) {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return
 	}
@@ -276,7 +287,7 @@ func signupHint(config *settings.Settings) {
 		return
 	}
 
-	databases, err := listDatabases(client)
+	databases, err := listDatabases(ctx, client)
 	if err != nil || len(databases) != 0 {
 		return
 	}
@@ -382,39 +393,49 @@ func runServer(server *http.Server) (int, error) {
 }
 
 func logout(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	cmd.SilenceUsage = true
 	settings, err := settings.ReadSettings()
 	if err != nil {
 		return fmt.Errorf("could not retrieve local config: %w", err)
 	}
 
-	if token := settings.GetToken(); len(token) == 0 {
+	token, err := settings.GetToken()
+	if err != nil {
+		return fmt.Errorf("could not read token: %w", err)
+	}
+	if len(token) == 0 {
 		fmt.Println("No user logged in.")
 		return nil
 	}
 
-	if err := invalidateSessionsIfRequested(); err != nil {
+	if err := invalidateSessionsIfRequested(ctx); err != nil {
 		return err
 	}
 
-	settings.SetToken("")
+	if err := settings.SetToken(""); err != nil {
+		return fmt.Errorf("could not remove token: %w", err)
+	}
+	if err := settings.InvalidateDatabaseTokens(); err != nil {
+		return fmt.Errorf("could not remove database tokens: %w", err)
+	}
 	settings.SetUsername("")
 	fmt.Println("Logged out.")
 
 	return nil
 }
 
-func invalidateSessionsIfRequested() error {
+func invalidateSessionsIfRequested(ctx context.Context) error {
 	if !flags.All() {
 		return nil
 	}
 
-	turso, err := authedTursoClient()
+	turso, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	from, err := turso.Tokens.Invalidate()
+	from, err := turso.Tokens.Invalidate(ctx)
 	if err != nil {
 		return err
 	}
@@ -425,12 +446,13 @@ func invalidateSessionsIfRequested() error {
 }
 
 func whoAmI(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
 	cmd.SilenceUsage = true
-	client, err := authedTursoClient()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
-	user, err := client.Users.GetUser()
+	user, err := client.Users.GetUser(ctx)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -37,9 +38,10 @@ var createApiTokensCmd = &cobra.Command{
 		"set of scopes you pass via --scope (or the --read-only / --full-access shorthands).",
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -63,18 +65,18 @@ var createApiTokensCmd = &cobra.Command{
 		}
 
 		if mintOrgFlag != "" {
-			if err := validateOrgExists(client, mintOrgFlag); err != nil {
+			if err := validateOrgExists(ctx, client, mintOrgFlag); err != nil {
 				return err
 			}
 		}
 
 		if mintGroupFlag != "" {
-			if err := validateGroupExists(client, mintOrgFlag, mintGroupFlag); err != nil {
+			if err := validateGroupExists(ctx, client, mintOrgFlag, mintGroupFlag); err != nil {
 				return err
 			}
 		}
 
-		data, err := client.ApiTokens.CreateScoped(name, mintOrgFlag, mintGroupFlag, scopes)
+		data, err := client.ApiTokens.CreateScoped(ctx, name, mintOrgFlag, mintGroupFlag, scopes)
 		if err != nil {
 			return err
 		}
@@ -124,8 +126,8 @@ func resolveMintScopes() ([]string, error) {
 	return out, nil
 }
 
-func validateOrgExists(client *turso.Client, slug string) error {
-	orgs, err := client.Organizations.List()
+func validateOrgExists(ctx context.Context, client *turso.Client, slug string) error {
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list organizations: %w", err)
 	}
@@ -141,12 +143,12 @@ func validateOrgExists(client *turso.Client, slug string) error {
 // organization slug. The Groups client URL-builds from client.Org, so we
 // temporarily point the client at the target org for the lookup, then
 // restore. (Single-command invocation, no concurrency to worry about.)
-func validateGroupExists(client *turso.Client, orgSlug, groupName string) error {
+func validateGroupExists(ctx context.Context, client *turso.Client, orgSlug, groupName string) error {
 	savedOrg := client.Org
 	client.Org = orgSlug
 	defer func() { client.Org = savedOrg }()
 
-	if _, err := client.Groups.Get(groupName); err != nil {
+	if _, err := client.Groups.Get(ctx, groupName); err != nil {
 		return fmt.Errorf("group %s not found in organization %s: %w", internal.Emph(groupName), internal.Emph(orgSlug), err)
 	}
 	return nil
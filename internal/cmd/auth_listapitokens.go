@@ -21,14 +21,15 @@ var listApiTokensCmd = &cobra.Command{
 		"They can be used to implement automations with the " + internal.Emph("turso") + " CLI or the platform API.",
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		apiTokens, err := client.ApiTokens.List()
+		apiTokens, err := client.ApiTokens.List(ctx)
 		if err != nil {
 			return err
 		}
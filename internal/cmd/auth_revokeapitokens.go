@@ -17,15 +17,16 @@ var revokeApiTokensCmd = &cobra.Command{
 	Short: "Revoke an API token.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		tokenName := args[0]
 
-		apiTokens, err := client.ApiTokens.List()
+		apiTokens, err := client.ApiTokens.List(ctx)
 		if err != nil {
 			return err
 		}
@@ -56,7 +57,7 @@ var revokeApiTokensCmd = &cobra.Command{
 		s := prompt.Spinner(fmt.Sprintf("Revoking API token %s... ", internal.Emph(tokenName)))
 		defer s.Stop()
 
-		if err := client.ApiTokens.Revoke(tokenName); err != nil {
+		if err := client.ApiTokens.Revoke(ctx, tokenName); err != nil {
 			return err
 		}
 		s.Stop()
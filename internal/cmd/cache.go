@@ -12,12 +12,22 @@ const (
 	DB_CACHE_TTL_SECONDS = 30 * 60
 )
 
+// databasesCacheKey scopes the databases cache by organization, since a
+// project file can pin an organization other than the one switched to.
+func databasesCacheKey() string {
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return DB_CACHE_KEY
+	}
+	return orgKey(config.Organization(), DB_CACHE_KEY)
+}
+
 func setDatabasesCache(dbNames []turso.Database) {
-	settings.SetCache(DB_CACHE_KEY, DB_CACHE_TTL_SECONDS, dbNames)
+	settings.SetCache(databasesCacheKey(), DB_CACHE_TTL_SECONDS, dbNames)
 }
 
 func getDatabasesCache() []turso.Database {
-	data, err := settings.GetCache[[]turso.Database](DB_CACHE_KEY)
+	data, err := settings.GetCache[[]turso.Database](databasesCacheKey())
 	if err != nil {
 		return nil
 	}
@@ -25,7 +35,7 @@ func getDatabasesCache() []turso.Database {
 }
 
 func invalidateDatabasesCache() {
-	settings.InvalidateCache[[]turso.Database](DB_CACHE_KEY)
+	settings.InvalidateCache[[]turso.Database](databasesCacheKey())
 }
 
 const (
@@ -72,16 +82,23 @@ func tokenValidCache(token string) bool {
 	return err == nil && ok
 }
 
-const DATABASE_TOKEN_KEY_PREFIX = "database_token."
+// Database tokens are secrets, so they are cached in the credential store
+// rather than with the other caches in the settings file.
 
 func setDbTokenCache(dbID, token string, exp int64) {
-	key := DATABASE_TOKEN_KEY_PREFIX + dbID
-	settings.SetCacheWithExp(key, exp, token)
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return
+	}
+	config.SetDatabaseToken(dbID, token, exp)
 }
 
 func dbTokenCache(dbID string) string {
-	key := DATABASE_TOKEN_KEY_PREFIX + dbID
-	token, err := settings.GetCache[string](key)
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return ""
+	}
+	token, err := config.DatabaseToken(dbID)
 	if err != nil {
 		return ""
 	}
@@ -89,7 +106,11 @@ func dbTokenCache(dbID string) string {
 }
 
 func invalidateDbTokenCache() {
-	settings.SetCacheRaw(DATABASE_TOKEN_KEY_PREFIX[:len(DATABASE_TOKEN_KEY_PREFIX)-1], struct{}{})
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return
+	}
+	config.InvalidateDatabaseTokens()
 }
 
 const (
@@ -3,10 +3,12 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/tursodatabase/turso-cli/internal"
 	"github.com/tursodatabase/turso-cli/internal/settings"
+	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 func init() {
@@ -15,6 +17,9 @@ func init() {
 	configCmd.AddCommand(configSetCmd)
 	configSetCmd.AddCommand(configSetAutoUpdateCmd)
 	configSetCmd.AddCommand(configSetTokenCmd)
+	configSetCmd.AddCommand(configSetCABundleCmd)
+	configSetCmd.AddCommand(configSetClientCertCmd)
+	configSetCmd.AddCommand(configSetProxyCmd)
 
 	configCmd.AddCommand(configCacheCmd)
 	configCacheCmd.AddCommand(configCacheClearCmd)
@@ -68,6 +73,7 @@ var configSetTokenCmd = &cobra.Command{
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		config, err := settings.ReadSettings()
 		if err != nil {
@@ -75,11 +81,13 @@ var configSetTokenCmd = &cobra.Command{
 		}
 
 		token := args[0]
-		if !isJwtTokenValid(token) {
+		if !isJwtTokenValid(ctx, token) {
 			return errors.New("invalid token")
 		}
 
-		config.SetToken(token)
+		if err := config.SetToken(token); err != nil {
+			return fmt.Errorf("%w\nIf the issue persists, set your token to the %s environment variable instead", err, internal.Emph(ENV_ACCESS_TOKEN))
+		}
 		if err := settings.TryToPersistChanges(); err != nil {
 			return fmt.Errorf("%w\nIf the issue persists, set your token to the %s environment variable instead", err, internal.Emph(ENV_ACCESS_TOKEN))
 		}
@@ -88,6 +96,108 @@ var configSetTokenCmd = &cobra.Command{
 	},
 }
 
+var configSetCABundleCmd = &cobra.Command{
+	Use:   "ca-bundle <path>",
+	Short: "Trust the root certificates in a PEM file in addition to the system ones",
+	Long:  "Trust the root certificates in a PEM file in addition to the system ones.\nPass an empty path to stop using a custom bundle.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		path, err := absolutePath(args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := turso.NewHTTPClient(turso.TransportConfig{CABundle: path}); err != nil {
+			return err
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		config.SetCABundle(path)
+		if path == "" {
+			fmt.Println("CA bundle cleared.")
+			return nil
+		}
+		fmt.Println("CA bundle set to", internal.Emph(path))
+		return nil
+	},
+}
+
+var configSetClientCertCmd = &cobra.Command{
+	Use:   "client-cert <cert> <key>",
+	Short: "Present a client certificate to servers that require mutual TLS",
+	Long:  "Present a client certificate to servers that require mutual TLS.\nPass empty paths to stop using a client certificate.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cert, err := absolutePath(args[0])
+		if err != nil {
+			return err
+		}
+		key, err := absolutePath(args[1])
+		if err != nil {
+			return err
+		}
+		if _, err := turso.NewHTTPClient(turso.TransportConfig{ClientCert: cert, ClientKey: key}); err != nil {
+			return err
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		config.SetClientCertificate(cert, key)
+		if cert == "" {
+			fmt.Println("Client certificate cleared.")
+			return nil
+		}
+		fmt.Println("Client certificate set to", internal.Emph(cert))
+		return nil
+	},
+}
+
+var configSetProxyCmd = &cobra.Command{
+	Use:               "proxy <url>",
+	Short:             "Send every request through the given proxy",
+	Long:              "Send every request through the given proxy.\nPass an empty URL to fall back to the HTTP_PROXY and HTTPS_PROXY environment variables.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		proxy := args[0]
+		if _, err := turso.NewHTTPClient(turso.TransportConfig{Proxy: proxy}); err != nil {
+			return err
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		config.SetProxy(proxy)
+		if proxy == "" {
+			fmt.Println("Proxy cleared.")
+			return nil
+		}
+		fmt.Println("Proxy set to", internal.Emph(proxy))
+		return nil
+	},
+}
+
+// absolutePath resolves path against the working directory, so the stored
+// setting keeps working when the CLI is run from elsewhere.
+func absolutePath(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve path %s: %w", path, err)
+	}
+	return abs, nil
+}
+
 var configCacheCmd = &cobra.Command{
 	Use:   "cache",
 	Short: "Manage your CLI cache",
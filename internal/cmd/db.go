@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -19,8 +20,8 @@ var (
 	showBranchesFlag     bool
 )
 
-func getInstanceNames(client *turso.Client, dbName string) []string {
-	instances, err := client.Instances.List(dbName)
+func getInstanceNames(ctx context.Context, client *turso.Client, dbName string) []string {
+	instances, err := client.Instances.List(ctx, dbName)
 	if err != nil {
 		return nil
 	}
@@ -39,23 +40,23 @@ func extractDatabaseNames(databases []turso.Database) []string {
 	return names
 }
 
-func getDatabaseConfig(client *turso.Client, name string) (turso.DatabaseConfig, error) {
+func getDatabaseConfig(ctx context.Context, client *turso.Client, name string) (turso.DatabaseConfig, error) {
 	if !flags.V3Api() {
-		return client.Databases.GetConfig(name)
+		return client.Databases.GetConfig(ctx, name)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return turso.DatabaseConfig{}, err
 	}
-	dbID, err := tryResolveDbID(client, name)
+	dbID, err := tryResolveDbID(ctx, client, name)
 	if err != nil {
 		return turso.DatabaseConfig{}, err
 	}
-	return client.DatabasesV3.GetConfig(orgID, dbID)
+	return client.DatabasesV3.GetConfig(ctx, orgID, dbID)
 }
 
-func getDatabase(client *turso.Client, name string, fresh ...bool) (turso.Database, error) {
-	databases, err := getDatabases(client, fresh...)
+func getDatabase(ctx context.Context, client *turso.Client, name string, fresh ...bool) (turso.Database, error) {
+	databases, err := getDatabases(ctx, client, fresh...)
 	if err != nil {
 		return turso.Database{}, err
 	}
@@ -69,35 +70,35 @@ func getDatabase(client *turso.Client, name string, fresh ...bool) (turso.Databa
 	return turso.Database{}, fmt.Errorf("database %s not found. List known databases using %s", internal.Emph(name), internal.Emph("turso db list"))
 }
 
-func listDatabases(client *turso.Client) ([]turso.Database, error) {
+func listDatabases(ctx context.Context, client *turso.Client) ([]turso.Database, error) {
 	if !flags.V3Api() {
-		return listDatabasesV2(client)
+		return listDatabasesV2(ctx, client)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 	if orgID == "" {
-		return listDatabasesV2(client)
+		return listDatabasesV2(ctx, client)
 	}
-	databases, _, err := client.DatabasesV3.List(orgID, turso.DatabaseV3ListOptions{})
+	databases, _, err := client.DatabasesV3.List(ctx, orgID, turso.DatabaseV3ListOptions{})
 	return databases, err
 }
 
-func listDatabasesV2(client *turso.Client) ([]turso.Database, error) {
-	response, err := client.Databases.List(turso.DatabaseListOptions{})
+func listDatabasesV2(ctx context.Context, client *turso.Client) ([]turso.Database, error) {
+	response, err := client.Databases.List(ctx, turso.DatabaseListOptions{})
 	if err != nil {
 		return nil, err
 	}
 	return response.Databases, err
 }
 
-func getDatabases(client *turso.Client, fresh ...bool) ([]turso.Database, error) {
+func getDatabases(ctx context.Context, client *turso.Client, fresh ...bool) ([]turso.Database, error) {
 	skipCache := len(fresh) > 0 && fresh[0]
 	if cachedNames := getDatabasesCache(); !skipCache && cachedNames != nil {
 		return cachedNames, nil
 	}
-	databases, err := listDatabases(client)
+	databases, err := listDatabases(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +106,8 @@ func getDatabases(client *turso.Client, fresh ...bool) ([]turso.Database, error)
 	return databases, nil
 }
 
-func getDatabasesMap(client *turso.Client, fresh bool) (map[string]turso.Database, error) {
-	databases, err := getDatabases(client, fresh)
+func getDatabasesMap(ctx context.Context, client *turso.Client, fresh bool) (map[string]turso.Database, error) {
+	databases, err := getDatabases(ctx, client, fresh)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +118,8 @@ func getDatabasesMap(client *turso.Client, fresh bool) (map[string]turso.Databas
 	return databasesMap, nil
 }
 
-func getDatabaseNames(client *turso.Client) []string {
-	databases, err := getDatabases(client)
+func getDatabaseNames(ctx context.Context, client *turso.Client) []string {
+	databases, err := getDatabases(ctx, client)
 	if err != nil {
 		return []string{}
 	}
@@ -126,12 +127,13 @@ func getDatabaseNames(client *turso.Client) []string {
 }
 
 func completeInstanceName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 	if len(args) == 1 {
-		return getInstanceNames(client, args[0]), cobra.ShellCompDirectiveNoFileComp
+		return getInstanceNames(ctx, client, args[0]), cobra.ShellCompDirectiveNoFileComp
 	}
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
@@ -149,8 +151,8 @@ const ENV_ACCESS_TOKEN = "TURSO_API_TOKEN"
 
 var ErrNotLoggedIn = fmt.Errorf("user not logged in, please login with %s", internal.Emph("turso auth login"))
 
-func getAccessToken() (string, error) {
-	token, err := envAccessToken()
+func getAccessToken(ctx context.Context) (string, error) {
+	token, err := envAccessToken(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -163,36 +165,39 @@ func getAccessToken() (string, error) {
 		return "", fmt.Errorf("could not read token from settings file: %w", err)
 	}
 
-	token = settings.GetToken()
-	if !isJwtTokenValid(token) {
+	token, err = settings.GetToken()
+	if err != nil {
+		return "", fmt.Errorf("could not read token: %w", err)
+	}
+	if !isJwtTokenValid(ctx, token) {
 		return "", ErrNotLoggedIn
 	}
 
 	return token, nil
 }
 
-func envAccessToken() (string, error) {
+func envAccessToken(ctx context.Context) (string, error) {
 	token := os.Getenv(ENV_ACCESS_TOKEN)
 	if token == "" {
 		return "", nil
 	}
-	if !isJwtTokenValid(token) {
+	if !isJwtTokenValid(ctx, token) {
 		return "", fmt.Errorf("token in %s env var is invalid. Update the env var with a valid value, or unset it to use a token from the configuration file", ENV_ACCESS_TOKEN)
 	}
 	return token, nil
 }
 
-func locations(client *turso.Client) (map[string]string, error) {
+func locations(ctx context.Context, client *turso.Client) (map[string]string, error) {
 	settings, _ := settings.ReadSettings()
-	return readLocations(settings, client)
+	return readLocations(ctx, settings, client)
 }
 
-func readLocations(settings *settings.Settings, client *turso.Client) (map[string]string, error) {
+func readLocations(ctx context.Context, settings *settings.Settings, client *turso.Client) (map[string]string, error) {
 	if locations := locationsCache(); locations != nil {
 		return locations, nil
 	}
 
-	locationsMap, err := mapLocations(client)
+	locationsMap, err := mapLocations(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -208,20 +213,20 @@ func readLocations(settings *settings.Settings, client *turso.Client) (map[strin
 	return locations, nil
 }
 
-func mapLocations(client *turso.Client) (map[string]map[string]string, error) {
-	locations, err := client.Organizations.Locations()
+func mapLocations(ctx context.Context, client *turso.Client) (map[string]map[string]string, error) {
+	locations, err := client.Organizations.Locations(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return locations, nil
 }
 
-func closestLocation(client *turso.Client) (string, error) {
+func closestLocation(ctx context.Context, client *turso.Client) (string, error) {
 	if closest := closestLocationCache(); closest != "" {
 		return closest, nil
 	}
 
-	closest, err := client.Locations.Closest()
+	closest, err := client.Locations.Closest(ctx)
 	if err != nil {
 		// We fallback to ams if we are unable to probe the closest location.
 		return "ams", err
@@ -231,8 +236,8 @@ func closestLocation(client *turso.Client) (string, error) {
 	return closest, nil
 }
 
-func isValidLocation(client *turso.Client, location string) bool {
-	locations, err := locations(client)
+func isValidLocation(ctx context.Context, client *turso.Client, location string) bool {
+	locations, err := locations(ctx, client)
 	if err != nil {
 		return true
 	}
@@ -240,8 +245,8 @@ func isValidLocation(client *turso.Client, location string) bool {
 	return ok
 }
 
-func formatLocation(client *turso.Client, id string) string {
-	locations, _ := locations(client)
+func formatLocation(ctx context.Context, client *turso.Client, id string) string {
+	locations, _ := locations(ctx, client)
 	if desc, ok := locations[id]; ok {
 		return fmt.Sprintf("%s (%s)", desc, id)
 	}
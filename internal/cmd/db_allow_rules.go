@@ -18,8 +18,11 @@ var clearAllowRulesVpcsFlag bool
 func init() {
 	dbConfigCmd.AddCommand(dbAllowRulesCmd)
 	dbAllowRulesCmd.AddCommand(dbShowAllowRulesCmd)
+	allowProjectDatabase(dbShowAllowRulesCmd)
 	dbAllowRulesCmd.AddCommand(dbSetAllowRulesCmd)
+	allowProjectDatabase(dbSetAllowRulesCmd)
 	dbAllowRulesCmd.AddCommand(dbClearAllowRulesCmd)
+	allowProjectDatabase(dbClearAllowRulesCmd)
 	dbSetAllowRulesCmd.Flags().StringSliceVar(&allowRulesIPsFlag, "ip", nil, "IP address or CIDR block to allow. Can be repeated. Replaces the current list of allowed IPs.")
 	dbSetAllowRulesCmd.Flags().StringSliceVar(&allowRulesVpcsFlag, "aws-vpc", nil, "AWS VPC endpoint ID (vpce-...) to allow. Can be repeated. Replaces the current list of allowed VPC endpoints.")
 	dbClearAllowRulesCmd.Flags().BoolVar(&clearAllowRulesIPsFlag, "ips", false, "Clear only the list of allowed IPs")
@@ -41,16 +44,17 @@ var dbShowAllowRulesCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		database, err := getDatabase(client, args[0], true)
+		database, err := getDatabase(ctx, client, args[0], true)
 		if err != nil {
 			return err
 		}
-		config, err := getDatabaseConfig(client, database.Name)
+		config, err := getDatabaseConfig(ctx, client, database.Name)
 		if err != nil {
 			return err
 		}
@@ -70,6 +74,7 @@ var dbSetAllowRulesCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		setIPs := cmd.Flags().Changed("ip")
 		setVpcs := cmd.Flags().Changed("aws-vpc")
@@ -93,15 +98,15 @@ var dbSetAllowRulesCmd = &cobra.Command{
 			config.AllowedAwsVpcIDs = &vpcs
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		database, err := getDatabase(client, args[0], true)
+		database, err := getDatabase(ctx, client, args[0], true)
 		if err != nil {
 			return err
 		}
-		if err := client.Databases.UpdateConfig(database.Name, config); err != nil {
+		if err := client.Databases.UpdateConfig(ctx, database.Name, config); err != nil {
 			return err
 		}
 		fmt.Printf("Updated access allow rules for database %s\n", internal.Emph(database.Name))
@@ -116,6 +121,7 @@ var dbClearAllowRulesCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		clearIPs, clearVpcs := clearAllowRulesIPsFlag, clearAllowRulesVpcsFlag
 		if !clearIPs && !clearVpcs {
@@ -131,15 +137,15 @@ var dbClearAllowRulesCmd = &cobra.Command{
 			config.AllowedAwsVpcIDs = &empty
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		database, err := getDatabase(client, args[0], true)
+		database, err := getDatabase(ctx, client, args[0], true)
 		if err != nil {
 			return err
 		}
-		if err := client.Databases.UpdateConfig(database.Name, config); err != nil {
+		if err := client.Databases.UpdateConfig(ctx, database.Name, config); err != nil {
 			return err
 		}
 
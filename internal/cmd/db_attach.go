@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -16,8 +17,11 @@ func showAttachDeprecationNotice() {
 func init() {
 	dbConfigCmd.AddCommand(dbAttachCmd)
 	dbAttachCmd.AddCommand(dbEnableAttachCmd)
+	allowProjectDatabase(dbEnableAttachCmd)
 	dbAttachCmd.AddCommand(dbDisableAttachCmd)
+	allowProjectDatabase(dbDisableAttachCmd)
 	dbAttachCmd.AddCommand(dbShowAttachStatusCmd)
+	allowProjectDatabase(dbShowAttachStatusCmd)
 }
 
 var dbAttachCmd = &cobra.Command{
@@ -32,9 +36,10 @@ var dbEnableAttachCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showAttachDeprecationNotice()
 		cmd.SilenceUsage = true
-		return updateAttachStatus(args[0], true)
+		return updateAttachStatus(ctx, args[0], true)
 	},
 }
 
@@ -44,9 +49,10 @@ var dbDisableAttachCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showAttachDeprecationNotice()
 		cmd.SilenceUsage = true
-		return updateAttachStatus(args[0], false)
+		return updateAttachStatus(ctx, args[0], false)
 	},
 }
 
@@ -56,19 +62,20 @@ var dbShowAttachStatusCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showAttachDeprecationNotice()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		database, err := getDatabase(client, name, true)
+		database, err := getDatabase(ctx, client, name, true)
 		if err != nil {
 			return err
 		}
-		config, err := client.Databases.GetConfig(database.Name)
+		config, err := client.Databases.GetConfig(ctx, database.Name)
 		if err != nil {
 			return err
 		}
@@ -77,16 +84,16 @@ var dbShowAttachStatusCmd = &cobra.Command{
 	},
 }
 
-func updateAttachStatus(name string, allowAttach bool) error {
-	client, err := authedTursoClient()
+func updateAttachStatus(ctx context.Context, name string, allowAttach bool) error {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
-	database, err := getDatabase(client, name, true)
+	database, err := getDatabase(ctx, client, name, true)
 	if err != nil {
 		return err
 	}
-	return client.Databases.UpdateConfig(database.Name, turso.DatabaseConfig{AllowAttach: &allowAttach})
+	return client.Databases.UpdateConfig(ctx, database.Name, turso.DatabaseConfig{AllowAttach: &allowAttach})
 }
 
 func attachMessage(attach bool) string {
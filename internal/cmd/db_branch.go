@@ -24,14 +24,15 @@ var dbBranchCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: dbBranchArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		sourceName := args[0]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		sourceDB, err := getDatabase(client, sourceName, true)
+		sourceDB, err := getDatabase(ctx, client, sourceName, true)
 		if err != nil {
 			return err
 		}
@@ -56,7 +57,7 @@ var dbBranchCmd = &cobra.Command{
 			groupFlag = sourceDB.Group
 		}
 
-		return CreateDatabase(targetName)
+		return CreateDatabase(ctx, targetName)
 	},
 }
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"strings"
@@ -51,6 +52,7 @@ func init() {
 	addDbFromDumpFlag(createCmd)
 	addDbFromDumpURLFlag(createCmd)
 	addDbFromFileFlag(createCmd)
+	addUploadFlags(createCmd)
 	addDbFromCSVFlag(createCmd)
 	addCSVTableNameFlag(createCmd)
 	flags.AddCSVSeparator(createCmd)
@@ -77,22 +79,23 @@ var createCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		name, err := getDatabaseName(args)
 		if err != nil {
 			return err
 		}
-		return CreateDatabase(name)
+		return CreateDatabase(ctx, name)
 	},
 }
 
-func CreateDatabase(name string) error {
-	client, err := authedTursoClient()
+func CreateDatabase(ctx context.Context, name string) error {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	groups, err := listGroups(client)
+	groups, err := listGroups(ctx, client)
 	if err != nil {
 		return err
 	}
@@ -103,7 +106,7 @@ func CreateDatabase(name string) error {
 	}
 	groupName := group.Name
 
-	location, err := locationFromFlag(client, group, groups)
+	location, err := locationFromFlag(ctx, client, group, groups)
 	if err != nil {
 		return err
 	}
@@ -114,12 +117,12 @@ func CreateDatabase(name string) error {
 		return err
 	}
 
-	seed, err := parseDBSeedFlags(client, isAWS, remoteEncryptionCipherFlag)
+	seed, err := parseDBSeedFlags(ctx, client, isAWS, remoteEncryptionCipherFlag)
 	if err != nil {
 		return err
 	}
 
-	if err := ensureGroup(client, groupName, groups, location, "latest"); err != nil {
+	if err := ensureGroup(ctx, client, groupName, groups, location, "latest"); err != nil {
 		return err
 	}
 
@@ -127,7 +130,10 @@ func CreateDatabase(name string) error {
 	spinner := prompt.Spinner(fmt.Sprintf("Creating database %s in group %s...", internal.Emph(name), internal.Emph(groupName)))
 	defer spinner.Stop()
 
-	if err := createDatabase(client, name, location, groupName, seed, spinner); err != nil {
+	if err := createDatabase(ctx, client, name, location, groupName, seed, spinner); err != nil {
+		if fromFileFlag != "" && turso.ImportInProgress(fromFileFlag) {
+			return fmt.Errorf("could not create database %s: %w\nThe draft database was kept, continue the upload with %s", name, err, internal.Emph("turso db import --resume "+fromFileFlag))
+		}
 		return fmt.Errorf("could not create database %s: %w", name, err)
 	}
 
@@ -145,46 +151,46 @@ func CreateDatabase(name string) error {
 	return nil
 }
 
-func createDatabase(client *turso.Client, name, location, groupName string, seed *turso.DBSeed, spinner *prompt.SpinnerT) error {
+func createDatabase(ctx context.Context, client *turso.Client, name, location, groupName string, seed *turso.DBSeed, spinner *prompt.SpinnerT) error {
 	if !flags.V3Api() {
-		return createDatabaseV2(client, name, location, groupName, seed, spinner)
+		return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 	}
 	if schemaFlag != "" || typeFlag == "schema" {
-		return createDatabaseV2(client, name, location, groupName, seed, spinner)
+		return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 	}
 	if sizeLimitFlag != "" {
-		return createDatabaseV2(client, name, location, groupName, seed, spinner)
+		return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 	}
 	if seed != nil && seed.Type != "database" && seed.Type != "upload" {
-		return createDatabaseV2(client, name, location, groupName, seed, spinner)
+		return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return err
 	}
 	if orgID == "" {
-		return createDatabaseV2(client, name, location, groupName, seed, spinner)
+		return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 	}
 	body := CreateDatabaseV3BodyFromFlags(name, seed)
 	if seed == nil || seed.Type != "database" {
-		groupID, err := tryResolveGroupID(client, groupName)
+		groupID, err := tryResolveGroupID(ctx, client, groupName)
 		if err != nil {
 			return err
 		}
 		if groupID == "" {
-			return createDatabaseV2(client, name, location, groupName, seed, spinner)
+			return createDatabaseV2(ctx, client, name, location, groupName, seed, spinner)
 		}
 		body.GroupID = groupID
 	} else if seed.Type == "database" {
-		_, err := client.DatabasesV3.Create(orgID, body)
+		_, err := client.DatabasesV3.Create(ctx, orgID, body)
 		return err
 	}
-	_, err = client.DatabasesV3.Create(orgID, body)
+	_, err = client.DatabasesV3.Create(ctx, orgID, body)
 	return err
 }
 
-func createDatabaseV2(client *turso.Client, name, location, groupName string, seed *turso.DBSeed, spinner *prompt.SpinnerT) error {
-	_, err := client.Databases.Create(name, location, "", "", groupName, schemaFlag, typeFlag == "schema", seed, sizeLimitFlag, remoteEncryptionCipherFlag, remoteEncryptionKeyFlag(), tursoDBFlag, spinner)
+func createDatabaseV2(ctx context.Context, client *turso.Client, name, location, groupName string, seed *turso.DBSeed, spinner *prompt.SpinnerT) error {
+	_, err := client.Databases.Create(ctx, name, location, "", "", groupName, schemaFlag, typeFlag == "schema", seed, sizeLimitFlag, remoteEncryptionCipherFlag, remoteEncryptionKeyFlag(), tursoDBFlag, spinner)
 	return err
 }
 
@@ -203,20 +209,23 @@ func CreateDatabaseV3BodyFromFlags(name string, seed *turso.DBSeed) turso.Create
 	return body
 }
 
-func ensureGroup(client *turso.Client, group string, groups []turso.Group, location, version string) error {
+func ensureGroup(ctx context.Context, client *turso.Client, group string, groups []turso.Group, location, version string) error {
 	if !shouldAutoCreateGroup(group, groups) {
 		return nil
 	}
-	if err := createGroup(client, group, location, version); err != nil {
+	if err := createGroup(ctx, client, group, location, version); err != nil {
 		return err
 	}
-	return client.Groups.WaitLocation(group, location)
+	return client.Groups.WaitLocation(ctx, group, location)
 }
 
 func getDatabaseName(args []string) (string, error) {
 	if len(args) > 0 && len(args[0]) > 0 {
 		return args[0], nil
 	}
+	if database := projectContext().Database; database != "" {
+		return database, nil
+	}
 
 	rng, err := codename.DefaultRNG()
 	if err != nil {
@@ -227,17 +236,21 @@ func getDatabaseName(args []string) (string, error) {
 
 // Returns (group, error)
 func groupFromFlag(groups []turso.Group) (turso.Group, error) {
+	name := groupFlag
+	if name == "" {
+		name = projectContext().Group
+	}
 
-	if groupFlag != "" {
-		if !groupExists(groups, groupFlag) {
-			return turso.Group{}, fmt.Errorf("group %s does not exist. Please double-check the name. You can run 'turso group list' to get a list of your groups, or 'turso group create' to make a new one", groupFlag)
+	if name != "" {
+		if !groupExists(groups, name) {
+			return turso.Group{}, fmt.Errorf("group %s does not exist. Please double-check the name. You can run 'turso group list' to get a list of your groups, or 'turso group create' to make a new one", name)
 		}
 		for _, group := range groups {
-			if group.Name == groupFlag {
+			if group.Name == name {
 				return group, nil
 			}
 		}
-		return turso.Group{}, fmt.Errorf("group %s does not exist. Please double-check the name. You can run 'turso group list' to get a list of your groups, or 'turso group create' to make a new one", groupFlag)
+		return turso.Group{}, fmt.Errorf("group %s does not exist. Please double-check the name. You can run 'turso group list' to get a list of your groups, or 'turso group create' to make a new one", name)
 	}
 
 	switch {
@@ -260,12 +273,15 @@ func groupExists(groups []turso.Group, name string) bool {
 	return false
 }
 
-func locationFromFlag(client *turso.Client, group turso.Group, groups []turso.Group) (string, error) {
+func locationFromFlag(ctx context.Context, client *turso.Client, group turso.Group, groups []turso.Group) (string, error) {
 	loc := locationFlag
+	if loc == "" {
+		loc = projectContext().Location
+	}
 	groupWillBeAutoCreated := shouldAutoCreateGroup(group.Name, groups)
 	if loc == "" {
 		if groupWillBeAutoCreated {
-			loc, _ = closestLocation(client)
+			loc, _ = closestLocation(ctx, client)
 		} else {
 			loc = group.Primary
 		}
@@ -283,7 +299,7 @@ func locationFromFlag(client *turso.Client, group turso.Group, groups []turso.Gr
 
 		return loc, nil
 	}
-	if !isValidLocation(client, loc) {
+	if !isValidLocation(ctx, client, loc) {
 		return "", fmt.Errorf("location '%s' is not valid", loc)
 	}
 	return loc, nil
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -11,8 +12,11 @@ import (
 func init() {
 	dbConfigCmd.AddCommand(dbDeleteProtectionCmd)
 	dbDeleteProtectionCmd.AddCommand(dbEnableDeleteProtectionCmd)
+	allowProjectDatabase(dbEnableDeleteProtectionCmd)
 	dbDeleteProtectionCmd.AddCommand(dbDisableDeleteProtectionCmd)
+	allowProjectDatabase(dbDisableDeleteProtectionCmd)
 	dbDeleteProtectionCmd.AddCommand(dbShowDeleteProtectionCmd)
+	allowProjectDatabase(dbShowDeleteProtectionCmd)
 }
 
 var dbDeleteProtectionCmd = &cobra.Command{
@@ -27,8 +31,9 @@ var dbEnableDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		return updateDeleteProtection(args[0], true)
+		return updateDeleteProtection(ctx, args[0], true)
 	},
 }
 
@@ -38,8 +43,9 @@ var dbDisableDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		return updateDeleteProtection(args[0], false)
+		return updateDeleteProtection(ctx, args[0], false)
 	},
 }
 
@@ -49,18 +55,19 @@ var dbShowDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		database, err := getDatabase(client, name, true)
+		database, err := getDatabase(ctx, client, name, true)
 		if err != nil {
 			return err
 		}
-		config, err := client.Databases.GetConfig(database.Name)
+		config, err := client.Databases.GetConfig(ctx, database.Name)
 		if err != nil {
 			return err
 		}
@@ -69,16 +76,16 @@ var dbShowDeleteProtectionCmd = &cobra.Command{
 	},
 }
 
-func updateDeleteProtection(name string, deleteProtection bool) error {
-	client, err := authedTursoClient()
+func updateDeleteProtection(ctx context.Context, name string, deleteProtection bool) error {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
-	database, err := getDatabase(client, name, true)
+	database, err := getDatabase(ctx, client, name, true)
 	if err != nil {
 		return err
 	}
-	return client.Databases.UpdateConfig(database.Name, turso.DatabaseConfig{DeleteProtection: &deleteProtection})
+	return client.Databases.UpdateConfig(ctx, database.Name, turso.DatabaseConfig{DeleteProtection: &deleteProtection})
 }
 
 func deleteProtectionMessage(status bool) string {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -24,25 +25,26 @@ var destroyCmd = &cobra.Command{
 	Args:              cobra.MinimumNArgs(1),
 	ValidArgsFunction: dbNameListArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		if len(args) > 1 {
-			return handleDestroyMultipleDBs(args, client)
+			return handleDestroyMultipleDBs(ctx, args, client)
 		}
 
-		return handleDestroySingleDB(args, client)
+		return handleDestroySingleDB(ctx, args, client)
 	},
 }
 
-func handleDestroySingleDB(args []string, client *turso.Client) error {
+func handleDestroySingleDB(ctx context.Context, args []string, client *turso.Client) error {
 	name := args[0]
 
-	db, err := getDatabase(client, name)
+	db, err := getDatabase(ctx, client, name)
 	if err != nil {
 		return nil
 	}
@@ -51,18 +53,18 @@ func handleDestroySingleDB(args []string, client *turso.Client) error {
 		if db.Group != "" {
 			return fmt.Errorf("group databases do not support instance destruction.\nUse %s instead", internal.Emph("turso group locations rm "+name))
 		}
-		return destroyDatabaseInstance(client, name, instanceFlag)
+		return destroyDatabaseInstance(ctx, client, name, instanceFlag)
 	}
 
 	if locationFlag != "" {
 		if db.Group != "" {
 			return fmt.Errorf("group databases do not support location destruction.\nUse %s instead", internal.Emph("turso group locations rm "+name+" "+locationFlag))
 		}
-		return destroyDatabaseRegion(client, name, locationFlag)
+		return destroyDatabaseRegion(ctx, client, name, locationFlag)
 	}
 
 	if yesFlag {
-		return destroyDatabases(client, args)
+		return destroyDatabases(ctx, client, args)
 	}
 
 	fmt.Printf("Database %s and all its data will be destroyed.\n", internal.Emph(name))
@@ -77,16 +79,16 @@ func handleDestroySingleDB(args []string, client *turso.Client) error {
 		return nil
 	}
 
-	return destroyDatabases(client, args)
+	return destroyDatabases(ctx, client, args)
 }
 
-func handleDestroyMultipleDBs(args []string, client *turso.Client) error {
+func handleDestroyMultipleDBs(ctx context.Context, args []string, client *turso.Client) error {
 	if instanceFlag != "" || locationFlag != "" {
 		return errors.New("can not use location nor instance flag when deleting more than 1 database")
 	}
 
 	if yesFlag {
-		return destroyDatabases(client, args)
+		return destroyDatabases(ctx, client, args)
 	}
 
 	fmt.Printf("Databases %s and all their data will be destroyed.\n", internal.Emph(strings.Join(args, ", ")))
@@ -101,5 +103,5 @@ func handleDestroyMultipleDBs(args []string, client *turso.Client) error {
 		return nil
 	}
 
-	return destroyDatabases(client, args)
+	return destroyDatabases(ctx, client, args)
 }
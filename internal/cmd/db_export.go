@@ -1,15 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 var withMetadata bool
 var overwriteExport bool
 var outputFile string
+var resumeExport bool
+var checkpointExport bool
+var verifyExportFlag bool
+var archiveExport string
+var exportTo string
+var s3EndpointFlag string
 
 var exportCmd = &cobra.Command{
 	Use:   "export <database>",
@@ -18,49 +29,125 @@ var exportCmd = &cobra.Command{
 
 This command exports a snapshot of the current generation of a Turso database
 to a local SQLite file, along with any WAL (Write-Ahead Log) frames. The WAL
-file will be saved as <database>.db-wal alongside the main database file.`,
+file will be saved as <database>.db-wal alongside the main database file.
+
+Progress is saved to <database>.db-export-state while exporting. If the export
+is interrupted, run the command again with --resume to continue it.
+
+With --checkpoint, the WAL frames are applied to the database file, which is
+then a complete database on its own, in rollback journal mode.
+
+With --verify, the export is checked once written, as with
+` + "`turso db verify-export`" + `.
+
+With --archive, the files are bundled in a .tar.gz, .tar.zst or .tar archive
+with a manifest, compressed according to its name and optionally encrypted
+with --passphrase-file or --recipient. The archive can be imported with
+` + "`turso db import`" + `.
+
+With --to s3://bucket/prefix, the files are uploaded to S3-compatible object
+storage as they are downloaded, without being written to disk. Credentials
+are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, and the region
+from AWS_REGION. Use --s3-endpoint or AWS_ENDPOINT_URL_S3 for storage other
+than AWS S3.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		dbName := args[0]
+		if exportTo != "" {
+			return exportS3Cmd(ctx, dbName)
+		}
+		if s3EndpointFlag != "" {
+			return fmt.Errorf("%s can only be used with %s", internal.Emph("--s3-endpoint"), internal.Emph("--to"))
+		}
+		if archiveExport != "" {
+			return exportArchiveCmd(ctx, dbName)
+		}
+		if archivePassphraseFileFlag != "" || len(archiveRecipientFlag) > 0 {
+			return fmt.Errorf("%s and %s can only be used with %s", internal.Emph("--passphrase-file"), internal.Emph("--recipient"), internal.Emph("--archive"))
+		}
 		if outputFile == "" {
 			outputFile = dbName + ".db"
 		}
-		err := ExportDatabase(dbName, outputFile, withMetadata, overwriteExport)
+		spinner := prompt.Spinner(fmt.Sprintf("Exporting %s...", internal.Emph(dbName)))
+		result, err := ExportDatabase(ctx, dbName, outputFile, exportOptions(dbName, spinner), overwriteExport)
+		spinner.Stop()
 		if err != nil {
+			if turso.ExportInProgress(outputFile) {
+				return fmt.Errorf("failed to export database: %w\nRun the command again with %s to continue the export", err, internal.Emph("--resume"))
+			}
 			return fmt.Errorf("failed to export database: %w", err)
 		}
+		switch {
+		case result.Resumed:
+			fmt.Println("Resumed the interrupted export.")
+		case result.Restarted:
+			fmt.Println("The database changed since the interrupted export started, so it was exported again.")
+		}
 		fmt.Printf("Exported database to %s\n", outputFile)
+		if checkpointExport {
+			fmt.Printf("Applied %d WAL frames to the database file.\n", result.CheckpointedFrames)
+		}
+		if verifyExportFlag {
+			return verifyExport(outputFile)
+		}
 		return nil
 	},
 }
 
-func ExportDatabase(dbName, outputFile string, withMetadata bool, overwrite bool) error {
-	if !overwrite {
-		if _, err := os.Stat(outputFile); err == nil {
-			return fmt.Errorf("file %s already exists, use `--overwrite` flag to overwrite it", outputFile)
-		}
-	}
-	client, err := authedTursoClient()
+func ExportDatabase(ctx context.Context, dbName, outputFile string, opts turso.ExportOptions, overwrite bool) (turso.ExportResult, error) {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
-		return err
+		return turso.ExportResult{}, err
 	}
-	db, err := getDatabase(client, dbName)
+	db, err := getDatabase(ctx, client, dbName)
 	if err != nil {
-		return fmt.Errorf("failed to find database: %w", err)
+		return turso.ExportResult{}, fmt.Errorf("failed to find database: %w", err)
 	}
 	dbUrl := getDatabaseHttpUrl(&db)
-	err = client.Databases.Export(dbName, dbUrl, outputFile, withMetadata, overwrite, remoteEncryptionKeyFlag())
-	if err != nil {
-		return err
+	return client.Databases.Export(ctx, dbName, dbUrl, outputFile, overwrite, opts)
+}
+
+func exportOptions(dbName string, spinner *prompt.SpinnerT) turso.ExportOptions {
+	return turso.ExportOptions{
+		WithMetadata:        withMetadata,
+		RemoteEncryptionKey: remoteEncryptionKeyFlag(),
+		Resume:              resumeExport,
+		Checkpoint:          checkpointExport,
+		OnProgress: func(progress turso.ExportProgress) {
+			spinner.Text(exportProgressText(dbName, progress))
+		},
+	}
+}
+
+func exportProgressText(dbName string, progress turso.ExportProgress) string {
+	elapsed := progress.Elapsed.Round(time.Second)
+	if progress.Frames == 0 && progress.SnapshotBytes < progress.SnapshotSize {
+		text := fmt.Sprintf("Exporting %s, %d%% of the snapshot downloaded (%s/%s)", internal.Emph(dbName),
+			progress.SnapshotBytes*100/progress.SnapshotSize, humanize.Bytes(uint64(progress.SnapshotBytes)), humanize.Bytes(uint64(progress.SnapshotSize)))
+		if progress.ETA > 0 {
+			return fmt.Sprintf("%s, %s left (elapsed %s)", text, progress.ETA.Round(time.Second), elapsed)
+		}
+		return fmt.Sprintf("%s (elapsed %s)", text, elapsed)
 	}
-	return nil
+	return fmt.Sprintf("Exporting %s, %d WAL frames exported (%s downloaded) (elapsed %s)", internal.Emph(dbName),
+		progress.Frames, humanize.Bytes(uint64(progress.WALBytes)), elapsed)
 }
 
 func init() {
 	exportCmd.Flags().BoolVar(&withMetadata, "with-metadata", false, "Include metadata in the export.")
 	exportCmd.Flags().BoolVar(&overwriteExport, "overwrite", false, "Overwrite output file if it exists.")
+	exportCmd.Flags().BoolVar(&checkpointExport, "checkpoint", false, "Apply the WAL to the database file, to export a single file.")
+	exportCmd.Flags().BoolVar(&verifyExportFlag, "verify", false, "Check the integrity of the export once written.")
+	exportCmd.Flags().BoolVar(&resumeExport, "resume", false, "Continue an interrupted export to the output file.")
 	exportCmd.Flags().StringVar(&outputFile, "output-file", "", "Specify the output file name (default: <database>.db)")
+	exportCmd.Flags().StringVar(&archiveExport, "archive", "", "Bundle the export in a .tar.gz, .tar.zst or .tar archive with a manifest")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Stream the export to object storage, as s3://bucket/prefix")
+	exportCmd.Flags().StringVar(&s3EndpointFlag, "s3-endpoint", "", "URL of an S3-compatible object storage, such as http://localhost:9000 (default: AWS S3)")
+	addArchivePassphraseFileFlag(exportCmd)
+	addArchiveRecipientFlag(exportCmd)
 	addRemoteEncryptionKeyFlag(exportCmd)
 	dbCmd.AddCommand(exportCmd)
+	allowProjectDatabase(exportCmd)
 }
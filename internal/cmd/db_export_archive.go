@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/archive"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+)
+
+// exportArchiveCmd runs turso db export --archive: the database is exported
+// to a temporary directory next to the archive, which is removed once the
+// archive is written.
+func exportArchiveCmd(ctx context.Context, dbName string) error {
+	if outputFile != "" || resumeExport {
+		return fmt.Errorf("%s can't be used with %s or %s", internal.Emph("--archive"), internal.Emph("--output-file"), internal.Emph("--resume"))
+	}
+	if !overwriteExport {
+		if _, err := os.Stat(archiveExport); err == nil {
+			return fmt.Errorf("file %s already exists, use `--overwrite` flag to overwrite it", archiveExport)
+		}
+	}
+	recipients, err := archiveRecipients()
+	if err != nil {
+		return err
+	}
+
+	client, err := authedTursoClient(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := getDatabase(ctx, client, dbName)
+	if err != nil {
+		return fmt.Errorf("failed to find database: %w", err)
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(archiveExport), ".turso-export-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	dbFile := filepath.Join(dir, dbName+".db")
+
+	manifest := &archive.Manifest{
+		Database:        db.Name,
+		DatabaseID:      db.ID,
+		Group:           db.Group,
+		ExportStartedAt: time.Now().UTC(),
+	}
+	spinner := prompt.Spinner(fmt.Sprintf("Exporting %s...", internal.Emph(dbName)))
+	defer spinner.Stop()
+	result, err := client.Databases.Export(ctx, dbName, getDatabaseHttpUrl(&db), dbFile, true, exportOptions(dbName, spinner))
+	if err != nil {
+		return fmt.Errorf("failed to export database: %w", err)
+	}
+	manifest.Generation = result.Generation
+	manifest.FrameNo = result.LastFrameNo
+	manifest.ExportedAt = time.Now().UTC()
+
+	if verifyExportFlag {
+		if _, err := checkExport(dbFile); err != nil {
+			return err
+		}
+	}
+
+	files := map[string]string{archive.TypeDatabase: dbFile}
+	for fileType, file := range map[string]string{archive.TypeWAL: dbFile + "-wal", archive.TypeMetadata: dbFile + "-info"} {
+		if _, err := os.Stat(file); err == nil {
+			files[fileType] = file
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	spinner.Text(fmt.Sprintf("Writing archive %s...", archiveExport))
+	if err := archive.Create(archiveExport, manifest, files, recipients...); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	spinner.Stop()
+
+	fmt.Printf("Exported database %s to %s, up to frame %d of generation %d.\n", internal.Emph(dbName), archiveExport, manifest.FrameNo, manifest.Generation)
+	if verifyExportFlag {
+		fmt.Println("The export was verified before archiving it.")
+	}
+	if len(recipients) > 0 {
+		fmt.Println("The archive is encrypted.")
+	}
+	return nil
+}
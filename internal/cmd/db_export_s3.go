@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/s3"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// exportS3Cmd runs turso db export --to: the files of the export are
+// streamed to <prefix><database>.db, .db-wal and .db-info in the bucket.
+func exportS3Cmd(ctx context.Context, dbName string) error {
+	if outputFile != "" || archiveExport != "" || resumeExport || checkpointExport || verifyExportFlag {
+		return fmt.Errorf("%s can't be used with %s, %s, %s, %s or %s", internal.Emph("--to"),
+			internal.Emph("--output-file"), internal.Emph("--archive"), internal.Emph("--resume"), internal.Emph("--checkpoint"), internal.Emph("--verify"))
+	}
+	bucket, prefix, err := s3.ParseURL(exportTo)
+	if err != nil {
+		return err
+	}
+	config := s3.ConfigFromEnv()
+	if s3EndpointFlag != "" {
+		config.Endpoint = s3EndpointFlag
+	}
+	httpClient, err := sharedHTTPClient()
+	if err != nil {
+		return err
+	}
+	storage, err := s3.New(config, httpClient)
+	if err != nil {
+		return fmt.Errorf("could not configure object storage: %w", err)
+	}
+	key := prefix + dbName + ".db"
+	if !overwriteExport {
+		exists, err := storage.ObjectExists(ctx, bucket, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("s3://%s/%s already exists, use `--overwrite` flag to overwrite it", bucket, key)
+		}
+	}
+
+	client, err := authedTursoClient(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := getDatabase(ctx, client, dbName)
+	if err != nil {
+		return fmt.Errorf("failed to find database: %w", err)
+	}
+
+	spinner := prompt.Spinner(fmt.Sprintf("Exporting %s...", internal.Emph(dbName)))
+	defer spinner.Stop()
+	uploaded := map[string]int64{}
+	result, err := client.Databases.ExportStream(ctx, dbName, getDatabaseHttpUrl(&db), exportOptions(dbName, spinner), func(suffix string) (turso.ExportObject, error) {
+		return &s3Object{Writer: storage.NewWriter(ctx, bucket, key+suffix), uploaded: uploaded, suffix: suffix}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export database: %w", err)
+	}
+	spinner.Stop()
+
+	fmt.Printf("Exported database %s to s3://%s/%s, up to frame %d of generation %d.\n", internal.Emph(dbName), bucket, key, result.LastFrameNo, result.Generation)
+	for _, suffix := range []string{"", "-wal", "-info"} {
+		if size, ok := uploaded[suffix]; ok {
+			fmt.Printf("  %s%s: %s\n", key, suffix, humanize.Bytes(uint64(size)))
+		}
+	}
+	return nil
+}
+
+// s3Object records the size of each object once uploaded.
+type s3Object struct {
+	*s3.Writer
+	uploaded map[string]int64
+	suffix   string
+}
+
+func (o *s3Object) Close() error {
+	if err := o.Writer.Close(); err != nil {
+		return err
+	}
+	o.uploaded[o.suffix] = o.Size()
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -15,6 +16,7 @@ var (
 
 func init() {
 	dbTokensCmd.AddCommand(dbGenerateTokenCmd)
+	allowProjectDatabase(dbGenerateTokenCmd)
 
 	flags.AddExpiration(dbGenerateTokenCmd)
 	flags.AddReadOnly(dbGenerateTokenCmd)
@@ -29,14 +31,15 @@ var dbGenerateTokenCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		database, err := getDatabase(client, name, true)
+		database, err := getDatabase(ctx, client, name, true)
 		if err != nil {
 			return err
 		}
@@ -48,7 +51,7 @@ var dbGenerateTokenCmd = &cobra.Command{
 
 		var claim *turso.PermissionsClaim
 		if len(flags.AttachClaims()) > 0 {
-			err := validateDBNames(client, flags.AttachClaims())
+			err := validateDBNames(ctx, client, flags.AttachClaims())
 			if err != nil {
 				return err
 			}
@@ -60,9 +63,9 @@ var dbGenerateTokenCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		token, err := getToken(client, database, expiration, flags.ReadOnly(), groupTokenFlag, claim, permissions)
+		token, err := getToken(ctx, client, database, expiration, flags.ReadOnly(), groupTokenFlag, claim, permissions)
 		if err != nil {
-			return fmt.Errorf("failed to generate database token: %v", err)
+			return fmt.Errorf("failed to generate database token: %w", err)
 		}
 		fmt.Println(token)
 		return nil
@@ -70,6 +73,7 @@ var dbGenerateTokenCmd = &cobra.Command{
 }
 
 func getToken(
+	ctx context.Context,
 	client *turso.Client,
 	database turso.Database,
 	expiration string,
@@ -81,26 +85,27 @@ func getToken(
 		if database.Group == "" {
 			return "", errors.New("--group flag can only be set with group databases")
 		}
-		return getGroupToken(client, turso.Group{Name: database.Group}, expiration, readOnly, claim, fineGrainedPermissions)
+		return getGroupToken(ctx, client, turso.Group{Name: database.Group}, expiration, readOnly, claim, fineGrainedPermissions)
 	}
 	if !flags.V3Api() {
-		return getTokenV2(client, database, expiration, readOnly, claim, fineGrainedPermissions)
+		return getTokenV2(ctx, client, database, expiration, readOnly, claim, fineGrainedPermissions)
 	}
 	if claim != nil {
-		return getTokenV2(client, database, expiration, readOnly, claim, fineGrainedPermissions)
+		return getTokenV2(ctx, client, database, expiration, readOnly, claim, fineGrainedPermissions)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return "", err
 	}
 	dbID := database.ID
 	if orgID == "" || dbID == "" {
-		return getTokenV2(client, database, expiration, readOnly, claim, fineGrainedPermissions)
+		return getTokenV2(ctx, client, database, expiration, readOnly, claim, fineGrainedPermissions)
 	}
-	return client.DatabasesV3.Token(orgID, dbID, expiration, readOnly, fineGrainedPermissions)
+	return client.DatabasesV3.Token(ctx, orgID, dbID, expiration, readOnly, fineGrainedPermissions)
 }
 
 func getTokenV2(
+	ctx context.Context,
 	client *turso.Client,
 	database turso.Database,
 	expiration string,
@@ -108,5 +113,5 @@ func getTokenV2(
 	claim *turso.PermissionsClaim,
 	fineGrainedPermissions []flags.FineGrainedPermissions,
 ) (string, error) {
-	return client.Databases.Token(database.Name, expiration, readOnly, claim, fineGrainedPermissions)
+	return client.Databases.Token(ctx, database.Name, expiration, readOnly, claim, fineGrainedPermissions)
 }
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/archive"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/sqlitefile"
+	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 func init() {
@@ -14,17 +22,44 @@ func init() {
 	addGroupFlag(importCmd)
 	addRemoteEncryptionKeyFlag(importCmd)
 	addRemoteEncryptionCipherFlag(importCmd)
+	addUploadFlags(importCmd)
+	addArchivePassphraseFileFlag(importCmd)
+	addArchiveIdentityFlag(importCmd)
+	importCmd.Flags().BoolVar(&resumeImport, "resume", false, "Continue the interrupted upload of the file.")
+	importCmd.Flags().BoolVar(&prepareImport, "prepare", false, "Import a copy of the file converted to the required settings.")
 }
 
+var (
+	resumeImport  bool
+	prepareImport bool
+)
+
 var importCmd = &cobra.Command{
-	Use:               "import [filename]",
-	Short:             "Import a SQLite database file to Turso.",
+	Use:   "import [filename]",
+	Short: "Import a SQLite database file to Turso.",
+	Long: `Import a SQLite database file to Turso.
+
+The file can also be an archive written by ` + "`turso db export --archive`" + `, which is
+imported as the database it was exported from. Use --passphrase-file or
+--identity to decrypt an encrypted archive.
+
+The progress of the upload is saved to <filename>-import-state. If the upload
+is interrupted, the draft database is kept, and running the command again
+with --resume continues the upload with the next chunk.
+
+Chunks are uploaded --upload-concurrency at once. --max-bandwidth limits the
+upload rate across all of them, e.g. --max-bandwidth 10mb for 10 MB/s.
+
+With --prepare, a temporary copy of the file is converted to WAL mode, 4 KiB
+pages, no auto-vacuum, UTF-8 and the reserved bytes required by
+--remote-encryption-cipher, and imported instead. The file is not modified.`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: noFilesArg,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		if len(args) == 0 {
 			return errors.New("filename is required: 'turso db import <filename>'")
@@ -34,6 +69,25 @@ var importCmd = &cobra.Command{
 		if err := checkFileExists(filename); err != nil {
 			return err
 		}
+		isArchive, err := archive.IsArchive(filename)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", filename, err)
+		}
+		if resumeImport && prepareImport {
+			return fmt.Errorf("%s can't be used with %s, the prepared copy is not kept", internal.Emph("--prepare"), internal.Emph("--resume"))
+		}
+		if isArchive {
+			if prepareImport {
+				return fmt.Errorf("%s can't be used with archives, their database is already prepared", internal.Emph("--prepare"))
+			}
+			if resumeImport {
+				return fmt.Errorf("%s can't be used with archives, the extracted database is not kept", internal.Emph("--resume"))
+			}
+			return importArchive(ctx, filename)
+		}
+		if resumeImport {
+			return resumeImportCmd(ctx, filename)
+		}
 		locked, err := isFileLocked(filename)
 		if err != nil {
 			return fmt.Errorf("could not check file lock: %w", err)
@@ -42,12 +96,149 @@ var importCmd = &cobra.Command{
 			return errors.New("database file is locked by another process (close any open connections and try again)")
 		}
 
+		if prepareImport {
+			return importPrepared(ctx, filename)
+		}
 		fromFileFlag = filename
 		name := sanitizeDatabaseName(filename)
-		return CreateDatabase(name)
+		return CreateDatabase(ctx, name)
 	},
 }
 
+// importPrepared imports a copy of filename converted to the settings
+// required to import it, under the name of filename.
+func importPrepared(ctx context.Context, filename string) error {
+	dir, err := os.MkdirTemp("", "turso-import-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	prepared, changes, err := prepareDatabaseFile(filename, dir, remoteEncryptionCipherFlag)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Printf("Database %s already has the required settings, importing it as is.\n\n", internal.Emph(filename))
+	} else {
+		fmt.Printf("Prepared a copy of %s to import:\n", internal.Emph(filename))
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+		fmt.Println()
+		fromFileIsTemporary = true
+	}
+	fromFileFlag = prepared
+	return CreateDatabase(ctx, sanitizeDatabaseName(filename))
+}
+
+// prepareDatabaseFile copies file to dir and converts the copy to the
+// settings required to import it with cipher, which may be empty. It returns
+// the path of the copy and the changes made, or file itself when it needs no
+// change.
+func prepareDatabaseFile(file, dir, cipher string) (string, []sqlitefile.Change, error) {
+	// The reserved bytes are only checked for a cipher
+	reservedBytes, ok := getRequiredReservedBytes(cipher)
+	if !ok {
+		reservedBytes = -1
+	}
+	header, err := sqlitefile.ReadHeader(file)
+	if errors.Is(err, sqlitefile.ErrNotDatabase) {
+		return "", nil, fmt.Errorf("file %s is not a valid SQLite database file", file)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("could not read %s: %w", file, err)
+	}
+	changes := sqlitefile.Changes(header, reservedBytes)
+	if len(changes) == 0 {
+		return file, nil, nil
+	}
+
+	prepared := filepath.Join(dir, filepath.Base(file))
+	spinner := prompt.Spinner(fmt.Sprintf("Preparing a copy of %s...", file))
+	err = sqlitefile.Prepare(file, prepared, reservedBytes)
+	spinner.Stop()
+	if err != nil {
+		return "", nil, fmt.Errorf("could not prepare %s: %w", file, err)
+	}
+	return prepared, changes, nil
+}
+
+// importArchive extracts an archive written by turso db export --archive to a
+// temporary directory, and imports its database under the exported name.
+func importArchive(ctx context.Context, filename string) error {
+	identities, err := archiveIdentities()
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "turso-import-*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := archive.Extract(filename, dir, identities...)
+	if errors.Is(err, archive.ErrEncrypted) {
+		return fmt.Errorf("archive %s is encrypted, use %s or %s to decrypt it", filename, internal.Emph("--passphrase-file"), internal.Emph("--identity"))
+	}
+	if err != nil {
+		return fmt.Errorf("could not extract archive %s: %w", filename, err)
+	}
+	dbFile, ok := manifest.File(archive.TypeDatabase)
+	if !ok {
+		return fmt.Errorf("archive %s has no database file", filename)
+	}
+	fmt.Printf("Importing database %s exported at %s, up to frame %d of generation %d.\n",
+		internal.Emph(manifest.Database), manifest.ExportedAt.Format(time.RFC3339), manifest.FrameNo, manifest.Generation)
+
+	// The WAL, if any, is next to the database file, so it is applied when
+	// the database is opened
+	fromFileFlag = filepath.Join(dir, dbFile.Name)
+	fromFileIsTemporary = true
+	return CreateDatabase(ctx, manifest.Database)
+}
+
+// resumeImportCmd continues the interrupted upload of filename to the draft
+// database it was started for.
+func resumeImportCmd(ctx context.Context, filename string) error {
+	interrupted, err := turso.FindInterruptedImport(filename)
+	if err != nil {
+		return err
+	}
+	if interrupted == nil {
+		return fmt.Errorf("there is no interrupted import of %s to resume", filename)
+	}
+	if interrupted.Encrypted && remoteEncryptionKeyFlag() == "" {
+		return fmt.Errorf("database %s is encrypted, use %s to resume its import", interrupted.Database, internal.Emph("--remote-encryption-key"))
+	}
+	uploadOptions, err := uploadOptionsFromFlags()
+	if err != nil {
+		return err
+	}
+	client, err := authedTursoClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	spinner := prompt.Spinner(fmt.Sprintf("Resuming the upload of database %s, %d of %d chunks uploaded...",
+		internal.Emph(interrupted.Database), interrupted.UploadedChunks, interrupted.TotalChunks))
+	defer spinner.Stop()
+	if err := client.Databases.ResumeImport(ctx, filename, remoteEncryptionKeyFlag(), uploadOptions, spinner); err != nil {
+		if turso.ImportInProgress(filename) {
+			return fmt.Errorf("could not import database %s: %w\nRun the command again to continue the upload", interrupted.Database, err)
+		}
+		return fmt.Errorf("could not import database %s: %w", interrupted.Database, err)
+	}
+	spinner.Stop()
+	invalidateDatabasesCache()
+
+	fmt.Printf("Created database %s at group %s in %s.\n\n", internal.Emph(interrupted.Database), internal.Emph(interrupted.Group), time.Since(start).Round(time.Millisecond).String())
+	fmt.Printf("Start an interactive SQL shell with:\n\n")
+	fmt.Printf("   %s\n\n", internal.Emph("turso db shell "+interrupted.Database))
+	return nil
+}
+
 // Sanitize a SQLite database filename to be used as a cloud database name.
 func sanitizeDatabaseName(filename string) string {
 	base := filepath.Base(filename)
@@ -1,17 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/dustin/go-humanize"
 	"github.com/rodaine/table"
 	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal/flags"
 	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 func init() {
 	dbCmd.AddCommand(dbInspectCmd)
+	allowProjectDatabase(dbInspectCmd)
 	addVerboseFlag(dbInspectCmd)
 	addQueriesFlag(dbInspectCmd)
 }
@@ -24,31 +27,40 @@ var dbInspectCmd = &cobra.Command{
 	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		name := args[0]
 		if name == "" {
 			return errors.New("please specify a database name")
 		}
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		if queriesFlag {
-			return handleInspectQueries(client, name)
+			return handleInspectQueries(ctx, client, name)
 		}
 
-		db, err := getDatabase(client, name, true)
+		db, err := getDatabase(ctx, client, name, true)
 		if err != nil {
 			return err
 		}
 
-		instances, dbUsage, err := instancesAndUsage(client, db.Name)
+		instances, dbUsage, err := instancesAndUsage(ctx, client, db.Name)
 		if err != nil {
 			return err
 		}
 
+		if flags.StructuredOutput() {
+			return printStructured(databaseInspectOutput{
+				Database:  db.Name,
+				Usage:     toUsageOutput(dbUsage.Usage),
+				Instances: toInstancesOutput(db, instances, dbUsage, true),
+			})
+		}
+
 		fmt.Printf("Total space used: %s\n", humanize.Bytes(dbUsage.Usage.StorageBytesUsed))
 		fmt.Printf("Number of rows read: %d\n", dbUsage.Usage.RowsRead)
 		fmt.Printf("Number of rows written: %d\n", dbUsage.Usage.RowsWritten)
@@ -87,11 +99,18 @@ func getInstanceUsageMap(usages []turso.InstanceUsage) map[string]turso.Usage {
 	return m
 }
 
-func handleInspectQueries(client *turso.Client, database string) error {
-	stats, err := client.Databases.Stats(database)
+func handleInspectQueries(ctx context.Context, client *turso.Client, database string) error {
+	stats, err := client.Databases.Stats(ctx, database)
 	if err != nil {
 		return err
 	}
+	if flags.StructuredOutput() {
+		out := queryStatsListOutput{Queries: make([]queryStatsOutput, 0, len(stats))}
+		for _, query := range stats {
+			out.Queries = append(out.Queries, queryStatsOutput{Query: query.Query, RowsRead: query.RowsRead, RowsWritten: query.RowsWritten})
+		}
+		return printStructured(out)
+	}
 	tbl := table.New("QUERY", "ROWS WRITTEN", "ROWS READ")
 	for _, query := range stats {
 		tbl.AddRow(query.Query, query.RowsWritten, query.RowsRead)
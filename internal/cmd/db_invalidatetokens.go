@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -22,14 +23,15 @@ var dbInvalidateTokensCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		database, err := getDatabase(client, name, true)
+		database, err := getDatabase(ctx, client, name, true)
 		if err != nil {
 			return err
 		}
@@ -39,7 +41,7 @@ var dbInvalidateTokensCmd = &cobra.Command{
 		}
 
 		if yesFlag {
-			return rotateAndNotify(client, database)
+			return rotateAndNotify(ctx, client, database)
 		}
 
 		fmt.Printf("To invalidate %s database tokens, all its replicas must be restarted.\n", internal.Emph(name))
@@ -55,15 +57,15 @@ var dbInvalidateTokensCmd = &cobra.Command{
 			return nil
 		}
 
-		return rotateAndNotify(client, database)
+		return rotateAndNotify(ctx, client, database)
 	},
 }
 
-func rotateAndNotify(turso *turso.Client, database turso.Database) error {
+func rotateAndNotify(ctx context.Context, turso *turso.Client, database turso.Database) error {
 	s := prompt.Spinner("Invalidating db auth tokens... ")
 	defer s.Stop()
 
-	if err := rotate(turso, database); err != nil {
+	if err := rotate(ctx, turso, database); err != nil {
 		return err
 	}
 
@@ -73,11 +75,11 @@ func rotateAndNotify(turso *turso.Client, database turso.Database) error {
 	return nil
 }
 
-func rotate(turso *turso.Client, database turso.Database) error {
+func rotate(ctx context.Context, turso *turso.Client, database turso.Database) error {
 	invalidateDbTokenCache()
 	settings.PersistChanges()
 	if database.Group != "" && database.Version != "tech-preview" {
-		return turso.Groups.Rotate(database.Group)
+		return turso.Groups.Rotate(ctx, database.Group)
 	}
-	return turso.Databases.Rotate(database.Name)
+	return turso.Databases.Rotate(ctx, database.Name)
 }
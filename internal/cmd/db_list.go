@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
 	"github.com/tursodatabase/turso-cli/internal/flags"
 	"github.com/tursodatabase/turso-cli/internal/turso"
@@ -23,28 +25,28 @@ type DatabaseFetcher struct {
 	LoadFullInfo bool
 }
 
-func (df *DatabaseFetcher) FetchPage(pageSize int, cursor *string) (turso.ListResponse, error) {
+func (df *DatabaseFetcher) FetchPage(ctx context.Context, pageSize int, cursor *string) (turso.ListResponse, error) {
 	if !flags.V3Api() {
-		return df.fetchPageV2(pageSize, cursor)
+		return df.fetchPageV2(ctx, pageSize, cursor)
 	}
 	if df.SchemaFilter != "" {
-		return df.fetchPageV2(pageSize, cursor)
+		return df.fetchPageV2(ctx, pageSize, cursor)
 	}
-	orgID, err := tryResolveOrgID(df.client)
+	orgID, err := tryResolveOrgID(ctx, df.client)
 	if err != nil {
 		return turso.ListResponse{}, err
 	}
 	if orgID == "" {
-		return df.fetchPageV2(pageSize, cursor)
+		return df.fetchPageV2(ctx, pageSize, cursor)
 	}
 	groupID := ""
 	if df.GroupFilter != "" {
-		id, err := tryResolveGroupID(df.client, df.GroupFilter)
+		id, err := tryResolveGroupID(ctx, df.client, df.GroupFilter)
 		if err != nil {
 			return turso.ListResponse{}, err
 		}
 		if id == "" {
-			return df.fetchPageV2(pageSize, cursor)
+			return df.fetchPageV2(ctx, pageSize, cursor)
 		}
 		groupID = id
 	}
@@ -58,7 +60,7 @@ func (df *DatabaseFetcher) FetchPage(pageSize int, cursor *string) (turso.ListRe
 		Cursor:     cursorStr,
 		ParentDbId: df.ParentDbId,
 	}
-	dbs, next, err := df.client.DatabasesV3.List(orgID, options)
+	dbs, next, err := df.client.DatabasesV3.List(ctx, orgID, options)
 	if err != nil {
 		return turso.ListResponse{}, err
 	}
@@ -68,7 +70,7 @@ func (df *DatabaseFetcher) FetchPage(pageSize int, cursor *string) (turso.ListRe
 	}
 	if df.LoadFullInfo {
 		for i := range response.Databases {
-			response.Databases[i], err = df.client.DatabasesV3.Get(orgID, response.Databases[i].ID)
+			response.Databases[i], err = df.client.DatabasesV3.Get(ctx, orgID, response.Databases[i].ID)
 			if err != nil {
 				return turso.ListResponse{}, err
 			}
@@ -77,7 +79,7 @@ func (df *DatabaseFetcher) FetchPage(pageSize int, cursor *string) (turso.ListRe
 	return response, nil
 }
 
-func (df *DatabaseFetcher) fetchPageV2(pageSize int, cursor *string) (turso.ListResponse, error) {
+func (df *DatabaseFetcher) fetchPageV2(ctx context.Context, pageSize int, cursor *string) (turso.ListResponse, error) {
 	cursorStr := ""
 	if cursor != nil {
 		cursorStr = *cursor
@@ -91,13 +93,13 @@ func (df *DatabaseFetcher) fetchPageV2(pageSize int, cursor *string) (turso.List
 		Parent: df.ParentDbId,
 	}
 
-	response, err := df.client.Databases.List(options)
+	response, err := df.client.Databases.List(ctx, options)
 	if err != nil {
 		return turso.ListResponse{}, err
 	}
 	if df.LoadFullInfo {
 		for i := range response.Databases {
-			response.Databases[i], err = df.client.Databases.Get(response.Databases[i].Name)
+			response.Databases[i], err = df.client.Databases.Get(ctx, response.Databases[i].Name)
 			if err != nil {
 				return turso.ListResponse{}, err
 			}
@@ -112,8 +114,9 @@ var listCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -123,6 +126,6 @@ var listCmd = &cobra.Command{
 			SchemaFilter: schemaFilter,
 			GroupFilter:  groupFilter,
 		}
-		return printDatabaseList(fetcher)
+		return printDatabaseList(ctx, fetcher)
 	},
 }
@@ -33,17 +33,18 @@ var regionsCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		locations, err := mapLocations(client)
+		locations, err := mapLocations(ctx, client)
 		if err != nil {
 			return err
 		}
 
-		closest, err := closestLocation(client)
+		closest, err := closestLocation(ctx, client)
 		if err != nil {
 			return err
 		}
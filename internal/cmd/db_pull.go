@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull <database> <file>",
+	Short: "Update a local export of a database with the changes made since.",
+	Long: `Update a local export of a database with the changes made since.
+
+The export must have been made with ` + "`turso db export --with-metadata`" + `. Only the WAL
+frames written since the export, or the previous pull, are downloaded and
+appended to <file>-wal. When the database generation changed, the database is
+exported again.
+
+Don't pull while the database is open.`,
+	Example: "  turso db export my-db --with-metadata\n  turso db pull my-db my-db.db",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+		dbName, file := args[0], args[1]
+
+		client, err := authedTursoClient(ctx)
+		if err != nil {
+			return err
+		}
+		db, err := getDatabase(ctx, client, dbName)
+		if err != nil {
+			return fmt.Errorf("failed to find database: %w", err)
+		}
+
+		spinner := prompt.Spinner(fmt.Sprintf("Pulling changes of %s...", internal.Emph(dbName)))
+		result, err := client.Databases.Pull(ctx, dbName, getDatabaseHttpUrl(&db), file, remoteEncryptionKeyFlag())
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to pull database: %w", err)
+		}
+		switch {
+		case result.Exported:
+			fmt.Printf("The database generation changed, exported %s again to %s.\n", internal.Emph(dbName), file)
+		case result.NewFrames == 0:
+			fmt.Printf("%s is up to date.\n", file)
+		default:
+			fmt.Printf("Pulled %d new WAL frames into %s, up to frame %d.\n", result.NewFrames, file, result.LastFrameNo)
+		}
+		return nil
+	},
+}
+
+func init() {
+	addRemoteEncryptionKeyFlag(pullCmd)
+	dbCmd.AddCommand(pullCmd)
+	allowProjectDatabase(pullCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -27,7 +28,8 @@ var replicateCmd = &cobra.Command{
 	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: replicateArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := authedTursoClient()
+		ctx := cmd.Context()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -36,7 +38,7 @@ var replicateCmd = &cobra.Command{
 			return errors.New("you must specify a database name to replicate it")
 		}
 
-		database, err := getDatabase(client, dbName, true)
+		database, err := getDatabase(ctx, client, dbName, true)
 		if err != nil {
 			return err
 		}
@@ -44,21 +46,21 @@ var replicateCmd = &cobra.Command{
 			return errors.New("replication is not available on AWS at the moment")
 		}
 
-		location, err := getReplicateLocation(client, args, database)
+		location, err := getReplicateLocation(ctx, client, args, database)
 		if err != nil {
 			return err
 		}
 		cmd.SilenceUsage = true
-		if !isValidLocation(client, location) {
+		if !isValidLocation(ctx, client, location) {
 			return fmt.Errorf("invalid location ID. Run %s to see a list of valid location IDs", internal.Emph("turso db locations"))
 		}
 
-		if ok, _ := canReplicate(client, dbName); !ok {
+		if ok, _ := canReplicate(ctx, client, dbName); !ok {
 			cmd := internal.Emph(fmt.Sprintf("turso group locations add %s %s", database.Group, location))
 			return fmt.Errorf("database %s is part of a group.\nUse %s to replicate the group instead", internal.Emph(dbName), cmd)
 		}
 
-		instance, err := replicate(client, database, location)
+		instance, err := replicate(ctx, client, database, location)
 		if err != nil {
 			return err
 		}
@@ -73,38 +75,38 @@ var replicateCmd = &cobra.Command{
 	},
 }
 
-func replicate(client *turso.Client, database turso.Database, location string) (*turso.Instance, error) {
+func replicate(ctx context.Context, client *turso.Client, database turso.Database, location string) (*turso.Instance, error) {
 	start := time.Now()
-	instance, err := createInstance(client, database, location)
+	instance, err := createInstance(ctx, client, database, location)
 	if shouldRetryReplicate(err) {
-		location, err = selectAlternativeLocation(client, database.Name, location)
+		location, err = selectAlternativeLocation(ctx, client, database.Name, location)
 		if err != nil {
 			return nil, err
 		}
 		start = time.Now()
-		instance, err = createInstance(client, database, location)
+		instance, err = createInstance(ctx, client, database, location)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to replicate database: %s", err)
+		return nil, fmt.Errorf("failed to replicate database: %w", err)
 	}
 
 	if waitFlag {
-		err := waitForInstance(client, database.Name, instance.Name, location)
+		err := waitForInstance(ctx, client, database.Name, instance.Name, location)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	elapsed := time.Since(start)
-	fmt.Printf("Replicated database %s to %s in %d seconds.\n\n", internal.Emph(database.Name), internal.Emph(formatLocation(client, location)), int(elapsed.Seconds()))
+	fmt.Printf("Replicated database %s to %s in %d seconds.\n\n", internal.Emph(database.Name), internal.Emph(formatLocation(ctx, client, location)), int(elapsed.Seconds()))
 	return instance, nil
 }
 
-func waitForInstance(client *turso.Client, database, instance, location string) error {
-	description := fmt.Sprintf("Waiting for replica of %s at %s to be ready", internal.Emph(database), internal.Emph(formatLocation(client, location)))
+func waitForInstance(ctx context.Context, client *turso.Client, database, instance, location string) error {
+	description := fmt.Sprintf("Waiting for replica of %s at %s to be ready", internal.Emph(database), internal.Emph(formatLocation(ctx, client, location)))
 	s := prompt.Spinner(description)
 	defer s.Stop()
-	return client.Instances.Wait(database, instance)
+	return client.Instances.Wait(ctx, database, instance)
 }
 
 func shouldRetryReplicate(err error) bool {
@@ -112,10 +114,10 @@ func shouldRetryReplicate(err error) bool {
 	return errors.As(err, &createInstanceLocationError)
 }
 
-func selectAlternativeLocation(client *turso.Client, database, locationID string) (string, error) {
+func selectAlternativeLocation(ctx context.Context, client *turso.Client, database, locationID string) (string, error) {
 	fmt.Printf("We couldn't replicate your database to location %s.\nPlease try again in a few moments, or pick one of the nearby locations.\n", internal.Emph(locationID))
 
-	location, _ := client.Locations.Get(locationID)
+	location, _ := client.Locations.Get(ctx, locationID)
 
 	closestLocationCodes := make([]string, 0, len(location.Closest))
 	for _, location := range location.Closest {
@@ -137,36 +139,37 @@ func selectAlternativeLocation(client *turso.Client, database, locationID string
 	return locationID, nil
 }
 
-func createInstance(client *turso.Client, database turso.Database, location string) (*turso.Instance, error) {
-	description := fmt.Sprintf("Replicating database %s to %s", internal.Emph(database.Name), internal.Emph(formatLocation(client, location)))
+func createInstance(ctx context.Context, client *turso.Client, database turso.Database, location string) (*turso.Instance, error) {
+	description := fmt.Sprintf("Replicating database %s to %s", internal.Emph(database.Name), internal.Emph(formatLocation(ctx, client, location)))
 	s := prompt.Spinner(description)
 	defer s.Stop()
 
 	if database.Group != "" {
-		return &turso.Instance{Name: location, Region: location}, client.Groups.AddLocation(database.Group, location)
+		return &turso.Instance{Name: location, Region: location}, client.Groups.AddLocation(ctx, database.Group, location)
 	}
 
-	return client.Instances.Create(database.Name, location)
+	return client.Instances.Create(ctx, database.Name, location)
 }
 
 func replicateArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 	if len(args) == 1 {
-		locations, _ := locations(client)
+		locations, _ := locations(ctx, client)
 		return maps.Keys(locations), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 	return dbNameArg(cmd, args, toComplete)
 }
 
-func getReplicateLocation(client *turso.Client, args []string, database turso.Database) (string, error) {
+func getReplicateLocation(ctx context.Context, client *turso.Client, args []string, database turso.Database) (string, error) {
 	if len(args) > 1 {
 		return args[1], nil
 	}
 
-	locations, err := locations(client)
+	locations, err := locations(ctx, client)
 	if err != nil {
 		return "", err
 	}
@@ -209,8 +212,8 @@ func pickLocation(locations map[string]string, exclude []string) string {
 	return choice
 }
 
-func canReplicate(client *turso.Client, name string) (bool, error) {
-	databases, err := getDatabases(client)
+func canReplicate(ctx context.Context, client *turso.Client, name string) (bool, error) {
+	databases, err := getDatabases(ctx, client)
 	if err != nil {
 		return false, err
 	}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -25,6 +26,7 @@ var proxy string
 
 func init() {
 	dbCmd.AddCommand(shellCmd)
+	allowProjectDatabase(shellCmd)
 	addInstanceFlag(shellCmd, "Connect to the database at the specified instance.")
 	addLocationFlag(shellCmd, "Connect to the database at the specified location.")
 	addRemoteEncryptionKeyFlag(shellCmd)
@@ -35,7 +37,7 @@ func init() {
 	flags.AddAttachClaims(shellCmd)
 }
 
-func getURL(db *turso.Database, client *turso.Client, http bool, primaryOnly bool) (string, error) {
+func getURL(ctx context.Context, db *turso.Database, client *turso.Client, http bool, primaryOnly bool) (string, error) {
 	scheme := "wss"
 	if http {
 		scheme = "https"
@@ -51,7 +53,7 @@ func getURL(db *turso.Database, client *turso.Client, http bool, primaryOnly boo
 			return getUrl(db, nil, scheme), nil
 		}
 
-		instances, err := client.Instances.List(db.Name)
+		instances, err := client.Instances.List(ctx, db.Name)
 		if err != nil {
 			return "", err
 		}
@@ -64,7 +66,7 @@ func getURL(db *turso.Database, client *turso.Client, http bool, primaryOnly boo
 		return "", errors.New("primary not found")
 	}
 
-	instances, err := client.Instances.List(db.Name)
+	instances, err := client.Instances.List(ctx, db.Name)
 	if err != nil {
 		return "", err
 	}
@@ -98,6 +100,7 @@ var shellCmd = &cobra.Command{
 	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		nameOrUrl := args[0]
 		if nameOrUrl == "" {
 			return errors.New("please specify a database name")
@@ -130,20 +133,20 @@ var shellCmd = &cobra.Command{
 		// Makes sure localhost URL or self-hosted will work even if not authenticated
 		// to turso. The token code will check for auth
 		if !isURL(nameOrUrl) {
-			VerifyUserIsLoggedIn()
-			client, err := authedTursoClient()
+			VerifyUserIsLoggedIn(ctx)
+			client, err := authedTursoClient(ctx)
 			if err != nil {
 				return fmt.Errorf("could not create turso client: %w", err)
 			}
 
-			db, err = databaseFromName(nameOrUrl, client)
+			db, err = databaseFromName(ctx, nameOrUrl, client)
 			if err != nil {
 				return err
 			}
 
 			var claim *turso.PermissionsClaim
 			if len(flags.AttachClaims()) > 0 {
-				err := validateDBNames(client, flags.AttachClaims())
+				err := validateDBNames(ctx, client, flags.AttachClaims())
 				if err != nil {
 					return err
 				}
@@ -152,11 +155,11 @@ var shellCmd = &cobra.Command{
 				}
 			}
 
-			authToken, err = tokenFromDb(db, client, claim)
+			authToken, err = tokenFromDb(ctx, db, client, claim)
 			if err != nil {
 				return err
 			}
-			dbUrl, err = getURL(db, client, nonInteractive || db.IsSchema || len(flags.AttachClaims()) == 0, isDump)
+			dbUrl, err = getURL(ctx, db, client, nonInteractive || db.IsSchema || len(flags.AttachClaims()) == 0, isDump)
 			if err != nil {
 				return err
 			}
@@ -193,11 +196,11 @@ var shellCmd = &cobra.Command{
 			} else if jwt != "" {
 				authToken = jwt
 			} else if strings.HasSuffix(u.Hostname(), ".turso.io") {
-				client, err := authedTursoClient()
+				client, err := authedTursoClient(ctx)
 				if err != nil {
 					return fmt.Errorf("could not create turso client: %w", err)
 				}
-				dbs, err := getDatabases(client)
+				dbs, err := getDatabases(ctx, client)
 				if err != nil {
 					return err
 				}
@@ -210,7 +213,7 @@ var shellCmd = &cobra.Command{
 				if db == nil {
 					return fmt.Errorf("could not find a database with the hostname %s", u.Hostname())
 				}
-				authToken, err = tokenFromDb(db, client, nil)
+				authToken, err = tokenFromDb(ctx, db, client, nil)
 				if err != nil {
 					return err
 				}
@@ -250,7 +253,7 @@ var shellCmd = &cobra.Command{
 			dbID = db.ID
 		}
 		if isDump {
-			return dump(getDbURLForDump(dbUrl), authToken)
+			return dump(ctx, getDbURLForDump(dbUrl), authToken)
 		}
 
 		if sql != "" {
@@ -289,8 +292,11 @@ func isAuthError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if apiErr, ok := turso.AsAPIError(err); ok {
+		return apiErr.Unauthorized()
+	}
+	// errors coming from the libsql client are not typed
 	msg := err.Error()
-	// TODO: use a more reliable way to detect auth errors
 	return strings.Contains(msg, "401") || strings.Contains(msg, "403")
 }
 
@@ -323,9 +329,9 @@ type ErrorResponse struct {
 	Message string `json:"error"`
 }
 
-func databaseFromName(str string, client *turso.Client) (*turso.Database, error) {
+func databaseFromName(ctx context.Context, str string, client *turso.Client) (*turso.Database, error) {
 	name := str
-	db, err := getDatabase(client, name)
+	db, err := getDatabase(ctx, client, name)
 	if err != nil {
 		return nil, err
 	}
@@ -338,20 +344,20 @@ func isURL(s string) bool {
 	return err == nil
 }
 
-func tokenFromDb(db *turso.Database, client *turso.Client, claim *turso.PermissionsClaim) (string, error) {
+func tokenFromDb(ctx context.Context, db *turso.Database, client *turso.Client, claim *turso.PermissionsClaim) (string, error) {
 	if db == nil {
 		return "", nil
 	}
 	// skip cache and always use token from server when claims are attached
 	if claim != nil {
-		return client.Databases.Token(db.Name, "2d", false, claim, nil)
+		return client.Databases.Token(ctx, db.Name, "2d", false, claim, nil)
 	}
 
 	if token := dbTokenCache(db.ID); token != "" {
 		return token, nil
 	}
 
-	token, err := generateDbToken(client, db)
+	token, err := generateDbToken(ctx, client, db)
 	if err != nil {
 		return "", err
 	}
@@ -362,18 +368,18 @@ func tokenFromDb(db *turso.Database, client *turso.Client, claim *turso.Permissi
 	return token, nil
 }
 
-func generateDbToken(client *turso.Client, db *turso.Database) (string, error) {
+func generateDbToken(ctx context.Context, client *turso.Client, db *turso.Database) (string, error) {
 	if !flags.V3Api() {
-		return client.Databases.Token(db.Name, "2d", false, nil, nil)
+		return client.Databases.Token(ctx, db.Name, "2d", false, nil, nil)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return "", err
 	}
 	if orgID == "" || db.ID == "" {
-		return client.Databases.Token(db.Name, "2d", false, nil, nil)
+		return client.Databases.Token(ctx, db.Name, "2d", false, nil, nil)
 	}
-	return client.DatabasesV3.Token(orgID, db.ID, "2d", false, nil)
+	return client.DatabasesV3.Token(ctx, orgID, db.ID, "2d", false, nil)
 }
 
 func getConnectionInfo(nameOrUrl string, db *turso.Database) string {
@@ -393,13 +399,17 @@ func pipeOrRedirect() bool {
 	return err == nil && (stat.Mode()&os.ModeCharDevice) == 0
 }
 
-func dump(dbURL, authToken string) error {
-	req, err := http.NewRequest("GET", dbURL+"/dump", nil)
+func dump(ctx context.Context, dbURL, authToken string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", dbURL+"/dump", nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Authorization", "Bearer "+authToken)
-	resp, err := http.DefaultClient.Do(req)
+	httpClient, err := proxiedHTTPClient(proxy)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return err
 	}
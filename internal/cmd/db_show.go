@@ -10,10 +10,12 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/flags"
 )
 
 func init() {
 	dbCmd.AddCommand(showCmd)
+	allowProjectDatabase(showCmd)
 	showCmd.Flags().BoolVar(&showUrlFlag, "url", false, "Show URL for the database HTTP API.")
 	showCmd.Flags().BoolVar(&showHttpUrlFlag, "http-url", false, "Show HTTP URL for the database HTTP API.")
 	showCmd.Flags().BoolVar(&showInstanceUrlsFlag, "instance-urls", false, "Show URL for the HTTP API of all existing instances")
@@ -29,17 +31,18 @@ var showCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
-		db, err := getDatabase(client, args[0], true)
+		db, err := getDatabase(ctx, client, args[0], true)
 		if err != nil {
 			return err
 		}
 
-		config, err := getDatabaseConfig(client, db.Name)
+		config, err := getDatabaseConfig(ctx, client, db.Name)
 		if err != nil {
 			return err
 		}
@@ -60,10 +63,10 @@ var showCmd = &cobra.Command{
 				ParentDbId:   db.ID,
 				LoadFullInfo: true,
 			}
-			return printDatabaseList(fetcher)
+			return printDatabaseList(ctx, fetcher)
 		}
 
-		instances, dbUsage, err := instancesAndUsage(client, db.Name)
+		instances, dbUsage, err := instancesAndUsage(ctx, client, db.Name)
 		if err != nil {
 			return fmt.Errorf("could not get instances of database %s: %w", db.Name, err)
 		}
@@ -78,6 +81,15 @@ var showCmd = &cobra.Command{
 			return fmt.Errorf("instance %s was not found for database %s. List known instances using %s", internal.Emph(showInstanceUrlFlag), internal.Emph(db.Name), internal.Emph("turso db show "+db.Name))
 		}
 
+		if flags.StructuredOutput() {
+			return printStructured(databaseShowOutput{
+				databaseOutput: toDatabaseOutput(db),
+				Config:         toDatabaseConfigOutput(config),
+				Usage:          toUsageOutput(dbUsage.Usage),
+				Instances:      toInstancesOutput(db, instances, dbUsage, false),
+			})
+		}
+
 		regions := make([]string, len(db.Regions))
 		copy(regions, db.Regions)
 		sort.Strings(regions)
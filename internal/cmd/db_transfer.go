@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -19,15 +20,16 @@ var dbTransferCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: dbNameAndOrgArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		dbName := args[0]
 		orgName := args[1]
 
-		if _, err := getDatabase(client, dbName, true); err != nil {
+		if _, err := getDatabase(ctx, client, dbName, true); err != nil {
 			return err
 		}
 		fmt.Printf("To transfer %s database to another organization, all its replicas must be updated.\n", internal.Emph(dbName))
@@ -43,18 +45,18 @@ var dbTransferCmd = &cobra.Command{
 			return nil
 		}
 
-		return transfer(client, dbName, orgName)
+		return transfer(ctx, client, dbName, orgName)
 	},
 }
 
-func transfer(client *turso.Client, dbName, orgName string) error {
+func transfer(ctx context.Context, client *turso.Client, dbName, orgName string) error {
 	invalidateDatabasesCache()
 
 	msg := fmt.Sprintf("Transferring database %s to organization %s", internal.Emph(dbName), internal.Emph(orgName))
 	s := prompt.Spinner(msg)
 	defer s.Stop()
 
-	if err := client.Databases.Transfer(dbName, orgName); err != nil {
+	if err := client.Databases.Transfer(ctx, dbName, orgName); err != nil {
 		return err
 	}
 
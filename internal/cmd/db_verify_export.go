@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+var verifyExportCmd = &cobra.Command{
+	Use:   "verify-export <file>",
+	Short: "Check the integrity of a database export.",
+	Long: `Check the integrity of a database export made with ` + "`turso db export`" + `.
+
+The SQLite header of the database file is checked, and its size against the
+page count. When there is a WAL file, its header and the checksums of all its
+frames are checked, and when there is a metadata file, its hash. The database
+content itself is not checked.`,
+	Example: "  turso db verify-export my-db.db",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return verifyExport(args[0])
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(verifyExportCmd)
+}
+
+// checkExport checks the export to file, and returns an error listing the
+// problems found, if any.
+func checkExport(file string) (turso.ExportCheck, error) {
+	check, err := turso.VerifyExport(file)
+	if err != nil {
+		return check, fmt.Errorf("failed to verify export: %w", err)
+	}
+	if !check.Valid() {
+		return check, fmt.Errorf("export %s is invalid:\n  %s", file, strings.Join(check.Problems, "\n  "))
+	}
+	return check, nil
+}
+
+// verifyExport checks the export to file and prints what was checked, or
+// returns an error listing the problems found.
+func verifyExport(file string) error {
+	check, err := checkExport(file)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Database: %s, %d pages of %d bytes\n", file, check.Pages, check.PageSize)
+	if check.WAL {
+		fmt.Printf("WAL:      %s-wal, %d frames\n", file, check.Frames)
+	}
+	if check.Metadata {
+		fmt.Printf("Metadata: %s-info, generation %d, frame %d\n", file, check.Generation, check.DurableFrameNum)
+	}
+	fmt.Printf("Export %s is %s.\n", file, internal.Emph("valid"))
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 func init() {
 	dbCmd.AddCommand(wakeUpDbCmd)
+	allowProjectDatabase(wakeUpDbCmd)
 }
 
 var wakeUpDbCmd = &cobra.Command{
@@ -21,23 +23,24 @@ var wakeUpDbCmd = &cobra.Command{
 	Aliases:           []string{"wakeup"},
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		name := args[0]
-		return wakeupDatabase(client, name)
+		return wakeupDatabase(ctx, client, name)
 	},
 }
 
-func wakeupDatabase(client *turso.Client, name string) error {
+func wakeupDatabase(ctx context.Context, client *turso.Client, name string) error {
 	start := time.Now()
 	s := prompt.Spinner(fmt.Sprintf("Unarchiving database %s... ", internal.Emph(name)))
 	defer s.Stop()
 
-	if err := client.Databases.Wakeup(name); err != nil {
+	if err := client.Databases.Wakeup(ctx, name); err != nil {
 		return err
 	}
 	s.Stop()
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/controlplane"
+)
+
+var (
+	controlPlanePort    int
+	controlPlaneToken   string
+	controlPlaneSqld    string
+	controlPlaneNoSqld  bool
+	controlPlaneDataDir string
+)
+
+func init() {
+	devCmd.AddCommand(devControlPlaneCmd)
+	devControlPlaneCmd.Flags().IntVarP(&controlPlanePort, "port", "p", 8081, "the port to which bind the control plane")
+	devControlPlaneCmd.Flags().StringVar(&controlPlaneToken, "token", "", "API token clients must use. Any token is accepted if empty")
+	devControlPlaneCmd.Flags().StringVar(&controlPlaneSqld, "sqld", "", "Path to the sqld binary serving the databases. Defaults to sqld on your PATH")
+	devControlPlaneCmd.Flags().BoolVar(&controlPlaneNoSqld, "no-sqld", false, "Don't start sqld: databases only exist in the control plane")
+	devControlPlaneCmd.Flags().StringVar(&controlPlaneDataDir, "data-dir", "", "Directory for the database files. A temporary directory is used if empty")
+}
+
+var devControlPlaneCmd = &cobra.Command{
+	Use:               "control-plane",
+	Short:             "starts a local fake of the Turso platform API",
+	Long:              fmt.Sprintf("starts a local fake of the Turso platform API.\n\nGroups, databases and tokens are kept in memory and every database is served by a local sqld instance, so commands like %s and %s work without network access.", internal.Emph("turso db create"), internal.Emph("turso db shell")),
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config := controlplane.Config{Token: controlPlaneToken, DataDir: controlPlaneDataDir}
+		if !controlPlaneNoSqld {
+			sqld, err := exec.LookPath(sqldOrDefault(controlPlaneSqld))
+			if err != nil {
+				return fmt.Errorf("could not find sqld, install it or use %s: %w", internal.Emph("--no-sqld"), err)
+			}
+			config.SqldPath = sqld
+		}
+
+		server, err := controlplane.New(config)
+		if err != nil {
+			return err
+		}
+		defer server.Close()
+
+		listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", controlPlanePort))
+		if err != nil {
+			return fmt.Errorf("could not listen on port %d: %w", controlPlanePort, err)
+		}
+		httpServer := &http.Server{Handler: server}
+		serveErr := make(chan error, 1)
+		go func() { serveErr <- httpServer.Serve(listener) }()
+
+		token := controlPlaneToken
+		if token == "" {
+			token = "dev"
+		}
+		fmt.Printf("Control plane listening on %s.\n\n", internal.Emph(listener.Addr().String()))
+		fmt.Printf("Point the CLI at it with:\n\n")
+		fmt.Printf("    export %s=http://%s\n", "TURSO_API_BASEURL", listener.Addr())
+		fmt.Printf("    export %s=%s\n\n", ENV_ACCESS_TOKEN, token)
+		if config.SqldPath == "" {
+			fmt.Println("sqld is disabled, databases can be managed but not connected to.")
+		}
+
+		// Runs until interrupted, which cancels the command context.
+		select {
+		case <-cmd.Context().Done():
+		case err := <-serveErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("control plane stopped unexpectedly: %w", err)
+			}
+		}
+		return httpServer.Shutdown(context.Background())
+	},
+}
+
+func sqldOrDefault(path string) string {
+	if path == "" {
+		return "sqld"
+	}
+	return path
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// Process exit codes. They are part of the CLI public interface and
+// documented in docs/exit-codes.md, so existing values must never change.
+const (
+	exitCodeError        = 1
+	exitCodeDrift        = 2
+	exitCodeNotFound     = 3
+	exitCodeUnauthorized = 4
+	exitCodeQuota        = 5
+	exitCodeConflict     = 6
+	exitCodeNetwork      = 7
+	exitCodeTimeout      = 124
+	exitCodeInterrupted  = 130
+)
+
+func exitCode(err error) int {
+	if errors.Is(err, errDrift) {
+		return exitCodeDrift
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return exitCodeTimeout
+	}
+	if errors.Is(err, turso.ErrPaymentRequired) {
+		return exitCodeQuota
+	}
+	if apiErr, ok := turso.AsAPIError(err); ok {
+		switch {
+		case apiErr.NotFound():
+			return exitCodeNotFound
+		case apiErr.Unauthorized():
+			return exitCodeUnauthorized
+		case apiErr.QuotaExceeded():
+			return exitCodeQuota
+		case apiErr.Conflict():
+			return exitCodeConflict
+		}
+		return exitCodeError
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return exitCodeNetwork
+	}
+	return exitCodeError
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			name: "generic error",
+			err:  errors.New("boom"),
+			code: exitCodeError,
+		},
+		{
+			name: "drift",
+			err:  fmt.Errorf("%w: 2 differences", errDrift),
+			code: exitCodeDrift,
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("failed to get database: %w", &turso.APIError{StatusCode: 404}),
+			code: exitCodeNotFound,
+		},
+		{
+			name: "unauthorized",
+			err:  &turso.APIError{StatusCode: 401},
+			code: exitCodeUnauthorized,
+		},
+		{
+			name: "forbidden",
+			err:  &turso.APIError{StatusCode: 403},
+			code: exitCodeUnauthorized,
+		},
+		{
+			name: "payment required",
+			err:  &turso.APIError{StatusCode: 402},
+			code: exitCodeQuota,
+		},
+		{
+			name: "quota error code",
+			err:  &turso.APIError{StatusCode: 400, Code: "feature_not_available_for_starter_plan"},
+			code: exitCodeQuota,
+		},
+		{
+			name: "payment required sentinel",
+			err:  turso.ErrPaymentRequired,
+			code: exitCodeQuota,
+		},
+		{
+			name: "conflict",
+			err:  &turso.APIError{StatusCode: 409},
+			code: exitCodeConflict,
+		},
+		{
+			name: "other api error",
+			err:  &turso.APIError{StatusCode: 500},
+			code: exitCodeError,
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("failed to list: %w", &url.Error{Op: "Get", URL: "https://api.turso.tech", Err: context.DeadlineExceeded}),
+			code: exitCodeTimeout,
+		},
+		{
+			name: "network error",
+			err:  fmt.Errorf("failed to list: %w", &url.Error{Op: "Get", URL: "https://api.turso.tech", Err: errors.New("connection refused")}),
+			code: exitCodeNetwork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.code {
+				t.Errorf("exitCode() = %d, want %d", got, tt.code)
+			}
+		})
+	}
+}
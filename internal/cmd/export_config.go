@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/terraform"
+)
+
+var exportConfigFormats = []string{"terraform"}
+
+var (
+	exportConfigFormatFlag       string
+	exportConfigFileFlag         string
+	exportConfigImportScriptFlag string
+)
+
+func init() {
+	rootCmd.AddCommand(exportConfigCmd)
+	exportConfigCmd.Flags().StringVar(&exportConfigFormatFlag, "format", "terraform", "Format of the configuration. Possible values: "+strings.Join(exportConfigFormats, ", "))
+	exportConfigCmd.Flags().StringVarP(&exportConfigFileFlag, "file", "f", "", "Write the configuration to a file instead of stdout")
+	exportConfigCmd.Flags().StringVar(&exportConfigImportScriptFlag, "import-script", "", "Write the terraform import commands to a shell script")
+	exportConfigCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return exportConfigFormats, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var exportConfigCmd = &cobra.Command{
+	Use:   "export-config",
+	Short: "Generate infrastructure-as-code configuration for your groups and databases",
+	Long: "Generate infrastructure-as-code configuration for the groups and databases of your organization.\n" +
+		"With " + internal.Emph("--format terraform") + ", resource blocks are written along with the " + internal.Emph("terraform import") + " commands that bring the existing resources under Terraform.",
+	Example:           "  turso export-config --format terraform -f turso.tf --import-script import.sh",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+
+		if exportConfigFormatFlag != "terraform" {
+			return fmt.Errorf("unknown format %q. Possible values: %s", exportConfigFormatFlag, strings.Join(exportConfigFormats, ", "))
+		}
+
+		client, err := authedTursoClient(ctx)
+		if err != nil {
+			return err
+		}
+		groups, databases, err := readGroupsAndDatabases(ctx, client)
+		if err != nil {
+			return err
+		}
+		config := terraform.Config{Groups: groups, Databases: databases}
+
+		if exportConfigImportScriptFlag != "" {
+			script := &strings.Builder{}
+			script.WriteString("#!/bin/sh\nset -e\n\n")
+			for _, resource := range config.Resources() {
+				script.WriteString(resource.ImportCommand() + "\n")
+			}
+			if err := os.WriteFile(exportConfigImportScriptFlag, []byte(script.String()), 0o755); err != nil {
+				return fmt.Errorf("could not write import script: %w", err)
+			}
+		}
+
+		if exportConfigFileFlag == "" {
+			return config.Write(os.Stdout)
+		}
+		file, err := os.Create(exportConfigFileFlag)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", exportConfigFileFlag, err)
+		}
+		if err := config.Write(file); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d groups and %d databases to %s.\n", len(groups), len(databases), exportConfigFileFlag)
+		return nil
+	},
+}
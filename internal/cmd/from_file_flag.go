@@ -4,6 +4,10 @@ import "github.com/spf13/cobra"
 
 var fromFileFlag string
 
+// fromFileIsTemporary is set when the file is removed once imported, so
+// that its upload can't be resumed.
+var fromFileIsTemporary bool
+
 func addDbFromFileFlag(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&fromFileFlag, "from-file", "", "create the database from a local SQLite3-compatible file")
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -35,23 +36,31 @@ var groupShowCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: groupArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		name := args[0]
-		group, err := getGroup(client, name)
+		group, err := getGroup(ctx, client, name)
 		if err != nil {
 			return err
 		}
 
-		config, err := client.Groups.GetConfig(group.Name)
+		config, err := client.Groups.GetConfig(ctx, group.Name)
 		if err != nil {
 			return err
 		}
 
+		if flags.StructuredOutput() {
+			out := toGroupOutput(group)
+			deleteProtection := config.IsDeleteProtected()
+			out.DeleteProtection = &deleteProtection
+			return printStructured(out)
+		}
+
 		version := group.Version
 		fmt.Printf("Locations:         %s\n", formatLocations(group.Locations, group.Primary))
 		fmt.Printf("Version:           %s\n", internal.Emph(version))
@@ -67,17 +76,22 @@ var groupsListCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		groups, err := getGroups(client, true)
+		groups, err := getGroups(ctx, client, true)
 		if err != nil {
 			return err
 		}
 
+		if flags.StructuredOutput() {
+			return printStructured(toGroupListOutput(groups))
+		}
+
 		printTable([]string{"Name", "Locations", "Version", "Status"}, groupsTable(groups))
 		return nil
 	},
@@ -89,19 +103,20 @@ var groupsCreateCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		location := locationFlag
 		if location == "" {
-			location, _ = closestLocation(client)
+			location, _ = closestLocation(ctx, client)
 		}
 
 		name := args[0]
-		return createGroup(client, name, location, flags.Version())
+		return createGroup(ctx, client, name, location, flags.Version())
 	},
 }
 
@@ -112,14 +127,15 @@ var unarchiveGroupCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: groupArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		name := args[0]
-		return unarchiveGroup(client, name)
+		return unarchiveGroup(ctx, client, name)
 	},
 }
 
@@ -129,15 +145,16 @@ var groupsDestroyCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: groupArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
 		name := args[0]
 		if yesFlag {
-			return destroyGroup(client, name)
+			return destroyGroup(ctx, client, name)
 		}
 
 		fmt.Printf("Group %s, all its replicas and databases will be destroyed.\n", internal.Emph(name))
@@ -152,22 +169,22 @@ var groupsDestroyCmd = &cobra.Command{
 			return nil
 		}
 
-		return destroyGroup(client, name)
+		return destroyGroup(ctx, client, name)
 	},
 }
 
-func createGroup(client *turso.Client, name, location, version string) error {
+func createGroup(ctx context.Context, client *turso.Client, name, location, version string) error {
 	start := time.Now()
 	description := fmt.Sprintf("Creating group %s at %s...", internal.Emph(name), internal.Emph(location))
 	spinner := prompt.Spinner(description)
 	defer spinner.Stop()
 
 	invalidateGroupsCache(client.Org)
-	if err := client.Groups.Create(name, location, version); err != nil {
+	if err := client.Groups.Create(ctx, name, location, version); err != nil {
 		return err
 	}
 
-	if err := handleGroupWaitFlag(client, name, location); err != nil {
+	if err := handleGroupWaitFlag(ctx, client, name, location); err != nil {
 		return err
 	}
 
@@ -178,12 +195,12 @@ func createGroup(client *turso.Client, name, location, version string) error {
 	return nil
 }
 
-func unarchiveGroup(client *turso.Client, name string) error {
+func unarchiveGroup(ctx context.Context, client *turso.Client, name string) error {
 	start := time.Now()
 	s := prompt.Spinner(fmt.Sprintf("Unarchiving group %s... ", internal.Emph(name)))
 	defer s.Stop()
 
-	if err := client.Groups.Unarchive(name); err != nil {
+	if err := client.Groups.Unarchive(ctx, name); err != nil {
 		return err
 	}
 	s.Stop()
@@ -193,13 +210,13 @@ func unarchiveGroup(client *turso.Client, name string) error {
 	return nil
 }
 
-func destroyGroup(client *turso.Client, name string) error {
+func destroyGroup(ctx context.Context, client *turso.Client, name string) error {
 	start := time.Now()
 	s := prompt.Spinner(fmt.Sprintf("Destroying group %s... ", internal.Emph(name)))
 	defer s.Stop()
 
 	invalidateGroupsCache(client.Org)
-	if err := client.Groups.Delete(name); err != nil {
+	if err := client.Groups.Delete(ctx, name); err != nil {
 		return err
 	}
 	s.Stop()
@@ -223,28 +240,41 @@ func groupsTable(groups []turso.Group) [][]string {
 }
 
 func aggregateGroupStatus(group turso.Group) string {
-	status := "Healthy"
-	if group.Archived {
+	switch groupHealth(group) {
+	case "archived":
 		return "Archived 💤"
+	case "unhealthy":
+		return "Unhealthy"
+	case "degraded":
+		return "Degraded"
+	default:
+		return "Healthy"
+	}
+}
+
+func groupHealth(group turso.Group) string {
+	status := "healthy"
+	if group.Archived {
+		return "archived"
 	}
 	for _, locationStatus := range group.Status.Locations {
 		if group.Primary == locationStatus.Name && locationStatus.Status == "down" {
-			status = "Unhealthy"
+			status = "unhealthy"
 			break
 		}
 		if locationStatus.Status == "down" {
-			status = "Degraded"
+			status = "degraded"
 		}
 	}
 	return status
 }
 
-func getGroups(client *turso.Client, fresh ...bool) ([]turso.Group, error) {
+func getGroups(ctx context.Context, client *turso.Client, fresh ...bool) ([]turso.Group, error) {
 	skipCache := len(fresh) > 0 && fresh[0]
 	if cached := getGroupsCache(client.Org); !skipCache && cached != nil {
 		return cached, nil
 	}
-	groups, err := listGroups(client)
+	groups, err := listGroups(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -252,22 +282,22 @@ func getGroups(client *turso.Client, fresh ...bool) ([]turso.Group, error) {
 	return groups, nil
 }
 
-func listGroups(client *turso.Client) ([]turso.Group, error) {
+func listGroups(ctx context.Context, client *turso.Client) ([]turso.Group, error) {
 	if !flags.V3Api() {
-		return client.Groups.List()
+		return client.Groups.List(ctx)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 	if orgID == "" {
-		return client.Groups.List()
+		return client.Groups.List(ctx)
 	}
-	return client.GroupsV3.List(orgID)
+	return client.GroupsV3.List(ctx, orgID)
 }
 
-func groupNames(client *turso.Client) ([]string, error) {
-	groups, err := getGroups(client)
+func groupNames(ctx context.Context, client *turso.Client) ([]string, error) {
+	groups, err := getGroups(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -278,29 +308,29 @@ func groupNames(client *turso.Client) ([]string, error) {
 	return names, nil
 }
 
-func getGroup(client *turso.Client, name string) (turso.Group, error) {
+func getGroup(ctx context.Context, client *turso.Client, name string) (turso.Group, error) {
 	if !flags.V3Api() {
-		return getGroupV2(client, name)
+		return getGroupV2(ctx, client, name)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return turso.Group{}, err
 	}
 	if orgID == "" {
-		return getGroupV2(client, name)
+		return getGroupV2(ctx, client, name)
 	}
-	groupID, err := tryResolveGroupID(client, name)
+	groupID, err := tryResolveGroupID(ctx, client, name)
 	if err != nil {
 		return turso.Group{}, err
 	}
 	if groupID == "" {
-		return getGroupV2(client, name)
+		return getGroupV2(ctx, client, name)
 	}
-	return client.GroupsV3.Get(orgID, groupID)
+	return client.GroupsV3.Get(ctx, orgID, groupID)
 }
 
-func getGroupV2(client *turso.Client, name string) (turso.Group, error) {
-	groups, err := getGroups(client)
+func getGroupV2(ctx context.Context, client *turso.Client, name string) (turso.Group, error) {
+	groups, err := getGroups(ctx, client)
 	if err != nil {
 		return turso.Group{}, err
 	}
@@ -312,23 +342,24 @@ func getGroupV2(client *turso.Client, name string) (turso.Group, error) {
 	return turso.Group{}, fmt.Errorf("group %s was not found", name)
 }
 
-func handleGroupWaitFlag(client *turso.Client, group, location string) error {
+func handleGroupWaitFlag(ctx context.Context, client *turso.Client, group, location string) error {
 	if !waitFlag {
 		return nil
 	}
-	return client.Groups.WaitLocation(group, location)
+	return client.Groups.WaitLocation(ctx, group, location)
 }
 
 func groupArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
 	if len(args) > 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 
-	client, err := authedTursoClient()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 
-	groups, _ := groupNames(client)
+	groups, _ := groupNames(ctx, client)
 	return groups, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 }
@@ -29,23 +29,24 @@ var groupAwsMigrationInfoCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		group := args[0]
 		if group == "" {
 			return errors.New("the first argument must contain a group name")
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		_, err = client.Groups.Get(group)
+		_, err = client.Groups.Get(ctx, group)
 		if err != nil {
 			return err
 		}
 
-		info, err := client.Groups.GetAwsMigrationInfo(group)
+		info, err := client.Groups.GetAwsMigrationInfo(ctx, group)
 		if err != nil {
 			return err
 		}
@@ -70,23 +71,24 @@ var groupAwsMigrationStartCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		group := args[0]
 		if group == "" {
 			return errors.New("the first argument must contain a group name")
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		_, err = client.Groups.Get(group)
+		_, err = client.Groups.Get(ctx, group)
 		if err != nil {
 			return err
 		}
 
-		info, err := client.Groups.GetAwsMigrationInfo(group)
+		info, err := client.Groups.GetAwsMigrationInfo(ctx, group)
 		if err != nil {
 			return err
 		}
@@ -117,12 +119,12 @@ var groupAwsMigrationStartCmd = &cobra.Command{
 		spinner := prompt.Spinner(fmt.Sprintf("AWS migration of group %v is in progress", group))
 		defer spinner.Stop()
 
-		err = client.Groups.StartAwsMigration(group)
+		err = client.Groups.StartAwsMigration(ctx, group)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 		defer cancel()
 
 		for {
@@ -135,7 +137,7 @@ var groupAwsMigrationStartCmd = &cobra.Command{
 					"Contact support@turso.tech in case of any issues\n", internal.Emph(group))
 				return nil
 			case <-time.NewTimer(5 * time.Second).C:
-				info, err := client.Groups.GetAwsMigrationInfo(group)
+				info, err := client.Groups.GetAwsMigrationInfo(ctx, group)
 				if err != nil {
 					return err
 				}
@@ -157,23 +159,24 @@ var groupAwsMigrationAbortCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		group := args[0]
 		if group == "" {
 			return errors.New("the first argument must contain a group name")
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		_, err = client.Groups.Get(group)
+		_, err = client.Groups.Get(ctx, group)
 		if err != nil {
 			return err
 		}
 
-		info, err := client.Groups.GetAwsMigrationInfo(group)
+		info, err := client.Groups.GetAwsMigrationInfo(ctx, group)
 		if err != nil {
 			return err
 		}
@@ -202,6 +205,6 @@ var groupAwsMigrationAbortCmd = &cobra.Command{
 		spinner := prompt.Spinner(fmt.Sprintf("AWS migration of group %v aborted", group))
 		defer spinner.Stop()
 
-		return client.Groups.AbortAwsMigration(group)
+		return client.Groups.AbortAwsMigration(ctx, group)
 	},
 }
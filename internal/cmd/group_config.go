@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -34,8 +35,9 @@ var groupEnableDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		return updateGroupDeleteProtection(args[0], true)
+		return updateGroupDeleteProtection(ctx, args[0], true)
 	},
 }
 
@@ -45,8 +47,9 @@ var groupDisableDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		return updateGroupDeleteProtection(args[0], false)
+		return updateGroupDeleteProtection(ctx, args[0], false)
 	},
 }
 
@@ -56,18 +59,19 @@ var groupShowDeleteProtectionCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: dbNameArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		group, err := getGroup(client, name)
+		group, err := getGroup(ctx, client, name)
 		if err != nil {
 			return err
 		}
-		config, err := client.Groups.GetConfig(group.Name)
+		config, err := client.Groups.GetConfig(ctx, group.Name)
 		if err != nil {
 			return err
 		}
@@ -76,16 +80,16 @@ var groupShowDeleteProtectionCmd = &cobra.Command{
 	},
 }
 
-func updateGroupDeleteProtection(name string, deleteProtection bool) error {
-	client, err := authedTursoClient()
+func updateGroupDeleteProtection(ctx context.Context, name string, deleteProtection bool) error {
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return err
 	}
-	group, err := getGroup(client, name)
+	group, err := getGroup(ctx, client, name)
 	if err != nil {
 		return err
 	}
-	return client.Groups.UpdateConfig(group.Name, turso.GroupConfig{DeleteProtection: &deleteProtection})
+	return client.Groups.UpdateConfig(ctx, group.Name, turso.GroupConfig{DeleteProtection: &deleteProtection})
 }
 
 func groupDeleteProtectionMessage(status bool) string {
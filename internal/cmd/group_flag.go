@@ -3,32 +3,35 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Clever/csvlint"
 	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
 	"github.com/tursodatabase/turso-cli/internal/flags"
 	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/sqlitefile"
 	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 var groupFlag string
 
 func addGroupFlag(cmd *cobra.Command) {
+	ctx := cmd.Context()
 	cmd.Flags().StringVar(&groupFlag, "group", "", "create the database in the specified group")
 	cmd.RegisterFlagCompletionFunc("group", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
-		groups, _ := groupNames(client)
+		groups, _ := groupNames(ctx, client)
 		return groups, cobra.ShellCompDirectiveNoFileComp
 	})
 }
@@ -59,7 +62,7 @@ func parseTimestampFlag() (*time.Time, error) {
 	return &timestamp, nil
 }
 
-func parseDBSeedFlags(client *turso.Client, isAWS bool, cipher string) (*turso.DBSeed, error) {
+func parseDBSeedFlags(ctx context.Context, client *turso.Client, isAWS bool, cipher string) (*turso.DBSeed, error) {
 	if countFlags(fromDBFlag, fromDumpFlag, fromFileFlag, fromDumpURLFlag, fromCSVFlag) > 1 {
 		return nil, errors.New("only one of --from prefixed flags can be used at a time")
 	}
@@ -80,11 +83,11 @@ func parseDBSeedFlags(client *turso.Client, isAWS bool, cipher string) (*turso.D
 	}
 
 	if fromFileFlag != "" {
-		return handleDBFile(client, fromFileFlag, isAWS, cipher)
+		return handleDBFile(ctx, client, fromFileFlag, isAWS, cipher)
 	}
 
 	if fromDumpFlag != "" {
-		return handleDumpFile(client, fromDumpFlag)
+		return handleDumpFile(ctx, client, fromDumpFlag)
 	}
 
 	if fromCSVFlag != "" {
@@ -92,7 +95,7 @@ func parseDBSeedFlags(client *turso.Client, isAWS bool, cipher string) (*turso.D
 		if err != nil {
 			return nil, err
 		}
-		return handleCSVFile(client, fromCSVFlag, csvTableNameFlag, csvSeparator, cipher)
+		return handleCSVFile(ctx, client, fromCSVFlag, csvTableNameFlag, csvSeparator, cipher)
 	}
 	if fromDumpURLFlag != "" {
 		return handleDumpURL(fromDumpURLFlag)
@@ -108,7 +111,7 @@ func handleDumpURL(url string) (*turso.DBSeed, error) {
 	}, nil
 }
 
-func handleDumpFile(client *turso.Client, file string) (*turso.DBSeed, error) {
+func handleDumpFile(ctx context.Context, client *turso.Client, file string) (*turso.DBSeed, error) {
 	dump, err := validateDumpFile(file)
 	if err != nil {
 		return nil, err
@@ -118,7 +121,7 @@ func handleDumpFile(client *turso.Client, file string) (*turso.DBSeed, error) {
 	spinner := prompt.Spinner("Uploading data...")
 	defer spinner.Stop()
 
-	dumpURL, err := client.Databases.UploadDump(dump)
+	dumpURL, err := client.Databases.UploadDump(ctx, dump)
 	if err != nil {
 		return nil, fmt.Errorf("could not upload dump: %w", err)
 	}
@@ -216,25 +219,11 @@ func checkIfDump(filename string) (bool, error) {
 
 // getReservedBytes retrieves the current reserved bytes setting from a SQLite database
 func getReservedBytes(dbPath string) (int, error) {
-	output, err := exec.Command("sqlite3", "-list", dbPath, ".filectrl reserve_bytes").CombinedOutput()
+	header, err := sqlitefile.ReadHeader(dbPath)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get reserved bytes: %w", err)
 	}
-	outputStr := strings.TrimSpace(string(output))
-
-	if strings.Contains(outputStr, ":") {
-		parts := strings.Split(outputStr, ":")
-		if len(parts) >= 2 {
-			outputStr = strings.TrimSpace(parts[1])
-		}
-	}
-
-	reservedBytes, err := strconv.Atoi(outputStr)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse reserved bytes from output '%s': %w", string(output), err)
-	}
-
-	return reservedBytes, nil
+	return header.ReservedBytes, nil
 }
 
 // validateReservedBytes checks if the database has the required reserved bytes for the given cipher
@@ -289,24 +278,24 @@ func sqliteFileIntegrityChecks(file string, cipher string) error {
 	if flags.Debug() {
 		log.Printf("Checking database settings...")
 	}
-	output, err := exec.Command("sqlite3", "-list", file, ".mode line",
-		"select journal_mode as j, page_size as p, auto_vacuum as a, encoding as e from pragma_journal_mode, pragma_page_size, pragma_auto_vacuum, pragma_encoding;").CombinedOutput()
+	header, err := sqlitefile.ReadHeader(file)
+	if errors.Is(err, sqlitefile.ErrNotDatabase) {
+		return fmt.Errorf("file %s is not a valid SQLite database file", file)
+	}
 	if err != nil {
-
 		return fmt.Errorf("failed to check database settings: %w", err)
 	}
 
-	settings := string(output)
-	if !strings.Contains(settings, "j: wal") && !strings.Contains(settings, "j = wal") {
+	if !header.WAL() {
 		return fmt.Errorf("database is not in WAL mode. Set it with 'sqlite3 %s 'PRAGMA journal_mode = WAL'", file)
 	}
-	if !strings.Contains(settings, "p: 4096") && !strings.Contains(settings, "p = 4096") {
+	if header.PageSize != 4096 {
 		return fmt.Errorf("database must use 4KB page size. you can set it with 'sqlite3 %s 'PRAGMA page_size = 4096; VACUUM;' Note that this is not possible to do if your database is already in WAL mode", file)
 	}
-	if !strings.Contains(settings, "a: 0") && !strings.Contains(settings, "a = 0") {
+	if header.AutoVacuum != 0 {
 		return fmt.Errorf("database must have autovacuum disabled. you can set it with 'sqlite3 %s 'PRAGMA auto_vacuum = 0;'", file)
 	}
-	if !strings.Contains(settings, "e: UTF-8") && !strings.Contains(settings, "e = UTF-8") {
+	if header.Encoding != sqlitefile.EncodingUTF8 {
 		return fmt.Errorf("database must use UTF-8 encoding. you can set it with 'sqlite3 %s 'PRAGMA encoding = 'UTF-8'	", file)
 	}
 
@@ -332,39 +321,54 @@ func sqliteFileIntegrityChecks(file string, cipher string) error {
 	return nil
 }
 
+// runQuickCheck checks the integrity of file with the SQLite library
+// embedded in the CLI, which also applies the changes left in its WAL.
 func runQuickCheck(file string) error {
-	cmd := exec.Command("sqlite3", "-list", file, "pragma quick_check;")
-	if err := cmd.Run(); err != nil {
+	if err := sqlitefile.QuickCheck(file); err != nil {
 		return fmt.Errorf("integrity check failed: %w", err)
 	}
 	return nil
 }
 
 func handleDBFileAWS(file string, cipher string) (*turso.DBSeed, error) {
+	if interrupted, err := turso.FindInterruptedImport(file); err != nil {
+		return nil, err
+	} else if interrupted != nil {
+		return nil, fmt.Errorf("the import of %s to database %s was interrupted after %d of %d chunks.\nContinue it with %s, or destroy the database with %s and remove %s to start over",
+			file, interrupted.Database, interrupted.UploadedChunks, interrupted.TotalChunks,
+			internal.Emph("turso db import --resume "+file), internal.Emph("turso db destroy "+interrupted.Database), turso.ImportStatePath(file))
+	}
+	uploadOptions, err := uploadOptionsFromFlags()
+	if err != nil {
+		return nil, err
+	}
 	if err := sqliteFileIntegrityChecks(file, cipher); err != nil {
 		return nil, err
 	}
 
 	seed := &turso.DBSeed{
-		Type:     "database_upload",
-		Filepath: file,
+		Type:      "database_upload",
+		Filepath:  file,
+		Resumable: !fromFileIsTemporary,
+		Upload:    uploadOptions,
 	}
 
 	return seed, nil
 }
 
-func handleDBFile(client *turso.Client, file string, isAWS bool, cipher string) (*turso.DBSeed, error) {
+func handleDBFile(ctx context.Context, client *turso.Client, file string, isAWS bool, cipher string) (*turso.DBSeed, error) {
 	if err := checkFileExists(file); err != nil {
 		return nil, err
 	}
-	if err := checkSQLiteAvailable(); err != nil {
-		return nil, err
-	}
 
 	if isAWS {
 		return handleDBFileAWS(file, cipher)
 	}
 
+	// The database is dumped with the sqlite3 command
+	if err := checkSQLiteAvailable(); err != nil {
+		return nil, err
+	}
 	if err := checkSQLiteFile(file); err != nil {
 		return nil, err
 	}
@@ -378,7 +382,7 @@ func handleDBFile(client *turso.Client, file string, isAWS bool, cipher string)
 		return nil, err
 	}
 
-	return handleDumpFile(client, tmp.Name())
+	return handleDumpFile(ctx, client, tmp.Name())
 }
 
 func checkFileExists(file string) error {
@@ -398,15 +402,12 @@ func checkSQLiteAvailable() error {
 }
 
 func checkSQLiteFile(file string) error {
-	output, err := exec.Command("sqlite3", "-list", file, "pragma quick_check;").CombinedOutput()
-
-	execErr := &exec.ExitError{}
-	if errors.As(err, &execErr) && execErr.ExitCode() == 26 {
+	err := sqlitefile.QuickCheck(file)
+	if errors.Is(err, sqlitefile.ErrNotDatabase) {
 		return fmt.Errorf("file %s is not a valid SQLite database file", file)
 	}
-
 	if err != nil {
-		return fmt.Errorf("could not check database file: %w: %s", err, output)
+		return fmt.Errorf("could not check database file: %w", err)
 	}
 	return nil
 }
@@ -432,7 +433,7 @@ func dumpSQLiteDatabase(database string, dump *os.File) error {
 	return nil
 }
 
-func handleCSVFile(client *turso.Client, file, csvTableName string, separator rune, cipher string) (*turso.DBSeed, error) {
+func handleCSVFile(ctx context.Context, client *turso.Client, file, csvTableName string, separator rune, cipher string) (*turso.DBSeed, error) {
 	if err := checkFileExists(file); err != nil {
 		return nil, err
 	}
@@ -462,7 +463,7 @@ func handleCSVFile(client *turso.Client, file, csvTableName string, separator ru
 		return nil, err
 	}
 
-	seed, err := handleDBFile(client, tempDB.Name(), false, cipher)
+	seed, err := handleDBFile(ctx, client, tempDB.Name(), false, cipher)
 	if err != nil {
 		return nil, err
 	}
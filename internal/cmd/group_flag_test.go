@@ -3,30 +3,27 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"testing"
 
+	"github.com/ncruces/go-sqlite3"
 	"github.com/stretchr/testify/require"
+	"github.com/tursodatabase/turso-cli/internal/sqlitefile"
 )
 
 // createTestDatabase creates a valid WAL-mode test database with approximately the given size
 func createTestDatabase(t *testing.T, sizeBytes int) string {
 	t.Helper()
 
-	if _, err := exec.LookPath("sqlite3"); err != nil {
-		t.Skip("sqlite3 not available, skipping test")
-	}
-
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
 
+	conn, err := sqlite3.Open(dbPath)
+	require.NoError(t, err)
+	defer conn.Close()
+
 	// Create database with correct settings for Turso
-	cmd := exec.Command("sqlite3", "-list", dbPath,
-		"PRAGMA page_size=4096;",
-		"PRAGMA journal_mode=WAL;",
-		"CREATE TABLE data (id INTEGER PRIMARY KEY, blob BLOB);")
-	require.NoError(t, cmd.Run(), "failed to create test database")
+	require.NoError(t, conn.Exec("PRAGMA page_size=4096; PRAGMA journal_mode=WAL; CREATE TABLE data (id INTEGER PRIMARY KEY, blob BLOB);"), "failed to create test database")
 
 	// Fill with data to reach target size
 	if sizeBytes > 0 {
@@ -36,9 +33,7 @@ func createTestDatabase(t *testing.T, sizeBytes int) string {
 			numRows = 1
 		}
 		for i := 0; i < numRows; i++ {
-			cmd = exec.Command("sqlite3", "-list", dbPath,
-				fmt.Sprintf("INSERT INTO data (blob) VALUES (randomblob(%d));", rowSize))
-			require.NoError(t, cmd.Run())
+			require.NoError(t, conn.Exec(fmt.Sprintf("INSERT INTO data (blob) VALUES (randomblob(%d));", rowSize)))
 		}
 	}
 
@@ -46,10 +41,6 @@ func createTestDatabase(t *testing.T, sizeBytes int) string {
 }
 
 func TestRunQuickCheck(t *testing.T) {
-	if _, err := exec.LookPath("sqlite3"); err != nil {
-		t.Skip("sqlite3 not available, skipping test")
-	}
-
 	t.Run("valid database succeeds", func(t *testing.T) {
 		dbPath := createTestDatabase(t, 10*1024) // 10KB
 		err := runQuickCheck(dbPath)
@@ -74,3 +65,56 @@ func TestRunQuickCheck(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSqliteFileIntegrityChecks_WithoutSQLiteCommand(t *testing.T) {
+	dbPath := createTestDatabase(t, 10*1024)
+	emptyDBPath := createTestDatabase(t, 0)
+
+	// The checks don't need the sqlite3 command
+	t.Setenv("PATH", "")
+	require.NoError(t, sqliteFileIntegrityChecks(dbPath, ""))
+
+	t.Run("rollback journal database", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "journal.db")
+		content, err := os.ReadFile(emptyDBPath)
+		require.NoError(t, err)
+		content[18], content[19] = 1, 1
+		require.NoError(t, os.WriteFile(dbPath, content, 0o644))
+
+		err = sqliteFileIntegrityChecks(dbPath, "")
+		require.ErrorContains(t, err, "database is not in WAL mode")
+	})
+
+	t.Run("not a database", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "garbage.db")
+		require.NoError(t, os.WriteFile(dbPath, []byte("not a valid sqlite database content here"), 0o644))
+
+		err := sqliteFileIntegrityChecks(dbPath, "")
+		require.ErrorContains(t, err, "is not a valid SQLite database file")
+	})
+}
+
+func TestPrepareDatabaseFile(t *testing.T) {
+	dbPath := createTestDatabase(t, 10*1024)
+	original, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+
+	// A database with the required settings is imported as is
+	prepared, changes, err := prepareDatabaseFile(dbPath, t.TempDir(), "")
+	require.NoError(t, err)
+	require.Equal(t, dbPath, prepared)
+	require.Empty(t, changes)
+
+	cipher := "aes256gcm"
+	require.ErrorContains(t, sqliteFileIntegrityChecks(dbPath, cipher), "database reserved bytes mismatch")
+	prepared, changes, err = prepareDatabaseFile(dbPath, t.TempDir(), cipher)
+	require.NoError(t, err)
+	require.NotEqual(t, dbPath, prepared)
+	require.Equal(t, []sqlitefile.Change{{Setting: "reserved bytes", From: "0", To: "28"}}, changes)
+	require.NoError(t, sqliteFileIntegrityChecks(prepared, cipher))
+
+	// The original file is untouched
+	content, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, original, content)
+}
@@ -35,6 +35,7 @@ var groupLocationsListCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showEdgeReplicaDeprecationNotice()
 
 		group := args[0]
@@ -43,12 +44,12 @@ var groupLocationsListCmd = &cobra.Command{
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		groups, err := client.Groups.Get(group)
+		groups, err := client.Groups.Get(ctx, group)
 		if err != nil {
 			return err
 		}
@@ -64,6 +65,7 @@ var groupLocationAddCmd = &cobra.Command{
 	Args:              cobra.MinimumNArgs(1),
 	ValidArgsFunction: locationsAddArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showEdgeReplicaDeprecationNotice()
 
 		groupName := args[0]
@@ -72,12 +74,12 @@ var groupLocationAddCmd = &cobra.Command{
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		group, err := client.Groups.Get(groupName)
+		group, err := client.Groups.Get(ctx, groupName)
 		if err != nil {
 			return err
 		}
@@ -85,7 +87,7 @@ var groupLocationAddCmd = &cobra.Command{
 		for _, location := range group.Locations {
 			alreadyExistingLocations[location] = true
 		}
-		available, err := locations(client)
+		available, err := locations(ctx, client)
 		if err != nil {
 			return err
 		}
@@ -98,7 +100,7 @@ var groupLocationAddCmd = &cobra.Command{
 		}
 
 		for _, location := range locations {
-			if !isValidLocation(client, location) {
+			if !isValidLocation(ctx, client, location) {
 				return fmt.Errorf("location '%s' is not a valid one", location)
 			}
 			if alreadyExistingLocations[location] {
@@ -115,11 +117,11 @@ var groupLocationAddCmd = &cobra.Command{
 			description := fmt.Sprintf("Replicating group %s to %s...", internal.Emph(groupName), internal.Emph(location))
 			spinner.Text(description)
 
-			if err := client.Groups.AddLocation(groupName, location); err != nil {
+			if err := client.Groups.AddLocation(ctx, groupName, location); err != nil {
 				return fmt.Errorf("failed to replicate group %s to %s: %w", groupName, location, err)
 			}
 
-			if err := handleGroupWaitFlag(client, groupName, location); err != nil {
+			if err := handleGroupWaitFlag(ctx, client, groupName, location); err != nil {
 				return fmt.Errorf("failed to wait for group %s to be ready on location %s: %w", groupName, location, err)
 			}
 		}
@@ -143,6 +145,7 @@ var groupsLocationsRmCmd = &cobra.Command{
 	Args:              cobra.MinimumNArgs(2),
 	ValidArgsFunction: locationsRmArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		showEdgeReplicaDeprecationNotice()
 
 		groupName := args[0]
@@ -151,19 +154,19 @@ var groupsLocationsRmCmd = &cobra.Command{
 		}
 
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		group, err := client.Groups.Get(groupName)
+		group, err := client.Groups.Get(ctx, groupName)
 		if err != nil {
 			return err
 		}
 
 		locations := args[1:]
 		for _, location := range locations {
-			if !isValidLocation(client, location) {
+			if !isValidLocation(ctx, client, location) {
 				return fmt.Errorf("location '%s' is not a valid one", location)
 			}
 			if group.Primary == location {
@@ -180,7 +183,7 @@ var groupsLocationsRmCmd = &cobra.Command{
 			description := fmt.Sprintf("Removing group %s from %s...", internal.Emph(groupName), internal.Emph(location))
 			spinner.Text(description)
 
-			if err := client.Groups.RemoveLocation(groupName, location); err != nil {
+			if err := client.Groups.RemoveLocation(ctx, groupName, location); err != nil {
 				return fmt.Errorf("failed to remove group %s from %s: %w", groupName, location, err)
 			}
 		}
@@ -199,23 +202,24 @@ var groupsLocationsRmCmd = &cobra.Command{
 }
 
 func locationsAddArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
 	if len(args) == 0 {
 		return groupArgs(cmd, args, toComplete)
 	}
 
-	client, err := authedTursoClient()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 
-	locations, _ := locations(client)
+	locations, _ := locations(ctx, client)
 
 	used := args[1:]
 	for _, location := range used {
 		delete(locations, location)
 	}
 
-	group, _ := getGroup(client, args[0])
+	group, _ := getGroup(ctx, client, args[0])
 	for _, location := range group.Locations {
 		delete(locations, location)
 	}
@@ -224,16 +228,17 @@ func locationsAddArgs(cmd *cobra.Command, args []string, toComplete string) ([]s
 }
 
 func locationsRmArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
 	if len(args) == 0 {
 		return groupArgs(cmd, args, toComplete)
 	}
 
-	client, err := authedTursoClient()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 
-	group, _ := getGroup(client, args[0])
+	group, _ := getGroup(ctx, client, args[0])
 	locations := make(map[string]bool, len(group.Locations))
 	for _, location := range group.Locations {
 		locations[location] = true
@@ -248,11 +253,12 @@ func locationsRmArgs(cmd *cobra.Command, args []string, toComplete string) ([]st
 }
 
 func groupArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 
-	groups, _ := groupNames(client)
+	groups, _ := groupNames(ctx, client)
 	return groups, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -21,20 +22,21 @@ var groupRenameCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: groupArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		oldName := args[0]
 		newName := args[1]
 
-		if _, err := getGroup(client, oldName); err != nil {
+		if _, err := getGroup(ctx, client, oldName); err != nil {
 			return err
 		}
 
 		if yesFlag {
-			return renameGroup(client, oldName, newName)
+			return renameGroup(ctx, client, oldName, newName)
 		}
 
 		ok, err := promptConfirmation(fmt.Sprintf("Are you sure you want to rename group %s to %s?", internal.Emph(oldName), internal.Emph(newName)))
@@ -47,16 +49,16 @@ var groupRenameCmd = &cobra.Command{
 			return nil
 		}
 
-		return renameGroup(client, oldName, newName)
+		return renameGroup(ctx, client, oldName, newName)
 	},
 }
 
-func renameGroup(client *turso.Client, oldName, newName string) error {
+func renameGroup(ctx context.Context, client *turso.Client, oldName, newName string) error {
 	msg := fmt.Sprintf("Renaming group %s to %s", internal.Emph(oldName), internal.Emph(newName))
 	s := prompt.Spinner(msg)
 	defer s.Stop()
 
-	if err := client.Groups.Rename(oldName, newName); err != nil {
+	if err := client.Groups.Rename(ctx, oldName, newName); err != nil {
 		return err
 	}
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -33,20 +34,21 @@ var groupTokensInvalidateCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: groupArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		group, err := getGroup(client, name)
+		group, err := getGroup(ctx, client, name)
 		if err != nil {
 			return err
 		}
 
 		if flags.Yes() {
-			return rotateGroup(client, group)
+			return rotateGroup(ctx, client, group)
 		}
 
 		fmt.Printf("To invalidate tokens for group %s, tokens from all its databases will be invalidated.\n", internal.Emph(name))
@@ -62,18 +64,18 @@ var groupTokensInvalidateCmd = &cobra.Command{
 			return nil
 		}
 
-		return rotateGroup(client, group)
+		return rotateGroup(ctx, client, group)
 	},
 }
 
-func rotateGroup(turso *turso.Client, group turso.Group) error {
+func rotateGroup(ctx context.Context, turso *turso.Client, group turso.Group) error {
 	s := prompt.Spinner("Invalidating group tokens... ")
 	defer s.Stop()
 
 	invalidateDbTokenCache()
 	settings.PersistChanges()
 
-	if err := turso.Groups.Rotate(group.Name); err != nil {
+	if err := turso.Groups.Rotate(ctx, group.Name); err != nil {
 		return err
 	}
 
@@ -97,14 +99,15 @@ var groupCreateTokenCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: groupArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		name := args[0]
 
-		group, err := getGroup(client, name)
+		group, err := getGroup(ctx, client, name)
 		if err != nil {
 			return err
 		}
@@ -115,7 +118,7 @@ var groupCreateTokenCmd = &cobra.Command{
 		}
 		var claim *turso.PermissionsClaim
 		if len(flags.AttachClaims()) > 0 {
-			err := validateDBNames(client, flags.AttachClaims())
+			err := validateDBNames(ctx, client, flags.AttachClaims())
 			if err != nil {
 				return err
 			}
@@ -127,7 +130,7 @@ var groupCreateTokenCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		token, err := getGroupToken(client, group, expiration, flags.ReadOnly(), claim, permission)
+		token, err := getGroupToken(ctx, client, group, expiration, flags.ReadOnly(), claim, permission)
 		if err != nil {
 			return fmt.Errorf("error creating token: %w", err)
 		}
@@ -141,6 +144,7 @@ var groupCreateTokenCmd = &cobra.Command{
 // org/group IDs can be resolved. The V3 endpoint does not support attach
 // claims, so requests with a claim fall back to the V2 API.
 func getGroupToken(
+	ctx context.Context,
 	client *turso.Client,
 	group turso.Group,
 	expiration string,
@@ -149,27 +153,27 @@ func getGroupToken(
 	fineGrainedPermissions []flags.FineGrainedPermissions,
 ) (string, error) {
 	if !flags.V3Api() || claim != nil {
-		return client.Groups.Token(group.Name, expiration, readOnly, claim, fineGrainedPermissions)
+		return client.Groups.Token(ctx, group.Name, expiration, readOnly, claim, fineGrainedPermissions)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return "", err
 	}
 	groupID := group.UUID
 	if groupID == "" {
-		groupID, err = tryResolveGroupID(client, group.Name)
+		groupID, err = tryResolveGroupID(ctx, client, group.Name)
 		if err != nil {
 			return "", err
 		}
 	}
 	if orgID == "" || groupID == "" {
-		return client.Groups.Token(group.Name, expiration, readOnly, claim, fineGrainedPermissions)
+		return client.Groups.Token(ctx, group.Name, expiration, readOnly, claim, fineGrainedPermissions)
 	}
-	return client.GroupsV3.Token(orgID, groupID, expiration, readOnly, fineGrainedPermissions)
+	return client.GroupsV3.Token(ctx, orgID, groupID, expiration, readOnly, fineGrainedPermissions)
 }
 
-func validateDBNames(client *turso.Client, dbNames []string) error {
-	databasesMap, err := getDatabasesMap(client, false)
+func validateDBNames(ctx context.Context, client *turso.Client, dbNames []string) error {
+	databasesMap, err := getDatabasesMap(ctx, client, false)
 	if err != nil {
 		return err
 	}
@@ -177,7 +181,7 @@ func validateDBNames(client *turso.Client, dbNames []string) error {
 	if len(missingDbs) == 0 {
 		return nil
 	}
-	databasesMap, err = getDatabasesMap(client, true)
+	databasesMap, err = getDatabasesMap(ctx, client, true)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -21,20 +22,21 @@ var groupTransferCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: groupTransferArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 		group := args[0]
 		org := args[1]
 
-		if _, err := getGroup(client, group); err != nil {
+		if _, err := getGroup(ctx, client, group); err != nil {
 			return err
 		}
 
 		if yesFlag {
-			return transferGroup(client, group, org)
+			return transferGroup(ctx, client, group, org)
 		}
 
 		fmt.Printf("Once group %s is transfered, all its locations and databases will belong to organization %s.\n\n", internal.Emph(group), internal.Emph(org))
@@ -53,16 +55,16 @@ var groupTransferCmd = &cobra.Command{
 			return nil
 		}
 
-		return transferGroup(client, group, org)
+		return transferGroup(ctx, client, group, org)
 	},
 }
 
-func transferGroup(client *turso.Client, group, organization string) error {
+func transferGroup(ctx context.Context, client *turso.Client, group, organization string) error {
 	msg := fmt.Sprintf("Transfering group %s to organization %s", internal.Emph(group), internal.Emph(organization))
 	s := prompt.Spinner(msg)
 	defer s.Stop()
 
-	if err := client.Groups.Transfer(group, organization); err != nil {
+	if err := client.Groups.Transfer(ctx, group, organization); err != nil {
 		return fmt.Errorf("error transfering group: %w", err)
 	}
 
@@ -83,11 +85,12 @@ func groupTransferArgs(cmd *cobra.Command, args []string, toComplete string) ([]
 }
 
 func organizationArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
-	orgs, _ := listOrganizations(client)
+	orgs, _ := listOrganizations(ctx, client)
 	slugs := make([]string, 0, len(orgs))
 	for _, org := range orgs {
 		slugs = append(slugs, org.Slug)
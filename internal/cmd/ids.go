@@ -1,17 +1,19 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
-func tryResolveOrgID(client *turso.Client) (string, error) {
+func tryResolveOrgID(ctx context.Context, client *turso.Client) (string, error) {
 	slug := client.Org
 	if orgs := getOrgsCache(); orgs != nil {
 		if id := findOrgID(orgs, slug); id != "" {
 			return id, nil
 		}
 	}
-	orgs, err := client.Organizations.List()
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -28,7 +30,7 @@ func findOrgID(orgs []turso.Organization, slug string) string {
 	return ""
 }
 
-func tryResolveGroupID(client *turso.Client, name string) (string, error) {
+func tryResolveGroupID(ctx context.Context, client *turso.Client, name string) (string, error) {
 	if groups := getGroupsCache(client.Org); groups != nil {
 		for _, g := range groups {
 			if g.Name == name && g.UUID != "" {
@@ -36,7 +38,7 @@ func tryResolveGroupID(client *turso.Client, name string) (string, error) {
 			}
 		}
 	}
-	group, err := client.Groups.Get(name)
+	group, err := client.Groups.Get(ctx, name)
 	if err != nil {
 		return "", err
 	}
@@ -60,7 +62,7 @@ func mergeGroupIntoCache(org string, group turso.Group) {
 	setGroupsCache(org, groups)
 }
 
-func tryResolveDbID(client *turso.Client, name string) (string, error) {
+func tryResolveDbID(ctx context.Context, client *turso.Client, name string) (string, error) {
 	if dbs := getDatabasesCache(); dbs != nil {
 		for _, db := range dbs {
 			if db.Name == name && db.ID != "" {
@@ -68,7 +70,7 @@ func tryResolveDbID(client *turso.Client, name string) (string, error) {
 			}
 		}
 	}
-	db, err := client.Databases.Get(name)
+	db, err := client.Databases.Get(ctx, name)
 	if err != nil {
 		return "", err
 	}
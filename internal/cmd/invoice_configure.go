@@ -18,13 +18,14 @@ var configureInvoiceInfoCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		oldCustomer, err := client.Billing.GetBillingCustomer()
+		oldCustomer, err := client.Billing.GetBillingCustomer(ctx)
 		if err != nil {
 			return err
 		}
@@ -34,12 +35,12 @@ var configureInvoiceInfoCmd = &cobra.Command{
 			return err
 		}
 
-		err = client.Billing.UpdateBillingCustomer(customer)
+		err = client.Billing.UpdateBillingCustomer(ctx, customer)
 		if err != nil {
 			return err
 		}
 
-		customer, err = client.Billing.GetBillingCustomer()
+		customer, err = client.Billing.GetBillingCustomer(ctx)
 		if err != nil {
 			return err
 		}
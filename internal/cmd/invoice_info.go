@@ -17,13 +17,14 @@ var showInvoiceInfoCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		customer, err := client.Billing.GetBillingCustomer()
+		customer, err := client.Billing.GetBillingCustomer(ctx)
 		if err != nil {
 			return err
 		}
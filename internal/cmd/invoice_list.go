@@ -19,8 +19,9 @@ var listInvoicesCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -30,11 +31,15 @@ var listInvoicesCmd = &cobra.Command{
 			return err
 		}
 
-		invoices, err := client.Invoices.List(invoiceType)
+		invoices, err := client.Invoices.List(ctx, invoiceType)
 		if err != nil {
 			return err
 		}
 
+		if flags.StructuredOutput() {
+			return printStructured(toInvoiceListOutput(invoices))
+		}
+
 		if len(invoices) == 0 {
 			fmt.Println("No invoices found.")
 			return nil
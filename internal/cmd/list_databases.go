@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -8,14 +9,16 @@ import (
 	"golang.org/x/term"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tursodatabase/turso-cli/internal/flags"
 	"github.com/tursodatabase/turso-cli/internal/turso"
 )
 
 type PageFetcher interface {
-	FetchPage(pageSize int, cursor *string) (turso.ListResponse, error)
+	FetchPage(ctx context.Context, pageSize int, cursor *string) (turso.ListResponse, error)
 }
 
 type dbListModel struct {
+	ctx       context.Context
 	databases []turso.Database
 	page      int
 	pageSize  int
@@ -30,8 +33,8 @@ func (m dbListModel) Init() tea.Cmd {
 	return m.fetchNextPage
 }
 
-func fetchPage(fetcher PageFetcher, pageSize int, cursor *string) (dbPageMsg, error) {
-	r, err := fetcher.FetchPage(pageSize, cursor)
+func fetchPage(ctx context.Context, fetcher PageFetcher, pageSize int, cursor *string) (dbPageMsg, error) {
+	r, err := fetcher.FetchPage(ctx, pageSize, cursor)
 	if err != nil {
 		return dbPageMsg{}, err
 	}
@@ -48,7 +51,7 @@ func fetchPage(fetcher PageFetcher, pageSize int, cursor *string) (dbPageMsg, er
 }
 
 func (m dbListModel) fetchNextPage() tea.Msg {
-	r, err := fetchPage(m.fetcher, m.pageSize, m.cursor)
+	r, err := fetchPage(m.ctx, m.fetcher, m.pageSize, m.cursor)
 	if err != nil {
 		return errMsg{err}
 	}
@@ -168,22 +171,38 @@ func (m dbListModel) View() string {
 	return s.String()
 }
 
-func printDatabaseList(fetcher PageFetcher) error {
-	if !isInteractive() {
-		var allDatabases []turso.Database
-		var cursor *string
+func fetchAllDatabases(ctx context.Context, fetcher PageFetcher) ([]turso.Database, error) {
+	var allDatabases []turso.Database
+	var cursor *string
 
-		for {
-			r, err := fetchPage(fetcher, 1000, cursor)
-			if err != nil {
-				return err
-			}
+	for {
+		r, err := fetchPage(ctx, fetcher, 1000, cursor)
+		if err != nil {
+			return nil, err
+		}
 
-			allDatabases = append(allDatabases, r.databases...)
-			cursor = r.cursor
-			if cursor == nil {
-				break
-			}
+		allDatabases = append(allDatabases, r.databases...)
+		cursor = r.cursor
+		if cursor == nil {
+			break
+		}
+	}
+	return allDatabases, nil
+}
+
+func printDatabaseList(ctx context.Context, fetcher PageFetcher) error {
+	if flags.StructuredOutput() {
+		allDatabases, err := fetchAllDatabases(ctx, fetcher)
+		if err != nil {
+			return err
+		}
+		return printStructured(toDatabaseListOutput(allDatabases))
+	}
+
+	if !isInteractive() {
+		allDatabases, err := fetchAllDatabases(ctx, fetcher)
+		if err != nil {
+			return err
 		}
 
 		model := dbListModel{
@@ -201,6 +220,7 @@ func printDatabaseList(fetcher PageFetcher) error {
 	}
 
 	model := dbListModel{
+		ctx:      ctx,
 		pageSize: pageSize,
 		fetcher:  fetcher,
 	}
@@ -8,13 +8,14 @@ import (
 var locationFlag string
 
 func addLocationFlag(cmd *cobra.Command, desc string) {
+	ctx := cmd.Context()
 	cmd.Flags().StringVar(&locationFlag, "location", "", desc)
 	cmd.RegisterFlagCompletionFunc("location", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
-		locations, _ := locations(client)
+		locations, _ := locations(ctx, client)
 		return maps.Keys(locations), cobra.ShellCompDirectiveNoFileComp
 	})
 }
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/manifest"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+var manifestFileFlag string
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	planCmd.Flags().StringVarP(&manifestFileFlag, "file", "f", "", "Show the changes needed to make the organization match the groups and databases of a manifest")
+	applyCmd.Flags().StringVarP(&manifestFileFlag, "file", "f", "", "Manifest of the groups and databases to apply")
+	applyCmd.MarkFlagRequired("file")
+	addYesFlag(applyCmd, "Apply the changes without asking for confirmation.")
+
+	planCmd.Args = cobra.NoArgs
+	planCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if manifestFileFlag == "" {
+			return cmd.Help()
+		}
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+		_, plan, err := manifestPlan(ctx, manifestFileFlag)
+		if err != nil {
+			return err
+		}
+		if flags.StructuredOutput() {
+			return printStructured(toManifestPlanOutput(plan))
+		}
+		printManifestPlan(plan)
+		return nil
+	}
+}
+
+var applyCmd = &cobra.Command{
+	Use:               "apply -f <manifest>",
+	Short:             "Create and configure groups and databases to match a manifest",
+	Long:              "Create and configure groups and databases to match a manifest.\nGroups and databases missing from the manifest are left untouched.\nRun " + internal.Emph("turso plan -f <manifest>") + " to review the changes first.",
+	Example:           "  turso plan -f turso.yaml\n  turso apply -f turso.yaml --yes",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+		client, plan, err := manifestPlan(ctx, manifestFileFlag)
+		if err != nil {
+			return err
+		}
+		printManifestPlan(plan)
+		if plan.Empty() {
+			return nil
+		}
+		for _, change := range plan.Changes {
+			if db := change.Database; change.Kind == manifest.CreateDatabase && db.Encryption != nil && os.Getenv(db.Encryption.KeyEnv) == "" {
+				return fmt.Errorf("the encryption key of database %s must be set in %s", change.Name, internal.Emph(db.Encryption.KeyEnv))
+			}
+		}
+
+		if !yesFlag {
+			ok, err := promptConfirmation("Apply these changes?")
+			if err != nil {
+				return fmt.Errorf("could not get prompt confirmed by user: %w", err)
+			}
+			if !ok {
+				fmt.Println("No changes applied.")
+				return nil
+			}
+		}
+
+		start := time.Now()
+		for i, change := range plan.Changes {
+			if err := applyManifestChange(ctx, client, change); err != nil {
+				return fmt.Errorf("could not %s: %w\n%d of %d changes were applied. Run %s again to apply the rest", change.Description, err, i, len(plan.Changes), internal.Emph("turso apply"))
+			}
+		}
+		invalidateGroupsCache(client.Org)
+		invalidateDatabasesCache()
+		fmt.Printf("\nApplied %d changes in %s.\n", len(plan.Changes), time.Since(start).Round(time.Millisecond))
+		return nil
+	},
+}
+
+// manifestPlan loads a manifest, validates the parts checked by the CLI and
+// diffs it against the current state of the organization.
+func manifestPlan(ctx context.Context, path string) (*turso.Client, *manifest.Plan, error) {
+	m, err := manifest.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, db := range m.Databases {
+		if rules := db.AllowRules; rules != nil {
+			if rules.IPs != nil {
+				ips := normalizeAllowRuleEntries(*rules.IPs)
+				if err := validateAllowedIPs(ips); err != nil {
+					return nil, nil, fmt.Errorf("database %s: %w", name, err)
+				}
+				rules.IPs = &ips
+			}
+			if rules.AwsVpcs != nil {
+				vpcs := normalizeAllowRuleEntries(*rules.AwsVpcs)
+				if err := validateAllowedVpcIDs(vpcs); err != nil {
+					return nil, nil, fmt.Errorf("database %s: %w", name, err)
+				}
+				rules.AwsVpcs = &vpcs
+			}
+		}
+	}
+
+	client, err := authedTursoClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spinner := prompt.Spinner("Comparing the manifest with your organization...")
+	defer spinner.Stop()
+	state, err := manifestState(ctx, client, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := manifest.Diff(m, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, plan, nil
+}
+
+// manifestState fetches the groups and databases of the organization, and the
+// configuration of the ones named in the manifest.
+func manifestState(ctx context.Context, client *turso.Client, m *manifest.Manifest) (manifest.State, error) {
+	state := manifest.State{
+		Groups:    map[string]manifest.GroupState{},
+		Databases: map[string]manifest.DatabaseState{},
+	}
+
+	groups, err := getGroups(ctx, client, true)
+	if err != nil {
+		return state, err
+	}
+	for _, group := range groups {
+		current := manifest.GroupState{Group: group}
+		if _, ok := m.Groups[group.Name]; ok {
+			if current.Config, err = client.Groups.GetConfig(ctx, group.Name); err != nil {
+				return state, err
+			}
+		}
+		state.Groups[group.Name] = current
+	}
+
+	databases, err := getDatabases(ctx, client, true)
+	if err != nil {
+		return state, err
+	}
+	for _, db := range databases {
+		if _, ok := m.Databases[db.Name]; !ok {
+			continue
+		}
+		config, err := getDatabaseConfig(ctx, client, db.Name)
+		if err != nil {
+			return state, err
+		}
+		state.Databases[db.Name] = manifest.DatabaseState{Database: db, Config: config}
+	}
+	return state, nil
+}
+
+func printManifestPlan(plan *manifest.Plan) {
+	for _, warning := range plan.Warnings {
+		fmt.Printf("%s: %s\n", internal.Warn("Warning"), warning)
+	}
+	if len(plan.Warnings) > 0 {
+		fmt.Println()
+	}
+	if plan.Empty() {
+		fmt.Println("No changes. Your organization matches the manifest.")
+		return
+	}
+	fmt.Printf("%d changes:\n\n", len(plan.Changes))
+	for _, change := range plan.Changes {
+		fmt.Printf("  %s %s\n", manifestChangeSymbol(change.Kind), change.Description)
+	}
+	fmt.Println()
+}
+
+func manifestChangeSymbol(kind manifest.Kind) string {
+	switch kind {
+	case manifest.CreateGroup, manifest.CreateDatabase, manifest.AddLocation:
+		return internal.Emph("+")
+	case manifest.RemoveLocation:
+		return internal.Warn("-")
+	default:
+		return internal.Emph("~")
+	}
+}
+
+func applyManifestChange(ctx context.Context, client *turso.Client, change manifest.Change) error {
+	spinner := prompt.Spinner(fmt.Sprintf("Applying: %s...", change.Description))
+	defer spinner.Stop()
+
+	switch change.Kind {
+	case manifest.CreateGroup:
+		if err := client.Groups.Create(ctx, change.Name, change.Location, "latest"); err != nil {
+			return err
+		}
+		if err := client.Groups.WaitLocation(ctx, change.Name, change.Location); err != nil {
+			return err
+		}
+	case manifest.AddLocation:
+		if err := client.Groups.AddLocation(ctx, change.Name, change.Location); err != nil {
+			return err
+		}
+		if err := client.Groups.WaitLocation(ctx, change.Name, change.Location); err != nil {
+			return err
+		}
+	case manifest.RemoveLocation:
+		if err := client.Groups.RemoveLocation(ctx, change.Name, change.Location); err != nil {
+			return err
+		}
+	case manifest.ConfigureGroup:
+		if err := client.Groups.UpdateConfig(ctx, change.Name, change.GroupConfig); err != nil {
+			return err
+		}
+	case manifest.CreateDatabase:
+		if err := createManifestDatabase(ctx, client, change, spinner); err != nil {
+			return err
+		}
+	case manifest.ConfigureDatabase:
+		if err := client.Databases.UpdateConfig(ctx, change.Name, change.DatabaseConfig); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown change %s", change.Kind)
+	}
+
+	spinner.Stop()
+	fmt.Printf("%s %s\n", manifestChangeSymbol(change.Kind), change.Description)
+	return nil
+}
+
+func createManifestDatabase(ctx context.Context, client *turso.Client, change manifest.Change, spinner *prompt.SpinnerT) error {
+	spec := change.Database
+	var seed *turso.DBSeed
+	if spec.Seed != nil {
+		if spec.Seed.Database != "" {
+			seed = &turso.DBSeed{Type: "database", Name: spec.Seed.Database, Timestamp: spec.Seed.Timestamp}
+		} else {
+			seed = &turso.DBSeed{Type: "dump", URL: spec.Seed.DumpURL}
+		}
+	}
+	var cipher, key string
+	if spec.Encryption != nil {
+		cipher = spec.Encryption.Cipher
+		key = os.Getenv(spec.Encryption.KeyEnv)
+	}
+	_, err := client.Databases.Create(ctx, change.Name, change.Location, "", "", spec.Group, "", false, seed, spec.SizeLimit, cipher, key, false, spinner)
+	return err
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -60,17 +61,17 @@ func init() {
 	flags.AddFineGrainedPermissions(jwksTemplate)
 }
 
-func switchToOrg(client *turso.Client, slug string, showHowToGoBack bool) error {
+func switchToOrg(ctx context.Context, client *turso.Client, slug string, showHowToGoBack bool) error {
 	settings, err := settings.ReadSettings()
 	if err != nil {
 		return err
 	}
-	orgs, err := client.Organizations.List()
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return err
 	}
 
-	current := settings.Organization()
+	current := settings.SelectedOrganization()
 	if current == "" {
 		for _, o := range orgs {
 			if o.Type == "personal" {
@@ -103,6 +104,9 @@ func switchToOrg(client *turso.Client, slug string, showHowToGoBack bool) error
 	if showHowToGoBack {
 		fmt.Printf("To switch back to your previous organization:\n\n\t%s\n", internal.Emph(prev))
 	}
+	if project := projectContext(); project.Organization != "" && project.Organization != slug {
+		fmt.Printf("\nCommands run in this project still use organization %s, pinned by %s.\n", internal.Emph(project.Organization), project.Path)
+	}
 	invalidateDatabasesCache()
 	return nil
 }
@@ -123,9 +127,10 @@ var auditLogsListCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -152,7 +157,7 @@ var auditLogsListCmd = &cobra.Command{
 			org = settingsObj.GetUsername()
 		}
 
-		auditLogs, err := client.Organizations.AuditLogs(org, page, limit)
+		auditLogs, err := client.Organizations.AuditLogs(ctx, org, page, limit)
 		if err != nil {
 			return err
 		}
@@ -212,6 +217,7 @@ var orgListCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		settings, err := settings.ReadSettings()
@@ -219,17 +225,21 @@ var orgListCmd = &cobra.Command{
 			return err
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		orgs, err := client.Organizations.List()
+		orgs, err := client.Organizations.List(ctx)
 		if err != nil {
 			return err
 		}
 
 		current := settings.Organization()
+		if flags.StructuredOutput() {
+			return printStructured(toOrganizationListOutput(orgs, current))
+		}
+
 		currentFound := false
 		personal := ""
 
@@ -254,7 +264,7 @@ var orgListCmd = &cobra.Command{
 
 		if !currentFound && personal != "" {
 			fmt.Println("You don't have a default organization. Switching to your personal one...")
-			return switchToOrg(client, personal, false)
+			return switchToOrg(ctx, client, personal, false)
 		}
 
 		return nil
@@ -267,26 +277,27 @@ var orgCreateCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		name := args[0]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		_, err = client.Organizations.Create(name, "", true)
+		_, err = client.Organizations.Create(ctx, name, "", true)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Organizations are only supported in paid plans.\n\n")
 
-		stripeCustomerId, err := client.Billing.CreateStripeCustomer(name)
+		stripeCustomerId, err := client.Billing.CreateStripeCustomer(ctx, name)
 		if err != nil {
 			return fmt.Errorf("failed to create customer: %w", err)
 		}
-		ok, err := PaymentMethodHelperWithStripeId(client, stripeCustomerId, name)
+		ok, err := PaymentMethodHelperWithStripeId(ctx, client, stripeCustomerId, name)
 		if err != nil {
 			return fmt.Errorf("failed to add payment method: %w", err)
 		}
@@ -305,21 +316,21 @@ var orgCreateCmd = &cobra.Command{
 			fmt.Println("organization creation aborted")
 			return nil
 		}
-		org, err := client.Organizations.Create(name, stripeCustomerId, false)
+		org, err := client.Organizations.Create(ctx, name, stripeCustomerId, false)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("\nCreated organization %s.\n", internal.Emph(org.Name))
-		switchToOrg(client, org.Name, true)
+		switchToOrg(ctx, client, org.Name, true)
 		fmt.Println()
-		client, err = authedTursoClient()
+		client, err = authedTursoClient(ctx)
 		if err != nil {
-			client.Organizations.Delete(org.Slug)
+			client.Organizations.Delete(ctx, org.Slug)
 			return err
 		}
-		if err = client.Subscriptions.Update("scaler", "", nil); err != nil {
-			client.Organizations.Delete(org.Slug)
+		if err = client.Subscriptions.Update(ctx, "scaler", "", nil); err != nil {
+			client.Organizations.Delete(ctx, org.Slug)
 			return err
 		}
 
@@ -333,10 +344,11 @@ var orgDestroyCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg, // TODO: add orgs autocomplete
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		slug := args[0]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -350,7 +362,7 @@ var orgDestroyCmd = &cobra.Command{
 			return errors.New("cannot destroy current organization, please switch to another one first")
 		}
 
-		if err = client.Organizations.Delete(slug); err != nil {
+		if err = client.Organizations.Delete(ctx, slug); err != nil {
 			return err
 		}
 		invalidateDatabasesCache()
@@ -365,15 +377,16 @@ var orgSwitchCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: orgSwitchArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 		slug := args[0]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		return switchToOrg(client, slug, true)
+		return switchToOrg(ctx, client, slug, true)
 	},
 }
 
@@ -430,14 +443,15 @@ var membersListCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		members, err := client.Organizations.ListMembers()
+		members, err := client.Organizations.ListMembers(ctx)
 		if err != nil {
 			return err
 		}
@@ -458,6 +472,7 @@ var membersAddCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		settings, err := settings.ReadSettings()
@@ -470,7 +485,7 @@ var membersAddCmd = &cobra.Command{
 			return errors.New("username cannot be empty")
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -481,7 +496,7 @@ var membersAddCmd = &cobra.Command{
 			role = "admin"
 		}
 
-		if err := client.Organizations.AddMember(username, role); err != nil {
+		if err := client.Organizations.AddMember(ctx, username, role); err != nil {
 			return err
 		}
 
@@ -498,6 +513,7 @@ var membersInviteCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		email := args[0]
@@ -505,7 +521,7 @@ var membersInviteCmd = &cobra.Command{
 			return errors.New("email cannot be empty")
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -516,7 +532,7 @@ var membersInviteCmd = &cobra.Command{
 			role = "admin"
 		}
 
-		if err := client.Organizations.InviteMember(email, role); err != nil {
+		if err := client.Organizations.InviteMember(ctx, email, role); err != nil {
 			return err
 		}
 
@@ -531,6 +547,7 @@ var inviteRemoveCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		email := args[0]
@@ -538,12 +555,12 @@ var inviteRemoveCmd = &cobra.Command{
 			return errors.New("email cannot be empty")
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		if err := client.Organizations.DeleteInvite(email); err != nil {
+		if err := client.Organizations.DeleteInvite(ctx, email); err != nil {
 			return err
 		}
 
@@ -558,14 +575,15 @@ var inviteListCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(0),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		invites, err := client.Organizations.ListInvites()
+		invites, err := client.Organizations.ListInvites(ctx)
 		if err != nil {
 			return err
 		}
@@ -591,6 +609,7 @@ var membersRemoveCmd = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		settings, err := settings.ReadSettings()
@@ -603,12 +622,12 @@ var membersRemoveCmd = &cobra.Command{
 			return errors.New("username cannot be empty")
 		}
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		if err := client.Organizations.RemoveMember(username); err != nil {
+		if err := client.Organizations.RemoveMember(ctx, username); err != nil {
 			return err
 		}
 
@@ -642,8 +661,8 @@ func BillingPortal() error {
 	return billingPortal(org)
 }
 
-func currentOrg(client *turso.Client) (turso.Organization, error) {
-	orgs, err := client.Organizations.List()
+func currentOrg(ctx context.Context, client *turso.Client) (turso.Organization, error) {
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return turso.Organization{}, err
 	}
@@ -673,19 +692,20 @@ var jwksList = &cobra.Command{
 	Short:             "List saved external JWKS sources",
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		org, err := currentOrg(client)
+		org, err := currentOrg(ctx, client)
 		if err != nil {
 			return err
 		}
 
-		jwksList, err := client.Organizations.ListJwks(org.Slug)
+		jwksList, err := client.Organizations.ListJwks(ctx, org.Slug)
 		if err != nil {
 			return err
 		}
@@ -703,14 +723,15 @@ var jwksTemplate = &cobra.Command{
 	Short:             "Generate JWT claims template for external auth provider",
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		org, err := currentOrg(client)
+		org, err := currentOrg(ctx, client)
 		if err != nil {
 			return err
 		}
@@ -734,7 +755,7 @@ var jwksTemplate = &cobra.Command{
 			Scope:       jwksScope,
 			Permissions: permissions,
 		}
-		template, err := client.Organizations.JwksTemplate(org.Slug, params)
+		template, err := client.Organizations.JwksTemplate(ctx, org.Slug, params)
 		if err != nil {
 			return err
 		}
@@ -749,21 +770,22 @@ var jwksSave = &cobra.Command{
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		name, url := args[0], args[1]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		org, err := currentOrg(client)
+		org, err := currentOrg(ctx, client)
 		if err != nil {
 			return err
 		}
 
-		err = client.Organizations.SaveJwks(org.Slug, name, url, jwksRegion)
+		err = client.Organizations.SaveJwks(ctx, org.Slug, name, url, jwksRegion)
 		if err != nil {
 			return err
 		}
@@ -778,21 +800,22 @@ var jwksRemove = &cobra.Command{
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
 
 		name := args[0]
 
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
 
-		org, err := currentOrg(client)
+		org, err := currentOrg(ctx, client)
 		if err != nil {
 			return err
 		}
 
-		err = client.Organizations.RemoveJwks(org.Slug, name, jwksRegion)
+		err = client.Organizations.RemoveJwks(ctx, org.Slug, name, jwksRegion)
 		if err != nil {
 			return err
 		}
@@ -801,12 +824,12 @@ var jwksRemove = &cobra.Command{
 	},
 }
 
-func listOrganizations(client *turso.Client, fresh ...bool) ([]turso.Organization, error) {
+func listOrganizations(ctx context.Context, client *turso.Client, fresh ...bool) ([]turso.Organization, error) {
 	skipCache := len(fresh) > 0 && fresh[0]
 	if cache := getOrgsCache(); !skipCache && cache != nil {
 		return cache, nil
 	}
-	orgs, err := client.Organizations.List()
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/manifest"
+	"github.com/tursodatabase/turso-cli/internal/settings"
+	"github.com/tursodatabase/turso-cli/internal/snapshot"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+	"gopkg.in/yaml.v3"
+)
+
+// The types below are the documents rendered by read commands when --output
+// is json or yaml. They are part of the CLI public interface and documented
+// in docs/output.md: fields may be added, but never renamed or removed.
+
+type databaseOutput struct {
+	Name             string   `json:"name"`
+	ID               string   `json:"id"`
+	Type             string   `json:"type"`
+	Group            string   `json:"group"`
+	Hostname         string   `json:"hostname"`
+	URL              string   `json:"url"`
+	PrimaryLocation  string   `json:"primary_location"`
+	Locations        []string `json:"locations"`
+	Version          string   `json:"version"`
+	Archived         bool     `json:"archived"`
+	IsSchema         bool     `json:"is_schema"`
+	Schema           string   `json:"schema"`
+	Parent           *string  `json:"parent"`
+	EncryptionCipher string   `json:"encryption_cipher"`
+}
+
+type databaseListOutput struct {
+	Databases []databaseOutput `json:"databases"`
+}
+
+type databaseConfigOutput struct {
+	DeleteProtection bool     `json:"delete_protection"`
+	AllowAttach      bool     `json:"allow_attach"`
+	AllowedIPs       []string `json:"allowed_ips"`
+	AllowedVpcIDs    []string `json:"allowed_vpc_ids"`
+}
+
+type usageOutput struct {
+	StorageBytes uint64 `json:"storage_bytes"`
+	RowsRead     uint64 `json:"rows_read"`
+	RowsWritten  uint64 `json:"rows_written"`
+	BytesSynced  uint64 `json:"bytes_synced"`
+}
+
+type instanceOutput struct {
+	Name     string       `json:"name"`
+	Type     string       `json:"type"`
+	Location string       `json:"location"`
+	URL      string       `json:"url"`
+	Usage    *usageOutput `json:"usage,omitempty"`
+}
+
+type databaseShowOutput struct {
+	databaseOutput
+	Config    databaseConfigOutput `json:"config"`
+	Usage     usageOutput          `json:"usage"`
+	Instances []instanceOutput     `json:"instances"`
+}
+
+type databaseInspectOutput struct {
+	Database  string           `json:"database"`
+	Usage     usageOutput      `json:"usage"`
+	Instances []instanceOutput `json:"instances"`
+}
+
+type queryStatsOutput struct {
+	Query       string `json:"query"`
+	RowsRead    int    `json:"rows_read"`
+	RowsWritten int    `json:"rows_written"`
+}
+
+type queryStatsListOutput struct {
+	Queries []queryStatsOutput `json:"queries"`
+}
+
+type locationStatusOutput struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type groupOutput struct {
+	Name             string                 `json:"name"`
+	ID               string                 `json:"id"`
+	PrimaryLocation  string                 `json:"primary_location"`
+	Locations        []string               `json:"locations"`
+	Version          string                 `json:"version"`
+	Archived         bool                   `json:"archived"`
+	Status           string                 `json:"status"`
+	LocationStatus   []locationStatusOutput `json:"location_status"`
+	DeleteProtection *bool                  `json:"delete_protection,omitempty"`
+}
+
+type groupListOutput struct {
+	Groups []groupOutput `json:"groups"`
+}
+
+type organizationOutput struct {
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Overages bool   `json:"overages"`
+	Current  bool   `json:"current"`
+}
+
+type organizationListOutput struct {
+	Organizations []organizationOutput `json:"organizations"`
+}
+
+type profileOutput struct {
+	Name         string `json:"name"`
+	Username     string `json:"username"`
+	Organization string `json:"organization"`
+	BaseURL      string `json:"base_url"`
+	Current      bool   `json:"current"`
+}
+
+type profileListOutput struct {
+	Profiles []profileOutput `json:"profiles"`
+}
+
+type manifestChangeOutput struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Location    string `json:"location,omitempty"`
+	Description string `json:"description"`
+}
+
+type manifestPlanOutput struct {
+	Changes  []manifestChangeOutput `json:"changes"`
+	Warnings []string               `json:"warnings"`
+}
+
+type snapshotDriftOutput struct {
+	Kind        string `json:"kind"`
+	Resource    string `json:"resource"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type snapshotDiffOutput struct {
+	Drift []snapshotDriftOutput `json:"drift"`
+}
+
+type planResourceOutput struct {
+	Name  string `json:"name"`
+	Used  uint64 `json:"used"`
+	Limit uint64 `json:"limit"`
+}
+
+type planOutput struct {
+	Organization string               `json:"organization"`
+	Plan         string               `json:"plan"`
+	Timeline     string               `json:"timeline"`
+	Overages     bool                 `json:"overages"`
+	QuotaReset   time.Time            `json:"quota_reset"`
+	Resources    []planResourceOutput `json:"resources"`
+}
+
+type invoiceOutput struct {
+	Number           string `json:"number"`
+	AmountDue        string `json:"amount_due"`
+	Status           string `json:"status"`
+	DueDate          string `json:"due_date"`
+	PaidAt           string `json:"paid_at"`
+	PaymentFailedAt  string `json:"payment_failed_at"`
+	InvoicePdf       string `json:"invoice_pdf"`
+	HostedInvoiceUrl string `json:"hosted_invoice_url"`
+}
+
+type invoiceListOutput struct {
+	Invoices []invoiceOutput `json:"invoices"`
+}
+
+func printStructured(v any) error {
+	output, err := flags.Output()
+	if err != nil {
+		return err
+	}
+	return writeStructured(os.Stdout, output, v)
+}
+
+func writeStructured(w io.Writer, output string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode output: %w", err)
+	}
+
+	switch output {
+	case flags.OutputYAML:
+		// Going through JSON keeps a single source of truth for field names
+		// and ordering: the json tags above define both formats.
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("could not encode output: %w", err)
+		}
+		resetYAMLStyle(&node)
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return fmt.Errorf("could not encode output: %w", err)
+		}
+		return encoder.Close()
+	default:
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+}
+
+// resetYAMLStyle drops the flow and quoting styles inherited from the JSON
+// source so the document is rendered in block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func toDatabaseOutput(db turso.Database) databaseOutput {
+	var parent *string
+	if db.Parent != nil {
+		parent = &db.Parent.Name
+	}
+	return databaseOutput{
+		Name:             db.Name,
+		ID:               db.ID,
+		Type:             databaseType(db.ID),
+		Group:            db.Group,
+		Hostname:         db.Hostname,
+		URL:              getDatabaseUrl(&db),
+		PrimaryLocation:  db.PrimaryRegion,
+		Locations:        nonNil(db.Regions),
+		Version:          db.Version,
+		Archived:         db.Sleeping,
+		IsSchema:         db.IsSchema,
+		Schema:           db.Schema,
+		Parent:           parent,
+		EncryptionCipher: db.EncryptionCipher,
+	}
+}
+
+func toDatabaseListOutput(databases []turso.Database) databaseListOutput {
+	out := databaseListOutput{Databases: make([]databaseOutput, 0, len(databases))}
+	for _, db := range databases {
+		out.Databases = append(out.Databases, toDatabaseOutput(db))
+	}
+	return out
+}
+
+func toDatabaseConfigOutput(config turso.DatabaseConfig) databaseConfigOutput {
+	return databaseConfigOutput{
+		DeleteProtection: config.IsDeleteProtected(),
+		AllowAttach:      config.AttachAllowed(),
+		AllowedIPs:       nonNil(config.AllowedIPList()),
+		AllowedVpcIDs:    nonNil(config.AllowedVpcIDList()),
+	}
+}
+
+func toUsageOutput(usage turso.Usage) usageOutput {
+	return usageOutput{
+		StorageBytes: usage.StorageBytesUsed,
+		RowsRead:     usage.RowsRead,
+		RowsWritten:  usage.RowsWritten,
+		BytesSynced:  usage.BytesSynced,
+	}
+}
+
+func toInstancesOutput(db turso.Database, instances []turso.Instance, usage turso.DbUsage, withUsage bool) []instanceOutput {
+	instancesUsage := getInstanceUsageMap(usage.Instances)
+	out := make([]instanceOutput, 0, len(instances))
+	for _, instance := range instances {
+		item := instanceOutput{
+			Name:     instance.Name,
+			Type:     instance.Type,
+			Location: instance.Region,
+			URL:      getInstanceUrl(&db, &instance),
+		}
+		if usg, ok := instancesUsage[instance.Uuid]; ok && withUsage {
+			u := toUsageOutput(usg)
+			item.Usage = &u
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func toGroupOutput(group turso.Group) groupOutput {
+	status := make([]locationStatusOutput, 0, len(group.Status.Locations))
+	for _, location := range group.Status.Locations {
+		status = append(status, locationStatusOutput{Name: location.Name, Status: location.Status})
+	}
+	return groupOutput{
+		Name:            group.Name,
+		ID:              group.UUID,
+		PrimaryLocation: group.Primary,
+		Locations:       nonNil(group.Locations),
+		Version:         group.Version,
+		Archived:        group.Archived,
+		Status:          groupHealth(group),
+		LocationStatus:  status,
+	}
+}
+
+func toGroupListOutput(groups []turso.Group) groupListOutput {
+	out := groupListOutput{Groups: make([]groupOutput, 0, len(groups))}
+	for _, group := range groups {
+		out.Groups = append(out.Groups, toGroupOutput(group))
+	}
+	return out
+}
+
+func toOrganizationListOutput(orgs []turso.Organization, current string) organizationListOutput {
+	out := organizationListOutput{Organizations: make([]organizationOutput, 0, len(orgs))}
+	for _, org := range orgs {
+		out.Organizations = append(out.Organizations, organizationOutput{
+			Name:     org.Name,
+			Slug:     org.Slug,
+			ID:       org.ID,
+			Type:     org.Type,
+			Overages: org.Overages,
+			Current:  isCurrentOrg(org, current),
+		})
+	}
+	return out
+}
+
+func toProfileListOutput(profiles []settings.Profile, current string) profileListOutput {
+	out := profileListOutput{Profiles: make([]profileOutput, 0, len(profiles))}
+	for _, profile := range profiles {
+		baseURL := profile.BaseURL
+		if baseURL == "" {
+			baseURL = tursoDefaultBaseURL
+		}
+		out.Profiles = append(out.Profiles, profileOutput{
+			Name:         profile.Name,
+			Username:     profile.Username,
+			Organization: profile.Organization,
+			BaseURL:      baseURL,
+			Current:      profile.Name == current,
+		})
+	}
+	return out
+}
+
+func toInvoiceListOutput(invoices []turso.Invoice) invoiceListOutput {
+	out := invoiceListOutput{Invoices: make([]invoiceOutput, 0, len(invoices))}
+	for _, invoice := range invoices {
+		out.Invoices = append(out.Invoices, invoiceOutput{
+			Number:           invoice.Number,
+			AmountDue:        invoice.Amount,
+			Status:           invoice.Status,
+			DueDate:          invoice.DueDate,
+			PaidAt:           invoice.PaidAt,
+			PaymentFailedAt:  invoice.PaymentFailedAt,
+			InvoicePdf:       invoice.InvoicePdf,
+			HostedInvoiceUrl: invoice.HostedInvoiceUrl,
+		})
+	}
+	return out
+}
+
+func toManifestPlanOutput(plan *manifest.Plan) manifestPlanOutput {
+	out := manifestPlanOutput{
+		Changes:  make([]manifestChangeOutput, 0, len(plan.Changes)),
+		Warnings: append([]string{}, plan.Warnings...),
+	}
+	for _, change := range plan.Changes {
+		out.Changes = append(out.Changes, manifestChangeOutput{
+			Kind:        string(change.Kind),
+			Name:        change.Name,
+			Location:    change.Location,
+			Description: change.Description,
+		})
+	}
+	return out
+}
+
+func toSnapshotDiffOutput(drifts []snapshot.Drift) snapshotDiffOutput {
+	out := snapshotDiffOutput{Drift: make([]snapshotDriftOutput, 0, len(drifts))}
+	for _, drift := range drifts {
+		out.Drift = append(out.Drift, snapshotDriftOutput{
+			Kind:        string(drift.Kind),
+			Resource:    drift.Resource,
+			Name:        drift.Name,
+			Description: drift.Description,
+		})
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+func TestWriteStructuredJSON(t *testing.T) {
+	dbs := []turso.Database{{Name: "db1", ID: "id1", Hostname: "db1-org.turso.io", Group: "default"}}
+
+	var buf bytes.Buffer
+	if err := writeStructured(&buf, flags.OutputJSON, toDatabaseListOutput(dbs)); err != nil {
+		t.Fatalf("writeStructured: %v", err)
+	}
+
+	var got map[string][]map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	db := got["databases"][0]
+	if db["name"] != "db1" || db["url"] != "libsql://db1-org.turso.io" || db["parent"] != nil {
+		t.Errorf("unexpected database document: %v", db)
+	}
+	if locations, ok := db["locations"].([]any); !ok || len(locations) != 0 {
+		t.Errorf("locations should be an empty list, got %v", db["locations"])
+	}
+}
+
+func TestWriteStructuredYAML(t *testing.T) {
+	out := toOrganizationListOutput([]turso.Organization{
+		{Name: "personal", Slug: "alice", Type: "personal"},
+		{Name: "Team", Slug: "123", Type: "team"},
+	}, "")
+
+	var buf bytes.Buffer
+	if err := writeStructured(&buf, flags.OutputYAML, out); err != nil {
+		t.Fatalf("writeStructured: %v", err)
+	}
+
+	want := `organizations:
+  - name: personal
+    slug: alice
+    id: ""
+    type: personal
+    overages: false
+    current: true
+  - name: Team
+    slug: "123"
+    id: ""
+    type: team
+    overages: false
+    current: false
+`
+	if buf.String() != want {
+		t.Errorf("unexpected YAML output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
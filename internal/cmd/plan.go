@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -31,8 +32,10 @@ func init() {
 }
 
 var planCmd = &cobra.Command{
-	Use:   "plan",
-	Short: "Manage your organization plan",
+	Use:     "plan [-f <manifest>]",
+	Short:   "Manage your organization plan, or show the changes of a manifest",
+	Long:    "Manage your organization plan with the subcommands below.\n\nWith " + internal.Emph("-f <manifest>") + ", show the changes that " + internal.Emph("turso apply -f <manifest>") + " would make\nto the groups and databases of the organization, without applying them.",
+	Example: "  turso plan show\n  turso plan -f turso.yaml",
 }
 
 var overagesCommand = &cobra.Command{
@@ -40,8 +43,8 @@ var overagesCommand = &cobra.Command{
 	Short: "Manage your current organization overages",
 }
 
-func getCurrentOrg(client *turso.Client, organizationName string) (turso.Organization, error) {
-	orgs, err := client.Organizations.List()
+func getCurrentOrg(ctx context.Context, client *turso.Client, organizationName string) (turso.Organization, error) {
+	orgs, err := client.Organizations.List(ctx)
 	if err != nil {
 		return turso.Organization{}, err
 	}
@@ -61,8 +64,9 @@ var planShowCmd = &cobra.Command{
 	Short: "Show your current organization plan",
 	Args:  cobra.ExactArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		client, err := authedTursoClient()
+		client, err := authedTursoClient(ctx)
 		if err != nil {
 			return err
 		}
@@ -72,7 +76,7 @@ var planShowCmd = &cobra.Command{
 			return fmt.Errorf("could not retrieve local config: %w", err)
 		}
 
-		subscription, orgUsage, plans, err := orgPlanData(client)
+		subscription, orgUsage, plans, err := orgPlanData(ctx, client)
 		if err != nil {
 			return err
 		}
@@ -84,21 +88,33 @@ var planShowCmd = &cobra.Command{
 			organizationName = settings.GetUsername()
 		}
 
-		currentOrg, err := getCurrentOrg(client, organizationName)
+		currentOrg, err := getCurrentOrg(ctx, client, organizationName)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Organization: %s\n", internal.Emph(currentOrg.Name))
 		if currentOrg.Overages {
 			plan, _ = strings.CutSuffix(plan, "_overages")
 		}
 
+		current := getPlan(plan, plans)
+		if flags.StructuredOutput() {
+			return printStructured(planOutput{
+				Organization: currentOrg.Slug,
+				Plan:         plan,
+				Timeline:     subscription.Timeline,
+				Overages:     currentOrg.Overages,
+				QuotaReset:   getFirstDayOfNextMonth(),
+				Resources:    planResources(orgUsage, current),
+			})
+		}
+
+		fmt.Printf("Organization: %s\n", internal.Emph(currentOrg.Name))
+
 		fmt.Printf("Plan: %s\n", internal.Emph(plan))
 		fmt.Print(overagesMessage(currentOrg.Overages))
 		fmt.Println()
 
-		current := getPlan(plan, plans)
 		tbl := planUsageTable(orgUsage, current, currentOrg)
 		tbl.Print()
 		fmt.Printf("\nQuota will reset on %s\n", getFirstDayOfNextMonth().Local().Format(time.RFC1123))
@@ -140,20 +156,32 @@ func planUsageTable(orgUsage turso.OrgUsage, current turso.Plan, currentOrg turs
 	return tbl
 }
 
-func orgPlanData(client *turso.Client) (sub turso.Subscription, usage turso.OrgUsage, plans []turso.Plan, err error) {
+func planResources(orgUsage turso.OrgUsage, current turso.Plan) []planResourceOutput {
+	return []planResourceOutput{
+		{Name: "storage", Used: orgUsage.Usage.StorageBytesUsed, Limit: current.Quotas.Storage},
+		{Name: "rows_read", Used: orgUsage.Usage.RowsRead, Limit: current.Quotas.RowsRead},
+		{Name: "rows_written", Used: orgUsage.Usage.RowsWritten, Limit: current.Quotas.RowsWritten},
+		{Name: "embedded_syncs", Used: orgUsage.Usage.BytesSynced, Limit: current.Quotas.BytesSynced},
+		{Name: "databases", Used: orgUsage.Usage.Databases, Limit: current.Quotas.Databases},
+		{Name: "locations", Used: orgUsage.Usage.Locations, Limit: current.Quotas.Locations},
+		{Name: "groups", Used: orgUsage.Usage.Groups, Limit: current.Quotas.Groups},
+	}
+}
+
+func orgPlanData(ctx context.Context, client *turso.Client) (sub turso.Subscription, usage turso.OrgUsage, plans []turso.Plan, err error) {
 	g := errgroup.Group{}
 	g.Go(func() (err error) {
-		sub, err = client.Subscriptions.Get()
+		sub, err = client.Subscriptions.Get(ctx)
 		return
 	})
 
 	g.Go(func() (err error) {
-		usage, err = client.Organizations.Usage()
+		usage, err = client.Organizations.Usage(ctx)
 		return
 	})
 
 	g.Go(func() (err error) {
-		plans, err = client.Plans.List()
+		plans, err = client.Plans.List(ctx)
 		return
 	})
 	err = g.Wait()
@@ -172,16 +200,17 @@ var planSelectCmd = &cobra.Command{
 }
 
 func planNameArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
 	if len(args) != 0 {
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	client, err := authedTursoClient()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	plans, err := getPlans(client)
+	plans, err := getPlans(ctx, client)
 	if err != nil {
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -223,19 +252,19 @@ var planDisableOverages = &cobra.Command{
 	},
 }
 
-func hasPaymentMethodCheck(client *turso.Client, stripeId string) (bool, error) {
+func hasPaymentMethodCheck(ctx context.Context, client *turso.Client, stripeId string) (bool, error) {
 	if stripeId != "" {
-		return client.Billing.HasPaymentMethodWithStripeId(stripeId)
+		return client.Billing.HasPaymentMethodWithStripeId(ctx, stripeId)
 	}
-	return client.Billing.HasPaymentMethod()
+	return client.Billing.HasPaymentMethod(ctx)
 }
 
-func checkPaymentMethod(client *turso.Client, stripeId string) (bool, error) {
+func checkPaymentMethod(ctx context.Context, client *turso.Client, stripeId string) (bool, error) {
 	errsInARoW := 0
 	var hasPaymentMethod bool
 	var err error
 	for {
-		hasPaymentMethod, err = hasPaymentMethodCheck(client, stripeId)
+		hasPaymentMethod, err = hasPaymentMethodCheck(ctx, client, stripeId)
 		if err != nil {
 			errsInARoW += 1
 		}
@@ -252,7 +281,7 @@ func checkPaymentMethod(client *turso.Client, stripeId string) (bool, error) {
 	}
 }
 
-func PaymentMethodHelperWithStripeId(client *turso.Client, stripeId, orgName string) (bool, error) {
+func PaymentMethodHelperWithStripeId(ctx context.Context, client *turso.Client, stripeId, orgName string) (bool, error) {
 	fmt.Printf("You need to add a payment method before you can create organization %s on the %s plan.\n", internal.Emph(orgName), internal.Emph("scaler"))
 	printPricingInfoDisclaimer()
 
@@ -263,7 +292,7 @@ func PaymentMethodHelperWithStripeId(client *turso.Client, stripeId, orgName str
 	}
 
 	fmt.Println()
-	if err := BillingPortalForStripeId(client, stripeId); err != nil {
+	if err := BillingPortalForStripeId(ctx, client, stripeId); err != nil {
 		return false, err
 	}
 	fmt.Println()
@@ -271,32 +300,32 @@ func PaymentMethodHelperWithStripeId(client *turso.Client, stripeId, orgName str
 	spinner := prompt.Spinner("Waiting for you to add a payment method")
 	defer spinner.Stop()
 
-	return checkPaymentMethod(client, stripeId)
+	return checkPaymentMethod(ctx, client, stripeId)
 }
 
-func GetSelectPlanInfo(client *turso.Client) (plans []turso.Plan, current turso.Subscription, hasPaymentMethod bool, err error) {
+func GetSelectPlanInfo(ctx context.Context, client *turso.Client) (plans []turso.Plan, current turso.Subscription, hasPaymentMethod bool, err error) {
 	g := errgroup.Group{}
 	g.Go(func() (err error) {
-		plans, err = getPlans(client)
+		plans, err = getPlans(ctx, client)
 		return
 	})
 	g.Go(func() (err error) {
-		current, err = client.Subscriptions.Get()
+		current, err = client.Subscriptions.Get(ctx)
 		return
 	})
 	g.Go(func() (err error) {
-		hasPaymentMethod, err = client.Billing.HasPaymentMethod()
+		hasPaymentMethod, err = client.Billing.HasPaymentMethod(ctx)
 		return
 	})
 	err = g.Wait()
 	return
 }
 
-func getPlans(client *turso.Client) ([]turso.Plan, error) {
+func getPlans(ctx context.Context, client *turso.Client) ([]turso.Plan, error) {
 	if cached := getPlansCache(); cached != nil {
 		return cached, nil
 	}
-	plans, err := client.Plans.List()
+	plans, err := client.Plans.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -324,8 +353,8 @@ func billingPortal(currentOrg string) error {
 	return nil
 }
 
-func BillingPortalForStripeId(client *turso.Client, stripeCustomerId string) error {
-	portal, err := client.Billing.PortalForStripeId(stripeCustomerId)
+func BillingPortalForStripeId(ctx context.Context, client *turso.Client, stripeCustomerId string) error {
+	portal, err := client.Billing.PortalForStripeId(ctx, stripeCustomerId)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/settings"
+)
+
+var profileBaseURLFlag string
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	profileCreateCmd.Flags().StringVar(&profileBaseURLFlag, "base-url", "", "Platform API URL used by the profile, e.g. for a staging environment")
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles, each with its own account, organization and API URL",
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:               "create <name>",
+	Short:             "Create a profile",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		if err := config.CreateProfile(name, profileBaseURLFlag); err != nil {
+			return err
+		}
+		fmt.Printf("Created profile %s.\n\n", internal.Emph(name))
+		fmt.Printf("Switch to it and log in with:\n\n")
+		fmt.Printf("   %s\n", internal.Emph("turso profile use "+name))
+		fmt.Printf("   %s\n", internal.Emph("turso auth login"))
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:               "use <name>",
+	Short:             "Use a profile for the next commands",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: profileArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		if err := config.SetProfile(name); err != nil {
+			return err
+		}
+		fmt.Printf("Now using profile %s.\n", internal.Emph(name))
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:               "list",
+	Short:             "List profiles",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+
+		profiles := config.Profiles()
+		current := config.Profile()
+		if flags.StructuredOutput() {
+			return printStructured(toProfileListOutput(profiles, current))
+		}
+
+		data := make([][]string, 0, len(profiles))
+		for _, profile := range profiles {
+			name := profile.Name
+			if name == current {
+				name = fmt.Sprintf("%s (current)", internal.Emph(name))
+			}
+			baseURL := profile.BaseURL
+			if baseURL == "" {
+				baseURL = tursoDefaultBaseURL
+			}
+			data = append(data, []string{name, profile.Username, profile.Organization, baseURL})
+		}
+		printTable([]string{"name", "username", "organization", "api url"}, data)
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Short:             "Delete a profile with its credentials and caches",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: profileArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+		if err := config.DeleteProfile(name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile %s.\n", internal.Emph(name))
+		return nil
+	},
+}
+
+func profileArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := []string{}
+	for _, profile := range config.Profiles() {
+		names = append(names, profile.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// checkActiveProfile fails when the profile selected with --profile,
+// TURSO_PROFILE or the settings doesn't exist, instead of silently acting as
+// a logged out profile.
+func checkActiveProfile(cmd *cobra.Command) error {
+	if strings.HasPrefix(cmd.CommandPath(), "turso profile") {
+		return nil
+	}
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return err
+	}
+	if profile := config.Profile(); !config.ProfileExists(profile) {
+		return fmt.Errorf("profile %s does not exist. Create it with %s", internal.Emph(profile), internal.Emph("turso profile create "+profile))
+	}
+	return nil
+}
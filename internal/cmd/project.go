@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal/settings"
+)
+
+// projectContext returns the context pinned by the project file found from
+// the working directory, or an empty one. Errors reading the file are
+// reported by checkProjectContext before any command runs.
+func projectContext() settings.Project {
+	project, err := settings.CurrentProject()
+	if err != nil || project == nil {
+		return settings.Project{}
+	}
+	return *project
+}
+
+func checkProjectContext() error {
+	_, err := settings.CurrentProject()
+	return err
+}
+
+// allowProjectDatabase lets cmd be run without its leading <database-name>
+// argument when the project file pins a database. The database is only
+// filled in when the given arguments are not valid on their own.
+func allowProjectDatabase(cmd *cobra.Command) {
+	validate := cmd.Args
+	run := cmd.RunE
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		return validate(cmd, withProjectDatabase(cmd, validate, args))
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return run(cmd, withProjectDatabase(cmd, validate, args))
+	}
+}
+
+func withProjectDatabase(cmd *cobra.Command, validate cobra.PositionalArgs, args []string) []string {
+	database := projectContext().Database
+	if database == "" || validate(cmd, args) == nil {
+		return args
+	}
+	return append([]string{database}, args...)
+}
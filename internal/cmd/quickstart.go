@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/browser"
@@ -30,8 +31,9 @@ var postInstallCmd = &cobra.Command{
 	Hidden: true,
 	Args:   cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		if checkSignedIn() {
+		if checkSignedIn(ctx) {
 			return nil
 		}
 		quickstart(true)
@@ -52,11 +54,15 @@ func quickstart(headless bool) {
 	fmt.Println("Opening Turso Quickstart Guide in your browser...")
 }
 
-func checkSignedIn() bool {
+func checkSignedIn(ctx context.Context) bool {
 	settings, err := settings.ReadSettings()
 	if err != nil {
 		return false
 	}
 
-	return isJwtTokenValid(settings.GetToken())
+	token, err := settings.GetToken()
+	if err != nil {
+		return false
+	}
+	return isJwtTokenValid(ctx, token)
 }
@@ -1,17 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/fatih/color"
 	semver "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tursodatabase/turso-cli/internal"
 	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
 	"github.com/tursodatabase/turso-cli/internal/settings"
 )
 
@@ -21,10 +26,29 @@ var rootCmd = &cobra.Command{
 	Long:    "Turso CLI",
 }
 
+var errInterrupted = errors.New("interrupted")
+
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	interrupt := func() { cancel(errInterrupted) }
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		// Restore the default behavior so a second Ctrl-C kills the
+		// process right away, even if cleanup hangs.
+		signal.Stop(signals)
+		interrupt()
+	}()
+	prompt.OnInterrupt(interrupt)
+
+	err := rootCmd.ExecuteContext(ctx)
+	if errors.Is(context.Cause(ctx), errInterrupted) {
+		os.Exit(exitCodeInterrupted)
+	}
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -41,7 +65,9 @@ func requiresLogin(cmd *cobra.Command) bool {
 		"turso __complete",
 		"turso __completeNoDesc",
 		"turso db shell",
+		"turso db verify-export",
 		"turso dev",
+		"turso profile",
 	}
 	for _, allowed := range allowlist {
 		if strings.HasPrefix(path, allowed) {
@@ -60,6 +86,7 @@ func init() {
 	_ = rootCmd.PersistentFlags().MarkHidden("no-multiple-token-sources-warning")
 
 	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
 		configSettings, err := settings.ReadSettings()
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "Error reading settings:", err)
@@ -75,7 +102,7 @@ func init() {
 			return
 		}
 		if configSettings.GetAutoupdate() == "on" && time.Now().Unix() >= configSettings.GetLastUpdateCheck()+int64(24*60*60) {
-			latest, err := fetchLatestVersion()
+			latest, err := fetchLatestVersion(ctx)
 			if err != nil {
 				_, _ = fmt.Fprintln(os.Stderr, "Error fetching latest version:", err)
 				return
@@ -104,20 +131,42 @@ func init() {
 			settings.PersistChanges()
 		}
 	}
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if _, err := flags.Output(); err != nil {
+			return err
+		}
+		if err := checkActiveProfile(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if err := checkProjectContext(); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
 		if !requiresLogin(cmd) {
-			return
+			return nil
 		}
-		VerifyUserIsLoggedIn()
+		VerifyUserIsLoggedIn(cmd.Context())
+		return nil
 	}
+	cobra.OnInitialize(func() {
+		if flags.StructuredOutput() {
+			color.NoColor = true
+		}
+	})
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 	flags.AddDebugFlag(rootCmd)
+	flags.AddMaxRetries(rootCmd)
+	flags.AddTimeout(rootCmd)
+	flags.AddTrace(rootCmd)
+	flags.AddProfile(rootCmd)
+	flags.AddOutput(rootCmd)
 	flags.AddV3ApiFlag(rootCmd)
 	flags.AddResetConfigFlag(rootCmd)
 }
 
-func VerifyUserIsLoggedIn() {
-	_, err := getAccessToken()
+func VerifyUserIsLoggedIn(ctx context.Context) {
+	_, err := getAccessToken(ctx)
 	if errors.Is(err, ErrNotLoggedIn) {
 		fmt.Printf("You are not logged in, please login with %s before running other commands.\n", internal.Emph("turso auth login"))
 		os.Exit(0)
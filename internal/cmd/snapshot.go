@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/prompt"
+	"github.com/tursodatabase/turso-cli/internal/snapshot"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// errDrift is returned by turso snapshot diff when the organization no longer
+// matches the snapshot.
+var errDrift = errors.New("organization drifted from the snapshot")
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record the state of your organization and detect drift from it",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <file>",
+	Short: "Save the groups, databases, members and API token names of your organization to a file",
+	Long: "Save the groups, databases and their configuration, the members and the API token names of your organization to a JSON file.\n" +
+		"Compare the organization with the file later with " + internal.Emph("turso snapshot diff <file>") + ".",
+	Example: "  turso snapshot save snapshot.json",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+
+		client, err := authedTursoClient(ctx)
+		if err != nil {
+			return err
+		}
+		spinner := prompt.Spinner("Reading the state of your organization...")
+		s, err := takeSnapshot(ctx, client)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+		if err := s.Save(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %d groups, %d databases, %d members and %d API tokens of organization %s to %s.\n",
+			len(s.Groups), len(s.Databases), len(s.Members), len(s.APITokens), internal.Emph(s.Organization), args[0])
+		return nil
+	},
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Compare your organization with a snapshot",
+	Long: "Compare the current state of your organization with a snapshot saved with " + internal.Emph("turso snapshot save") + ".\n" +
+		"Exits with status 2 when the organization drifted from the snapshot.",
+	Example: "  turso snapshot diff snapshot.json || notify-team",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cmd.SilenceUsage = true
+
+		saved, err := snapshot.Load(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := authedTursoClient(ctx)
+		if err != nil {
+			return err
+		}
+		spinner := prompt.Spinner("Comparing your organization with the snapshot...")
+		live, err := takeSnapshot(ctx, client)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+		if saved.Organization != live.Organization {
+			return fmt.Errorf("the snapshot is of organization %s, but the current organization is %s", saved.Organization, live.Organization)
+		}
+
+		drifts := snapshot.Diff(saved, live)
+		if flags.StructuredOutput() {
+			if err := printStructured(toSnapshotDiffOutput(drifts)); err != nil {
+				return err
+			}
+		} else {
+			printSnapshotDrift(drifts, saved.TakenAt)
+		}
+		if len(drifts) > 0 {
+			return fmt.Errorf("%w: %d differences", errDrift, len(drifts))
+		}
+		return nil
+	},
+}
+
+// takeSnapshot reads the state of the organization of client. Caches are not
+// used, so that the snapshot is accurate.
+func takeSnapshot(ctx context.Context, client *turso.Client) (*snapshot.Snapshot, error) {
+	org, err := getCurrentOrg(ctx, client, client.Org)
+	if err != nil {
+		return nil, err
+	}
+	s := &snapshot.Snapshot{
+		Version:      snapshot.Version,
+		Organization: org.Slug,
+		TakenAt:      time.Now().UTC().Truncate(time.Second),
+		Members:      []snapshot.Member{},
+		APITokens:    []string{},
+	}
+
+	if s.Groups, s.Databases, err = readGroupsAndDatabases(ctx, client); err != nil {
+		return nil, err
+	}
+
+	// Personal organizations have no members besides their owner.
+	if org.Type != "personal" {
+		members, err := client.Organizations.ListMembers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			s.Members = append(s.Members, snapshot.Member{Name: member.Name, Role: member.Role})
+		}
+	}
+
+	tokens, err := client.ApiTokens.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		s.APITokens = append(s.APITokens, token.Name)
+	}
+
+	s.Sort()
+	return s, nil
+}
+
+// readGroupsAndDatabases reads the groups and databases of the organization of
+// client, with their configuration, bypassing caches.
+func readGroupsAndDatabases(ctx context.Context, client *turso.Client) ([]snapshot.Group, []snapshot.Database, error) {
+	groups, err := getGroups(ctx, client, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	groupList := []snapshot.Group{}
+	for _, group := range groups {
+		config, err := client.Groups.GetConfig(ctx, group.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		groupList = append(groupList, snapshot.Group{
+			Name:      group.Name,
+			Primary:   group.Primary,
+			Locations: append([]string{}, group.Locations...),
+			Archived:  group.Archived,
+			Config:    config,
+		})
+	}
+
+	databases, err := getDatabases(ctx, client, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbs := []snapshot.Database{}
+	for _, db := range databases {
+		config, err := getDatabaseConfig(ctx, client, db.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		dbs = append(dbs, snapshot.Database{
+			Name:             db.Name,
+			ID:               db.ID,
+			Group:            db.Group,
+			IsSchema:         db.IsSchema,
+			Schema:           db.Schema,
+			EncryptionCipher: db.EncryptionCipher,
+			Config:           config,
+		})
+	}
+	return groupList, dbs, nil
+}
+
+func printSnapshotDrift(drifts []snapshot.Drift, takenAt time.Time) {
+	if len(drifts) == 0 {
+		fmt.Printf("No drift. Your organization matches the snapshot taken at %s.\n", takenAt.Format(time.RFC3339))
+		return
+	}
+	fmt.Printf("%d differences from the snapshot taken at %s:\n\n", len(drifts), takenAt.Format(time.RFC3339))
+	for _, drift := range drifts {
+		fmt.Printf("  %s %s\n", snapshotDriftSymbol(drift.Kind), drift.Description)
+	}
+	fmt.Println()
+}
+
+func snapshotDriftSymbol(kind snapshot.Kind) string {
+	switch kind {
+	case snapshot.Added:
+		return internal.Emph("+")
+	case snapshot.Removed:
+		return internal.Warn("-")
+	default:
+		return internal.Emph("~")
+	}
+}
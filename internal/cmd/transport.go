@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/tursodatabase/turso-cli/internal/flags"
+	"github.com/tursodatabase/turso-cli/internal/settings"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+const (
+	ENV_CA_BUNDLE   = "TURSO_CA_BUNDLE"
+	ENV_CLIENT_CERT = "TURSO_CLIENT_CERT"
+	ENV_CLIENT_KEY  = "TURSO_CLIENT_KEY"
+	ENV_PROXY       = "TURSO_PROXY"
+)
+
+// sharedHTTPClient is used for every request to the platform and to
+// databases, so they all share the same TLS settings and connection pool.
+var sharedHTTPClient = sync.OnceValues(func() (*http.Client, error) {
+	config, err := transportConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := turso.NewHTTPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not configure HTTP transport: %w", err)
+	}
+	return client, nil
+})
+
+// sharedTracer opens the file given with --trace-file once, so requests
+// from every client end up in the same trace.
+var sharedTracer = sync.OnceValues(func() (*turso.Tracer, error) {
+	path := flags.TraceFile()
+	if path == "" {
+		return nil, nil
+	}
+	if path == "-" {
+		return turso.NewTracer(os.Stderr, flags.TraceBodies()), nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open trace file: %w", err)
+	}
+	return turso.NewTracer(file, flags.TraceBodies()), nil
+})
+
+// transportConfig reads the transport settings from the configuration file.
+// Environment variables take precedence over the stored values.
+func transportConfig() (turso.TransportConfig, error) {
+	config, err := settings.ReadSettings()
+	if err != nil {
+		return turso.TransportConfig{}, fmt.Errorf("could not read settings file: %w", err)
+	}
+
+	transport := turso.TransportConfig{
+		CABundle:       config.GetCABundle(),
+		Proxy:          config.GetProxy(),
+		RequestTimeout: flags.Timeout(),
+	}
+	transport.ClientCert, transport.ClientKey = config.GetClientCertificate()
+	if transport.Tracer, err = sharedTracer(); err != nil {
+		return turso.TransportConfig{}, err
+	}
+
+	if value := os.Getenv(ENV_CA_BUNDLE); value != "" {
+		transport.CABundle = value
+	}
+	if value := os.Getenv(ENV_CLIENT_CERT); value != "" {
+		transport.ClientCert = value
+	}
+	if value := os.Getenv(ENV_CLIENT_KEY); value != "" {
+		transport.ClientKey = value
+	}
+	if value := os.Getenv(ENV_PROXY); value != "" {
+		transport.Proxy = value
+	}
+	return transport, nil
+}
+
+// proxiedHTTPClient returns the shared client, or a client going through
+// proxy when one is given explicitly, e.g. with `db shell --proxy`.
+func proxiedHTTPClient(proxy string) (*http.Client, error) {
+	if proxy == "" {
+		return sharedHTTPClient()
+	}
+	config, err := transportConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.Proxy = proxy
+	client, err := turso.NewHTTPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not configure HTTP transport: %w", err)
+	}
+	return client, nil
+}
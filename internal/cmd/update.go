@@ -74,8 +74,9 @@ var updateCmd = &cobra.Command{
 	Args:              cobra.NoArgs,
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cmd.SilenceUsage = true
-		latest, err := fetchLatestVersion()
+		latest, err := fetchLatestVersion(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get version information: %w", err)
 		}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+var uploadConcurrencyFlag int
+var maxBandwidthFlag string
+
+func addUploadFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&uploadConcurrencyFlag, "upload-concurrency", turso.DefaultUploadConcurrency, "Number of chunks of the database file uploaded at once")
+	cmd.Flags().StringVar(&maxBandwidthFlag, "max-bandwidth", "", "Maximum upload rate of the database file per second. Values with units are accepted, e.g. 500kb, 10mb")
+}
+
+func uploadOptionsFromFlags() (turso.UploadOptions, error) {
+	if uploadConcurrencyFlag < 1 {
+		return turso.UploadOptions{}, fmt.Errorf("--upload-concurrency must be at least 1, got %d", uploadConcurrencyFlag)
+	}
+	opts := turso.UploadOptions{Concurrency: uploadConcurrencyFlag}
+	if maxBandwidthFlag != "" {
+		bandwidth, err := humanize.ParseBytes(maxBandwidthFlag)
+		if err != nil || bandwidth == 0 {
+			return turso.UploadOptions{}, fmt.Errorf("invalid --max-bandwidth %q, expected a size such as 10mb", maxBandwidthFlag)
+		}
+		opts.MaxBandwidth = int64(bandwidth)
+	}
+	return opts, nil
+}
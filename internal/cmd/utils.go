@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,8 +31,8 @@ const (
 	tursoDefaultBaseURL = "https://api.turso.tech"
 )
 
-func authedTursoClient() (*turso.Client, error) {
-	token, err := getAccessToken()
+func authedTursoClient(ctx context.Context) (*turso.Client, error) {
+	token, err := getAccessToken(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +55,15 @@ func tursoClient(token string) (*turso.Client, error) {
 		return nil, fmt.Errorf("error creating turso client: could not read settings file: %w", err)
 	}
 
+	httpClient, err := sharedHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("error creating turso client: %w", err)
+	}
+
 	org := config.Organization()
-	return turso.New(tursoUrl, token, version, org), nil
+	client := turso.New(tursoUrl, token, version, org)
+	client.SetHTTPClient(httpClient)
+	return client, nil
 }
 
 func filterInstancesByRegion(instances []turso.Instance, region string) []turso.Instance {
@@ -143,7 +151,7 @@ func printTable(header []string, data [][]string) {
 	table.Render()
 }
 
-func destroyDatabases(client *turso.Client, names []string) error {
+func destroyDatabases(ctx context.Context, client *turso.Client, names []string) error {
 	if len(names) == 0 {
 		return nil
 	}
@@ -165,7 +173,7 @@ func destroyDatabases(client *turso.Client, names []string) error {
 	for _, name := range names {
 		name := name
 		g.Go(func() error {
-			return deleteDatabase(client, name)
+			return deleteDatabase(ctx, client, name)
 		})
 	}
 
@@ -187,41 +195,41 @@ func destroyDatabases(client *turso.Client, names []string) error {
 	return nil
 }
 
-func deleteDatabase(client *turso.Client, name string) error {
+func deleteDatabase(ctx context.Context, client *turso.Client, name string) error {
 	if !flags.V3Api() {
-		return client.Databases.Delete(name)
+		return client.Databases.Delete(ctx, name)
 	}
-	orgID, err := tryResolveOrgID(client)
+	orgID, err := tryResolveOrgID(ctx, client)
 	if err != nil {
 		return err
 	}
 	if orgID == "" {
-		return client.Databases.Delete(name)
+		return client.Databases.Delete(ctx, name)
 	}
-	dbID, err := tryResolveDbID(client, name)
+	dbID, err := tryResolveDbID(ctx, client, name)
 	if err != nil {
 		return err
 	}
 	if dbID == "" {
-		return client.Databases.Delete(name)
+		return client.Databases.Delete(ctx, name)
 	}
-	return client.DatabasesV3.Delete(orgID, dbID)
+	return client.DatabasesV3.Delete(ctx, orgID, dbID)
 }
 
-func destroyDatabaseRegion(client *turso.Client, database, region string) error {
-	if !isValidLocation(client, region) {
+func destroyDatabaseRegion(ctx context.Context, client *turso.Client, database, region string) error {
+	if !isValidLocation(ctx, client, region) {
 		return fmt.Errorf("location '%s' is not a valid one", region)
 	}
 
 	s := prompt.Spinner(fmt.Sprintf("Destroying location %s of database %s... ", internal.Emph(region), internal.Emph(database)))
 	defer s.Stop()
 
-	db, err := getDatabase(client, database, true)
+	db, err := getDatabase(ctx, client, database, true)
 	if err != nil {
 		return err
 	}
 
-	instances, err := client.Instances.List(db.Name)
+	instances, err := client.Instances.List(ctx, db.Name)
 	if err != nil {
 		return err
 	}
@@ -235,7 +243,7 @@ func destroyDatabaseRegion(client *turso.Client, database, region string) error
 	g := errgroup.Group{}
 	for i := range replicas {
 		replica := replicas[i]
-		g.Go(func() error { return deleteDatabaseInstance(client, db.Name, replica.Name) })
+		g.Go(func() error { return deleteDatabaseInstance(ctx, client, db.Name, replica.Name) })
 	}
 
 	if err := g.Wait(); err != nil {
@@ -252,11 +260,11 @@ func destroyDatabaseRegion(client *turso.Client, database, region string) error
 	return nil
 }
 
-func destroyDatabaseInstance(client *turso.Client, database, instance string) error {
+func destroyDatabaseInstance(ctx context.Context, client *turso.Client, database, instance string) error {
 	s := prompt.Spinner(fmt.Sprintf("Destroying instance %s of database %s... ", instance, internal.Emph(database)))
 	defer s.Stop()
 
-	err := deleteDatabaseInstance(client, database, instance)
+	err := deleteDatabaseInstance(ctx, client, database, instance)
 	if err != nil {
 		return err
 	}
@@ -266,8 +274,8 @@ func destroyDatabaseInstance(client *turso.Client, database, instance string) er
 	return nil
 }
 
-func deleteDatabaseInstance(client *turso.Client, database, instance string) error {
-	err := client.Instances.Delete(database, instance)
+func deleteDatabaseInstance(ctx context.Context, client *turso.Client, database, instance string) error {
+	err := client.Instances.Delete(ctx, database, instance)
 	if err != nil {
 		if err.Error() == "could not find database "+database+" to delete instance from" {
 			return fmt.Errorf("database %s not found. List known databases using %s", internal.Emph(database), internal.Emph("turso db list"))
@@ -315,23 +323,25 @@ func promptConfirmation(prompt string) (bool, error) {
 }
 
 func dbNameArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
 	if len(args) == 0 {
-		return getDatabaseNames(client), cobra.ShellCompDirectiveNoFileComp
+		return getDatabaseNames(ctx, client), cobra.ShellCompDirectiveNoFileComp
 	}
 	return []string{}, cobra.ShellCompDirectiveNoFileComp
 }
 
 func dbNameListArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
 	var dbNameList = make([]string, 0)
-	for _, dbName := range getDatabaseNames(client) {
+	for _, dbName := range getDatabaseNames(ctx, client) {
 		if !slices.Contains(args, dbName) {
 			dbNameList = append(dbNameList, dbName)
 		}
@@ -340,23 +350,24 @@ func dbNameListArg(cmd *cobra.Command, args []string, toComplete string) ([]stri
 }
 
 func dbNameAndOrgArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := authedTursoClient()
+	ctx := cmd.Context()
+	client, err := authedTursoClient(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 	if len(args) == 1 {
-		orgs, _ := client.Organizations.List()
+		orgs, _ := client.Organizations.List(ctx)
 		return extractOrgNames(orgs), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
 	}
 	return dbNameArg(cmd, args, toComplete)
 }
 
-func fetchLatestVersion() (string, error) {
+func fetchLatestVersion(ctx context.Context) (string, error) {
 	client, err := unauthedTursoClient()
 	if err != nil {
 		return "", err
 	}
-	resp, err := client.Get("/releases/latest", nil)
+	resp, err := client.Get(ctx, "/releases/latest", nil)
 	if err != nil {
 		return "", err
 	}
@@ -380,17 +391,17 @@ func fetchLatestVersion() (string, error) {
 	return versionResp.Version, nil
 }
 
-func instancesAndUsage(client *turso.Client, database string) (instances []turso.Instance, usage turso.DbUsage, err error) {
+func instancesAndUsage(ctx context.Context, client *turso.Client, database string) (instances []turso.Instance, usage turso.DbUsage, err error) {
 	if flags.V3Api() {
 		return nil, turso.DbUsage{}, nil
 	}
 	g := errgroup.Group{}
 	g.Go(func() (err error) {
-		instances, err = client.Instances.List(database)
+		instances, err = client.Instances.List(ctx, database)
 		return
 	})
 	g.Go(func() (err error) {
-		usage, err = client.Databases.Usage(database)
+		usage, err = client.Databases.Usage(ctx, database)
 		return
 	})
 	err = g.Wait()
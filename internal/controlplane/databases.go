@@ -0,0 +1,263 @@
+package controlplane
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+type database struct {
+	turso.Database
+	config turso.DatabaseConfig
+}
+
+// databaseResponse carries the configuration fields the v3 API returns
+// along with the database.
+type databaseResponse struct {
+	turso.Database
+	DeleteProtection bool `json:"delete_protection"`
+	AllowRulesConfig struct {
+		AllowedIPs       []string `json:"allowed_ips"`
+		AllowedAwsVpcIDs []string `json:"allowed_aws_vpc_ids"`
+	} `json:"allow_rules_config"`
+}
+
+func (db *database) response() databaseResponse {
+	resp := databaseResponse{Database: db.Database, DeleteProtection: db.config.IsDeleteProtected()}
+	resp.AllowRulesConfig.AllowedIPs = db.config.AllowedIPList()
+	resp.AllowRulesConfig.AllowedAwsVpcIDs = db.config.AllowedVpcIDList()
+	return resp
+}
+
+// lookupDatabase finds a database by name or, for the v3 API, by ID.
+func (o *organization) lookupDatabase(key string) *database {
+	if db, ok := o.databases[key]; ok {
+		return db
+	}
+	for _, db := range o.databases {
+		if db.ID == key {
+			return db
+		}
+	}
+	return nil
+}
+
+func (o *organization) databaseFromRequest(w http.ResponseWriter, r *http.Request) *database {
+	db := o.lookupDatabase(r.PathValue("database"))
+	if db == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("database %s not found", r.PathValue("database")))
+	}
+	return db
+}
+
+func (s *Server) listDatabases(w http.ResponseWriter, r *http.Request, org *organization) {
+	query := r.URL.Query()
+	groupFilter := query.Get("group")
+	if id := query.Get("group_id"); id != "" {
+		if g := org.lookupGroup(id); g != nil {
+			groupFilter = g.Name
+		} else {
+			groupFilter = id
+		}
+	}
+	parentFilter := query.Get("parent")
+	if id := query.Get("parent_db_id"); id != "" {
+		parentFilter = id
+	}
+
+	databases := make([]turso.Database, 0, len(org.databases))
+	for _, db := range org.databases {
+		if groupFilter != "" && db.Group != groupFilter {
+			continue
+		}
+		if parentFilter != "" && (db.Parent == nil || (db.Parent.Name != parentFilter && db.Parent.ID != parentFilter)) {
+			continue
+		}
+		databases = append(databases, db.Database)
+	}
+	sort.Slice(databases, func(i, j int) bool { return databases[i].Name < databases[j].Name })
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		start := sort.Search(len(databases), func(i int) bool { return databases[i].Name >= cursor })
+		databases = databases[start:]
+	}
+	resp := turso.ListResponse{Databases: databases}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < len(databases) {
+		next := databases[limit].Name
+		resp.Databases = databases[:limit]
+		resp.Pagination = &turso.Pagination{Next: &next}
+	}
+	writeJSON(w, resp)
+}
+
+type createDatabaseRequest struct {
+	Name             string                  `json:"name"`
+	Group            string                  `json:"group"`
+	GroupID          string                  `json:"group_id"`
+	Seed             *turso.DBSeed           `json:"seed"`
+	RemoteEncryption *turso.RemoteEncryption `json:"remote_encryption"`
+}
+
+func (s *Server) createDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body createDatabaseRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "database name is required")
+		return
+	}
+	if _, exists := org.databases[body.Name]; exists {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("database %s already exists", body.Name))
+		return
+	}
+	if body.Seed != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("seeding databases from %s is not supported by the local control plane", body.Seed.Type))
+		return
+	}
+	groupKey := body.Group
+	if body.GroupID != "" {
+		groupKey = body.GroupID
+	}
+	g := org.lookupGroup(groupKey)
+	if g == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("group %s not found", groupKey))
+		return
+	}
+
+	deleteProtection := false
+	db := &database{
+		Database: turso.Database{
+			ID:            uuid.NewString(),
+			Name:          body.Name,
+			Regions:       g.Locations,
+			PrimaryRegion: LocalRegion,
+			Hostname:      fmt.Sprintf("%s-%s.localhost", body.Name, org.Slug),
+			Version:       g.Version,
+			Group:         g.Name,
+		},
+		config: turso.DatabaseConfig{DeleteProtection: &deleteProtection},
+	}
+	if body.RemoteEncryption != nil {
+		db.EncryptionCipher = body.RemoteEncryption.EncryptionCipher
+	}
+	if s.sqld != nil {
+		addr, err := s.sqld.start(db.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		db.Hostname = addr
+	}
+	org.databases[db.Name] = db
+	writeJSON(w, map[string]any{"database": db.Database, "username": s.config.Username})
+}
+
+func (s *Server) getDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db := org.databaseFromRequest(w, r); db != nil {
+		writeJSON(w, map[string]any{"database": db.response()})
+	}
+}
+
+func (s *Server) deleteDatabase(w http.ResponseWriter, r *http.Request, org *organization) {
+	db := org.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	if db.config.IsDeleteProtected() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("database %s is delete protected", db.Name))
+		return
+	}
+	if err := s.removeDatabase(org, db); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, map[string]any{"database": db.Name})
+}
+
+func (s *Server) removeDatabase(org *organization, db *database) error {
+	if s.sqld != nil {
+		if err := s.sqld.stop(db.ID, true); err != nil {
+			return err
+		}
+	}
+	delete(org.databases, db.Name)
+	return nil
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request, org *organization) {
+	db := org.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	instance := turso.Instance{
+		Uuid:     db.ID,
+		Name:     LocalRegion,
+		Type:     "primary",
+		Region:   LocalRegion,
+		Hostname: db.Hostname,
+	}
+	writeJSON(w, map[string]any{"instances": []turso.Instance{instance}})
+}
+
+// getDatabaseUsage reports no usage, the control plane doesn't meter the
+// sqld instances.
+func (s *Server) getDatabaseUsage(w http.ResponseWriter, r *http.Request, org *organization) {
+	db := org.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	usage := turso.DbUsage{
+		UUID:      db.ID,
+		Instances: []turso.InstanceUsage{{UUID: db.ID}},
+	}
+	writeJSON(w, turso.DbUsageResponse{DbUsage: usage})
+}
+
+func (s *Server) getDatabaseStats(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db := org.databaseFromRequest(w, r); db != nil {
+		writeJSON(w, []turso.Stats{})
+	}
+}
+
+func (s *Server) getDatabaseConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	if db := org.databaseFromRequest(w, r); db != nil {
+		writeJSON(w, db.config)
+	}
+}
+
+func (s *Server) updateDatabaseConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	db := org.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	var body turso.DatabaseConfig
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.AllowAttach != nil {
+		db.config.AllowAttach = body.AllowAttach
+	}
+	if body.DeleteProtection != nil {
+		db.config.DeleteProtection = body.DeleteProtection
+	}
+	if body.AllowedIPs != nil {
+		db.config.AllowedIPs = body.AllowedIPs
+	}
+	if body.AllowedAwsVpcIDs != nil {
+		db.config.AllowedAwsVpcIDs = body.AllowedAwsVpcIDs
+	}
+	writeJSON(w, db.config)
+}
+
+func (s *Server) createDatabaseToken(w http.ResponseWriter, r *http.Request, org *organization) {
+	db := org.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	s.writeToken(w, r, db.ID)
+}
@@ -0,0 +1,200 @@
+package controlplane
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+type group struct {
+	turso.Group
+	config turso.GroupConfig
+}
+
+// lookupGroup finds a group by name or, for the v3 API, by ID.
+func (o *organization) lookupGroup(key string) *group {
+	if g, ok := o.groups[key]; ok {
+		return g
+	}
+	for _, g := range o.groups {
+		if g.UUID == key {
+			return g
+		}
+	}
+	return nil
+}
+
+func (o *organization) groupFromRequest(w http.ResponseWriter, r *http.Request) *group {
+	g := o.lookupGroup(r.PathValue("group"))
+	if g == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("group %s not found", r.PathValue("group")))
+	}
+	return g
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request, org *organization) {
+	groups := make([]turso.Group, 0, len(org.groups))
+	for _, g := range org.groups {
+		groups = append(groups, g.Group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	writeJSON(w, map[string]any{"groups": groups})
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	var body struct{ Name, Location, Version string }
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "group name is required")
+		return
+	}
+	if _, exists := org.groups[body.Name]; exists {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("group %s already exists", body.Name))
+		return
+	}
+	if _, ok := Locations[body.Location]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("location %s is not valid", body.Location))
+		return
+	}
+	if body.Version == "" {
+		body.Version = "latest"
+	}
+
+	deleteProtection := false
+	g := &group{
+		Group: turso.Group{
+			UUID:      uuid.NewString(),
+			Name:      body.Name,
+			Locations: []string{body.Location},
+			Primary:   body.Location,
+			Version:   body.Version,
+		},
+		config: turso.GroupConfig{DeleteProtection: &deleteProtection},
+	}
+	g.updateStatus()
+	org.groups[g.Name] = g
+	writeJSON(w, map[string]any{"group": g.Group})
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	if g := org.groupFromRequest(w, r); g != nil {
+		writeJSON(w, map[string]any{"group": g.Group})
+	}
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	if g.config.IsDeleteProtected() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("group %s is delete protected", g.Name))
+		return
+	}
+	for _, db := range org.databases {
+		if db.Group != g.Name {
+			continue
+		}
+		if err := s.removeDatabase(org, db); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	delete(org.groups, g.Name)
+	writeJSON(w, map[string]any{"group": g.Group})
+}
+
+func (s *Server) getGroupConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	if g := org.groupFromRequest(w, r); g != nil {
+		writeJSON(w, g.config)
+	}
+}
+
+func (s *Server) updateGroupConfig(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	var body turso.GroupConfig
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.DeleteProtection != nil {
+		g.config.DeleteProtection = body.DeleteProtection
+	}
+	writeJSON(w, g.config)
+}
+
+func (s *Server) addGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	location := r.PathValue("location")
+	if _, ok := Locations[location]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("location %s is not valid", location))
+		return
+	}
+	if !slices.Contains(g.Locations, location) {
+		g.Locations = append(g.Locations, location)
+		g.updateStatus()
+	}
+	writeJSON(w, map[string]any{"group": g.Group})
+}
+
+func (s *Server) removeGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	location := r.PathValue("location")
+	if location == g.Primary {
+		writeError(w, http.StatusBadRequest, "the primary location can't be removed")
+		return
+	}
+	g.Locations = slices.DeleteFunc(g.Locations, func(l string) bool { return l == location })
+	g.updateStatus()
+	writeJSON(w, map[string]any{"group": g.Group})
+}
+
+func (s *Server) waitGroupLocation(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	if !slices.Contains(g.Locations, r.PathValue("location")) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("location %s not found in group %s", r.PathValue("location"), g.Name))
+		return
+	}
+	writeJSON(w, map[string]any{})
+}
+
+func (s *Server) unarchiveGroup(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	g.Archived = false
+	writeJSON(w, map[string]any{"group": g.Group})
+}
+
+func (s *Server) createGroupToken(w http.ResponseWriter, r *http.Request, org *organization) {
+	g := org.groupFromRequest(w, r)
+	if g == nil {
+		return
+	}
+	s.writeToken(w, r, g.UUID)
+}
+
+func (g *group) updateStatus() {
+	g.Status.Locations = g.Status.Locations[:0]
+	for _, location := range g.Locations {
+		g.Status.Locations = append(g.Status.Locations, turso.LocationStatus{Name: location, Status: "up"})
+	}
+}
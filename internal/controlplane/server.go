@@ -0,0 +1,352 @@
+// Package controlplane implements an in-memory fake of the Turso platform
+// API, so the CLI can be exercised end-to-end without network access.
+//
+// It covers the organizations, groups, databases, tokens and locations
+// endpoints of the v1, v2 and v3 APIs used by the CLI. When a sqld binary
+// is configured, every database is served by its own local sqld instance and
+// database tokens are accepted by it.
+package controlplane
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// LocalRegion is the primary region of every database. The CLI connects to
+// databases in this region over plain HTTP.
+const LocalRegion = "local"
+
+// Locations are the location codes accepted when creating groups. All of
+// them are served by the same local machine.
+var Locations = map[string]string{
+	LocalRegion:     "Local machine",
+	"ams":           "Amsterdam, Netherlands",
+	"aws-us-east-1": "AWS US East (Virginia)",
+	"aws-eu-west-1": "AWS EU West (Ireland)",
+	"iad":           "Ashburn, Virginia (US)",
+}
+
+type Config struct {
+	// Token is the platform API token clients must present. When empty any
+	// non-empty token is accepted.
+	Token string
+	// Username is the owner of the personal organization, "dev" by default.
+	Username string
+	// SqldPath is the sqld binary used to serve databases. When empty,
+	// databases only exist in the control plane.
+	SqldPath string
+	// DataDir holds the sqld databases. A temporary directory is used, and
+	// removed on Close, when empty.
+	DataDir string
+}
+
+// Server is an http.Handler serving the fake platform API.
+type Server struct {
+	config     Config
+	mux        *http.ServeMux
+	key        ed25519.PrivateKey
+	sqld       *sqldPool
+	removeData bool
+
+	mu    sync.Mutex
+	orgs  map[string]*organization
+	order []string
+}
+
+type organization struct {
+	turso.Organization
+	groups    map[string]*group
+	databases map[string]*database
+}
+
+func New(config Config) (*Server, error) {
+	if config.Username == "" {
+		config.Username = "dev"
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate signing key: %w", err)
+	}
+
+	s := &Server{
+		config: config,
+		mux:    http.NewServeMux(),
+		key:    key,
+		orgs:   make(map[string]*organization),
+	}
+	s.addOrganization(turso.Organization{Name: config.Username, Slug: config.Username, Type: "personal"})
+
+	if config.SqldPath != "" {
+		if s.config.DataDir == "" {
+			if s.config.DataDir, err = os.MkdirTemp("", "turso-control-plane-*"); err != nil {
+				return nil, fmt.Errorf("could not create data directory: %w", err)
+			}
+			s.removeData = true
+		}
+		if s.sqld, err = newSqldPool(config.SqldPath, s.config.DataDir, key.Public().(ed25519.PublicKey)); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	s.routes()
+	return s, nil
+}
+
+// Close stops the sqld instances and removes the temporary data directory.
+func (s *Server) Close() error {
+	var err error
+	if s.sqld != nil {
+		err = s.sqld.close()
+	}
+	if s.removeData {
+		err = errors.Join(err, os.RemoveAll(s.config.DataDir))
+	}
+	return err
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid or missing token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	return s.config.Token == "" || token == s.config.Token
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /v1/auth/validate", s.validateToken)
+	s.mux.HandleFunc("GET /v1/current-user", s.currentUser)
+	s.mux.HandleFunc("GET /v1/auth/api-tokens", s.listApiTokens)
+	s.mux.HandleFunc("GET /v2/organizations", s.listOrganizations)
+	s.mux.HandleFunc("POST /v1/organizations", s.createOrganization)
+	s.mux.HandleFunc("DELETE /v1/organizations/{org}", s.deleteOrganization)
+	s.handle("GET", "/v1/organizations/{org}", "/members", s.listMembers)
+	s.mux.HandleFunc("GET /v1/locations/{location}", s.getLocation)
+	s.mux.HandleFunc("GET /v2/locations", s.listLocations)
+	s.mux.HandleFunc("GET /v2/organizations/{org}/locations", s.listLocations)
+
+	// Organization scoped endpoints exist both under the organization and,
+	// for the personal organization, directly under /v1.
+	for _, prefix := range []string{"/v1", "/v1/organizations/{org}"} {
+		s.handle("GET", prefix, "/groups", s.listGroups)
+		s.handle("POST", prefix, "/groups", s.createGroup)
+		s.handle("GET", prefix, "/groups/{group}", s.getGroup)
+		s.handle("DELETE", prefix, "/groups/{group}", s.deleteGroup)
+		s.handle("GET", prefix, "/groups/{group}/configuration", s.getGroupConfig)
+		s.handle("PATCH", prefix, "/groups/{group}/configuration", s.updateGroupConfig)
+		s.handle("POST", prefix, "/groups/{group}/locations/{location}", s.addGroupLocation)
+		s.handle("DELETE", prefix, "/groups/{group}/locations/{location}", s.removeGroupLocation)
+		s.handle("GET", prefix, "/groups/{group}/locations/{location}/wait", s.waitGroupLocation)
+		s.handle("POST", prefix, "/groups/{group}/auth/tokens", s.createGroupToken)
+		s.handle("POST", prefix, "/groups/{group}/auth/rotate", s.rotate)
+		s.handle("POST", prefix, "/groups/{group}/unarchive", s.unarchiveGroup)
+
+		s.handle("GET", prefix, "/databases", s.listDatabases)
+		s.handle("POST", prefix, "/databases", s.createDatabase)
+		s.handle("GET", prefix, "/databases/{database}", s.getDatabase)
+		s.handle("DELETE", prefix, "/databases/{database}", s.deleteDatabase)
+		s.handle("GET", prefix, "/databases/{database}/instances", s.listInstances)
+		s.handle("GET", prefix, "/databases/{database}/usage", s.getDatabaseUsage)
+		s.handle("GET", prefix, "/databases/{database}/usage/queries", s.getDatabaseStats)
+		s.handle("GET", prefix, "/databases/{database}/configuration", s.getDatabaseConfig)
+		s.handle("PATCH", prefix, "/databases/{database}/configuration", s.updateDatabaseConfig)
+		s.handle("POST", prefix, "/databases/{database}/auth/tokens", s.createDatabaseToken)
+		s.handle("POST", prefix, "/databases/{database}/auth/rotate", s.rotate)
+	}
+
+	// The v3 API addresses every resource by its ID.
+	s.handle("GET", "/v3/organizations/{org}", "/groups", s.listGroups)
+	s.handle("GET", "/v3/organizations/{org}", "/groups/{group}", s.getGroup)
+	s.handle("POST", "/v3/organizations/{org}", "/groups/{group}/auth/tokens", s.createGroupToken)
+	s.handle("GET", "/v3/organizations/{org}", "/databases", s.listDatabases)
+	s.handle("POST", "/v3/organizations/{org}", "/databases", s.createDatabase)
+	s.handle("GET", "/v3/organizations/{org}", "/databases/{database}", s.getDatabase)
+	s.handle("DELETE", "/v3/organizations/{org}", "/databases/{database}", s.deleteDatabase)
+	s.handle("POST", "/v3/organizations/{org}", "/databases/{database}/auth/tokens", s.createDatabaseToken)
+}
+
+// orgHandler handles a request scoped to org. The server lock is held.
+type orgHandler func(w http.ResponseWriter, r *http.Request, org *organization)
+
+func (s *Server) handle(method, prefix, suffix string, handler orgHandler) {
+	s.mux.HandleFunc(method+" "+prefix+suffix, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		org := s.lookupOrganization(r.PathValue("org"))
+		if org == nil {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("you are not a member of organization %s", r.PathValue("org")))
+			return
+		}
+		handler(w, r, org)
+	})
+}
+
+// lookupOrganization finds an organization by slug or ID. An empty key
+// designates the personal organization.
+func (s *Server) lookupOrganization(key string) *organization {
+	if key == "" {
+		key = s.config.Username
+	}
+	if org, ok := s.orgs[key]; ok {
+		return org
+	}
+	for _, org := range s.orgs {
+		if org.ID == key {
+			return org
+		}
+	}
+	return nil
+}
+
+func (s *Server) addOrganization(org turso.Organization) *organization {
+	org.ID = uuid.NewString()
+	o := &organization{
+		Organization: org,
+		groups:       make(map[string]*group),
+		databases:    make(map[string]*database),
+	}
+	s.orgs[org.Slug] = o
+	s.order = append(s.order, org.Slug)
+	return o
+}
+
+func (s *Server) validateToken(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"exp": -1})
+}
+
+func (s *Server) currentUser(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, turso.UserInfoResponse{User: turso.UserInfo{Username: s.config.Username, Plan: "developer"}})
+}
+
+// listApiTokens lists no tokens: the server accepts a single platform token,
+// configured when it starts.
+func (s *Server) listApiTokens(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{"tokens": []turso.ApiToken{}})
+}
+
+// listMembers lists the user as the only member, and owner, of every
+// organization.
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, map[string]any{"members": []turso.Member{{Name: s.config.Username, Role: "owner"}}})
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgs := make([]turso.Organization, 0, len(s.order))
+	for _, slug := range s.order {
+		orgs = append(orgs, s.orgs[slug].Organization)
+	}
+	writeJSON(w, map[string]any{"organizations": orgs})
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request) {
+	var body turso.Organization
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "organization name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.orgs[body.Name]; exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("organization %s already exists", body.Name))
+		return
+	}
+	org := turso.Organization{Name: body.Name, Slug: body.Name, Type: "team"}
+	if r.URL.Query().Get("dry_run") != "true" {
+		org = s.addOrganization(org).Organization
+	}
+	writeJSON(w, map[string]any{"organization": org})
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.lookupOrganization(r.PathValue("org"))
+	if org == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("organization %s not found", r.PathValue("org")))
+		return
+	}
+	if org.Type == "personal" {
+		writeError(w, http.StatusBadRequest, "personal organizations can't be deleted")
+		return
+	}
+	if len(org.databases) > 0 {
+		writeError(w, http.StatusConflict, "organization still has databases")
+		return
+	}
+	delete(s.orgs, org.Slug)
+	for i, slug := range s.order {
+		if slug == org.Slug {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	writeJSON(w, map[string]any{})
+}
+
+func (s *Server) getLocation(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("location")
+	description, ok := Locations[code]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("location %s not found", code))
+		return
+	}
+	writeJSON(w, map[string]any{"location": turso.LocationResponse{Code: code, Description: description}})
+}
+
+func (s *Server) listLocations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, turso.OrgLocationsResponse{Locations: turso.OrgLocations{"local": Locations}})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Body == nil || r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers with the error format of the platform API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
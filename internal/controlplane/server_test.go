@@ -0,0 +1,166 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+func newTestClient(t *testing.T, config Config, org string) (*turso.Client, *Server) {
+	t.Helper()
+	server, err := New(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	base, err := url.Parse(httpServer.URL)
+	require.NoError(t, err)
+	return turso.New(base, "token", "dev", org), server
+}
+
+func TestDatabaseLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, Config{}, "")
+
+	require.NoError(t, client.Groups.Create(ctx, "default", "ams", "latest"))
+	require.NoError(t, client.Groups.WaitLocation(ctx, "default", "ams"))
+
+	created, err := client.Databases.Create(ctx, "db1", "ams", "", "", "default", "", false, nil, "", "", "", false, nil)
+	require.NoError(t, err)
+	require.Equal(t, "db1", created.Database.Name)
+	require.Equal(t, LocalRegion, created.Database.PrimaryRegion)
+
+	_, err = client.Databases.Create(ctx, "db1", "ams", "", "", "default", "", false, nil, "", "", "", false, nil)
+	require.Error(t, err)
+
+	db, err := client.Databases.Get(ctx, "db1")
+	require.NoError(t, err)
+	require.Equal(t, created.Database.ID, db.ID)
+
+	instances, err := client.Instances.List(ctx, "db1")
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	require.Equal(t, "primary", instances[0].Type)
+
+	require.NoError(t, client.Databases.Delete(ctx, "db1"))
+	_, err = client.Databases.Get(ctx, "db1")
+	apiErr, ok := turso.AsAPIError(err)
+	require.True(t, ok)
+	require.True(t, apiErr.NotFound())
+}
+
+func TestDatabaseListPagination(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, Config{}, "")
+
+	require.NoError(t, client.Groups.Create(ctx, "default", "local", "latest"))
+	for _, name := range []string{"c", "a", "b"} {
+		_, err := client.Databases.Create(ctx, name, "local", "", "", "default", "", false, nil, "", "", "", false, nil)
+		require.NoError(t, err)
+	}
+
+	page, err := client.Databases.List(ctx, turso.DatabaseListOptions{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Databases, 2)
+	require.Equal(t, "a", page.Databases[0].Name)
+	require.NotNil(t, page.Pagination)
+
+	page, err = client.Databases.List(ctx, turso.DatabaseListOptions{Limit: 2, Cursor: *page.Pagination.Next})
+	require.NoError(t, err)
+	require.Len(t, page.Databases, 1)
+	require.Equal(t, "c", page.Databases[0].Name)
+	require.Nil(t, page.Pagination)
+}
+
+func TestV3Endpoints(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, Config{}, "")
+
+	orgs, err := client.Organizations.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+	orgID := orgs[0].ID
+
+	require.NoError(t, client.Groups.Create(ctx, "default", "local", "latest"))
+	groups, err := client.GroupsV3.List(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+
+	db, err := client.DatabasesV3.Create(ctx, orgID, turso.CreateDatabaseV3Body{Name: "db1", GroupID: groups[0].UUID})
+	require.NoError(t, err)
+	require.Equal(t, "default", db.Group)
+
+	config, err := client.DatabasesV3.GetConfig(ctx, orgID, db.ID)
+	require.NoError(t, err)
+	require.False(t, config.IsDeleteProtected())
+
+	require.NoError(t, client.DatabasesV3.Delete(ctx, orgID, db.ID))
+	dbs, _, err := client.DatabasesV3.List(ctx, orgID, turso.DatabaseV3ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, dbs)
+}
+
+func TestDatabaseTokens(t *testing.T) {
+	ctx := context.Background()
+	client, server := newTestClient(t, Config{}, "")
+
+	require.NoError(t, client.Groups.Create(ctx, "default", "local", "latest"))
+	_, err := client.Databases.Create(ctx, "db1", "local", "", "", "default", "", false, nil, "", "", "", false, nil)
+	require.NoError(t, err)
+
+	token, err := client.Databases.Token(ctx, "db1", "2d", true, nil, nil)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(server.key.Public().(ed25519.PublicKey), []byte(parts[0]+"."+parts[1]), signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		A   string `json:"a"`
+		Exp int64  `json:"exp"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	require.Equal(t, "ro", claims.A)
+	require.WithinDuration(t, time.Now().Add(48*time.Hour), time.Unix(claims.Exp, 0), time.Minute)
+}
+
+func TestRejectsInvalidToken(t *testing.T) {
+	client, _ := newTestClient(t, Config{Token: "secret"}, "")
+
+	_, err := client.Tokens.Validate(context.Background(), "token")
+	apiErr, ok := turso.AsAPIError(err)
+	require.True(t, ok)
+	require.True(t, apiErr.Unauthorized())
+}
+
+func TestParseExpiration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Time{
+		"":      {},
+		"never": {},
+		"2d":    now.Add(48 * time.Hour),
+		"1w":    now.Add(7 * 24 * time.Hour),
+		"90m":   now.Add(90 * time.Minute),
+	} {
+		got, err := parseExpiration(value, now)
+		require.NoError(t, err, value)
+		require.Equal(t, want, got, value)
+	}
+	_, err := parseExpiration("soon", now)
+	require.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package controlplane
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const sqldStartTimeout = 10 * time.Second
+
+// sqldPool runs one sqld instance per database, all of them trusting the
+// tokens signed by the control plane.
+type sqldPool struct {
+	path      string
+	dataDir   string
+	keyFile   string
+	instances map[string]*sqldInstance
+}
+
+type sqldInstance struct {
+	cmd    *exec.Cmd
+	addr   string
+	dir    string
+	exited chan struct{}
+	err    error
+}
+
+func newSqldPool(path, dataDir string, key ed25519.PublicKey) (*sqldPool, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create data directory: %w", err)
+	}
+	p := &sqldPool{
+		path:      path,
+		dataDir:   dataDir,
+		keyFile:   filepath.Join(dataDir, "jwt-key.pem"),
+		instances: make(map[string]*sqldInstance),
+	}
+	if err := p.writeKey(key); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *sqldPool) writeKey(key ed25519.PublicKey) error {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("could not encode JWT key: %w", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	if err := os.WriteFile(p.keyFile, data, 0o600); err != nil {
+		return fmt.Errorf("could not write JWT key: %w", err)
+	}
+	return nil
+}
+
+// start launches a sqld instance for the database id and returns the
+// address it listens on.
+func (p *sqldPool) start(id string) (string, error) {
+	addr, err := freeAddr()
+	if err != nil {
+		return "", err
+	}
+	instance := &sqldInstance{addr: addr, dir: filepath.Join(p.dataDir, id)}
+	if err := p.run(instance); err != nil {
+		return "", err
+	}
+	p.instances[id] = instance
+	return addr, nil
+}
+
+func (p *sqldPool) run(instance *sqldInstance) error {
+	instance.cmd = exec.Command(p.path,
+		"--no-welcome",
+		"--http-listen-addr", instance.addr,
+		"--auth-jwt-key-file", p.keyFile,
+		"-d", instance.dir,
+	)
+	instance.cmd.Env = append(os.Environ(), "RUST_LOG=error")
+	instance.cmd.Stderr = os.Stderr
+	if err := instance.cmd.Start(); err != nil {
+		return fmt.Errorf("could not start sqld: %w", err)
+	}
+	instance.exited = make(chan struct{})
+	go func() {
+		instance.err = instance.cmd.Wait()
+		close(instance.exited)
+	}()
+
+	deadline := time.Now().Add(sqldStartTimeout)
+	for {
+		res, err := http.Get("http://" + instance.addr + "/health")
+		if err == nil {
+			res.Body.Close()
+			return nil
+		}
+		select {
+		case <-instance.exited:
+			return fmt.Errorf("sqld exited unexpectedly: %v", instance.err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			instance.kill()
+			return fmt.Errorf("sqld not ready after %v", sqldStartTimeout)
+		}
+	}
+}
+
+func (i *sqldInstance) kill() {
+	i.cmd.Process.Kill()
+	<-i.exited
+}
+
+// stop terminates the sqld instance of the database id, removing its data
+// when removeData is set.
+func (p *sqldPool) stop(id string, removeData bool) error {
+	instance, ok := p.instances[id]
+	if !ok {
+		return nil
+	}
+	instance.kill()
+	delete(p.instances, id)
+	if removeData {
+		return os.RemoveAll(instance.dir)
+	}
+	return nil
+}
+
+// setKey replaces the JWT key and restarts every instance so they pick it
+// up. Instances keep their address and data.
+func (p *sqldPool) setKey(key ed25519.PublicKey) error {
+	if err := p.writeKey(key); err != nil {
+		return err
+	}
+	for _, instance := range p.instances {
+		instance.kill()
+		if err := p.run(instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *sqldPool) close() error {
+	var err error
+	for id := range p.instances {
+		err = errors.Join(err, p.stop(id, false))
+	}
+	return err
+}
+
+// freeAddr returns a local address with a port nobody is listening on.
+func freeAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("could not find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
@@ -0,0 +1,103 @@
+package controlplane
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeToken answers a token request for the database or group id with a
+// JWT that sqld accepts.
+func (s *Server) writeToken(w http.ResponseWriter, r *http.Request, id string) {
+	query := r.URL.Query()
+	claims := map[string]any{
+		"id":  id,
+		"iat": time.Now().Unix(),
+		"a":   "rw",
+	}
+	if query.Get("authorization") == "read-only" {
+		claims["a"] = "ro"
+	}
+	exp, err := parseExpiration(query.Get("expiration"), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !exp.IsZero() {
+		claims["exp"] = exp.Unix()
+	}
+
+	jwt, err := signToken(s.key, claims)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, map[string]string{"jwt": jwt})
+}
+
+// rotate replaces the signing key, which invalidates every token issued so
+// far. Unlike the platform, the key is shared by all groups and databases.
+func (s *Server) rotate(w http.ResponseWriter, r *http.Request, org *organization) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if s.sqld != nil {
+		if err := s.sqld.setKey(key.Public().(ed25519.PublicKey)); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	s.key = key
+	writeJSON(w, map[string]any{})
+}
+
+// parseExpiration parses the expiration of a token: "never", a Go duration
+// or a number of days or weeks such as "7d" or "2w". The zero time means the
+// token does not expire.
+func parseExpiration(value string, now time.Time) (time.Time, error) {
+	if value == "" || value == "never" {
+		return time.Time{}, nil
+	}
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n <= 0 {
+			return time.Time{}, fmt.Errorf("invalid expiration %q", value)
+		}
+		return now.Add(time.Duration(n) * unit), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid expiration %q", value)
+	}
+	return now.Add(d), nil
+}
+
+func signToken(key ed25519.PrivateKey, claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(payload)
+	signature := ed25519.Sign(key, []byte(unsigned))
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
@@ -7,7 +7,7 @@ import (
 var debugFlag bool
 
 func AddDebugFlag(cmd *cobra.Command) {
-	usage := "If set, shows dumps of all outgoing HTTP requests."
+	usage := "If set, shows dumps of all outgoing platform API requests on stderr, with credentials redacted."
 	cmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, usage)
 	cmd.PersistentFlags().MarkHidden("debug")
 }
@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultMaxRetries = 3
+
+var maxRetriesFlag int
+
+func AddMaxRetries(cmd *cobra.Command) {
+	usage := "Maximum number of times a failed platform API request is retried. Can also be set with TURSO_MAX_RETRIES."
+	cmd.PersistentFlags().IntVar(&maxRetriesFlag, "max-retries", maxRetriesFromEnv(), usage)
+}
+
+func MaxRetries() int {
+	if maxRetriesFlag < 0 {
+		return 0
+	}
+	return maxRetriesFlag
+}
+
+func maxRetriesFromEnv() int {
+	value, ok := os.LookupEnv("TURSO_MAX_RETRIES")
+	if !ok {
+		return defaultMaxRetries
+	}
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 {
+		return defaultMaxRetries
+	}
+	return retries
+}
@@ -0,0 +1,47 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+var outputFlag string
+
+func AddOutput(cmd *cobra.Command) {
+	usage := "Output format of read commands. Possible values are 'table', 'json' or 'yaml'."
+	cmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", OutputTable, usage)
+	_ = cmd.RegisterFlagCompletionFunc("output", outputFlagCompletion)
+}
+
+func Output() (string, error) {
+	if err := validateOutput(outputFlag); err != nil {
+		return "", err
+	}
+	return outputFlag, nil
+}
+
+// StructuredOutput reports whether commands should render machine readable
+// documents instead of human tables. Spinners and colors are disabled then.
+func StructuredOutput() bool {
+	return outputFlag == OutputJSON || outputFlag == OutputYAML
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return fmt.Errorf("output must be one of '%s', '%s' or '%s'", OutputTable, OutputJSON, OutputYAML)
+	}
+}
+
+func outputFlagCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{OutputTable, OutputJSON, OutputYAML}, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,18 @@
+package flags
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var profileFlag string
+
+func AddProfile(cmd *cobra.Command) {
+	usage := "Use the credentials, organization and caches of the given profile. Can also be set with TURSO_PROFILE."
+	cmd.PersistentFlags().StringVar(&profileFlag, "profile", os.Getenv("TURSO_PROFILE"), usage)
+}
+
+func Profile() string {
+	return profileFlag
+}
@@ -0,0 +1,18 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var timeoutFlag time.Duration
+
+func AddTimeout(cmd *cobra.Command) {
+	usage := "Abort a request that makes no progress for the given duration, e.g. 30s or 5m. Zero means no timeout."
+	cmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, usage)
+}
+
+func Timeout() time.Duration {
+	return timeoutFlag
+}
@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	traceFileFlag   string
+	traceBodiesFlag bool
+)
+
+func AddTrace(cmd *cobra.Command) {
+	usage := "Write a JSON line per HTTP request to the given file, or to stderr with '-'. Tokens and encryption keys are redacted. Can also be set with TURSO_TRACE_FILE."
+	cmd.PersistentFlags().StringVar(&traceFileFlag, "trace-file", os.Getenv("TURSO_TRACE_FILE"), usage)
+	bodiesUsage := "Include headers and request and response bodies in the trace."
+	cmd.PersistentFlags().BoolVar(&traceBodiesFlag, "trace-bodies", false, bodiesUsage)
+}
+
+func TraceFile() string {
+	return traceFileFlag
+}
+
+func TraceBodies() bool {
+	return traceBodiesFlag
+}
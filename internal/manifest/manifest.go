@@ -0,0 +1,126 @@
+// Package manifest reads declarative descriptions of the groups and databases
+// of an organization and computes the changes that make the organization
+// match them.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes groups and databases by name. Resources missing from the
+// manifest are left untouched, and so are the settings a resource omits.
+type Manifest struct {
+	Groups    map[string]*Group    `yaml:"groups"`
+	Databases map[string]*Database `yaml:"databases"`
+}
+
+type Group struct {
+	Primary string `yaml:"primary"`
+	// Locations are the replica locations of the group. When set, locations
+	// missing from the list are removed from the group.
+	Locations        []string `yaml:"locations"`
+	DeleteProtection *bool    `yaml:"delete_protection"`
+}
+
+type Database struct {
+	Group            string      `yaml:"group"`
+	Seed             *Seed       `yaml:"seed"`
+	SizeLimit        string      `yaml:"size_limit"`
+	Encryption       *Encryption `yaml:"encryption"`
+	DeleteProtection *bool       `yaml:"delete_protection"`
+	AllowAttach      *bool       `yaml:"allow_attach"`
+	AllowRules       *AllowRules `yaml:"allow_rules"`
+}
+
+// Seed is the data a database is created with: a copy of another database,
+// optionally at a point in time, or a dump.
+type Seed struct {
+	Database  string     `yaml:"database"`
+	Timestamp *time.Time `yaml:"timestamp"`
+	DumpURL   string     `yaml:"dump_url"`
+}
+
+// Encryption names the environment variable holding the encryption key, so
+// that manifests can be committed.
+type Encryption struct {
+	Cipher string `yaml:"cipher"`
+	KeyEnv string `yaml:"key_env"`
+}
+
+type AllowRules struct {
+	IPs     *[]string `yaml:"ips"`
+	AwsVpcs *[]string `yaml:"aws_vpcs"`
+}
+
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return m, nil
+}
+
+func (m *Manifest) validate() error {
+	for name, group := range m.Groups {
+		if group == nil || group.Primary == "" {
+			return fmt.Errorf("group %s: primary location is required", name)
+		}
+	}
+	for name, db := range m.Databases {
+		if db == nil || db.Group == "" {
+			return fmt.Errorf("database %s: group is required", name)
+		}
+		if seed := db.Seed; seed != nil {
+			if (seed.Database == "") == (seed.DumpURL == "") {
+				return fmt.Errorf("database %s: seed needs exactly one of database or dump_url", name)
+			}
+			if seed.Timestamp != nil && seed.Database == "" {
+				return fmt.Errorf("database %s: seed timestamp can only be used with a database", name)
+			}
+		}
+		if enc := db.Encryption; enc != nil && (enc.Cipher == "" || enc.KeyEnv == "") {
+			return fmt.Errorf("database %s: encryption needs both cipher and key_env", name)
+		}
+	}
+	return nil
+}
+
+// locations returns the locations of the group, the primary one first.
+func (g *Group) locations() []string {
+	locations := []string{g.Primary}
+	for _, location := range g.Locations {
+		if !contains(locations, location) {
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+const testManifest = `
+groups:
+  eu:
+    primary: fra
+    locations: [ams]
+    delete_protection: true
+databases:
+  orders:
+    group: eu
+    size_limit: 1gb
+    delete_protection: true
+    allow_rules:
+      ips: [10.0.0.0/8]
+  events:
+    group: eu
+    seed:
+      database: orders
+`
+
+func kinds(plan *Plan) []Kind {
+	result := []Kind{}
+	for _, change := range plan.Changes {
+		result = append(result, change.Kind)
+	}
+	return result
+}
+
+func TestParseRejectsInvalidManifests(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown field":       "groups:\n  eu:\n    primary: fra\n    replicas: [ams]\n",
+		"missing primary":     "groups:\n  eu:\n    locations: [ams]\n",
+		"missing group":       "databases:\n  db:\n    size_limit: 1gb\n",
+		"two seeds":           "databases:\n  db:\n    group: eu\n    seed:\n      database: a\n      dump_url: https://example.com/dump.sql\n",
+		"timestamp with dump": "databases:\n  db:\n    group: eu\n    seed:\n      dump_url: https://example.com/dump.sql\n      timestamp: 2024-01-01T00:00:00Z\n",
+		"encryption key":      "databases:\n  db:\n    group: eu\n    encryption:\n      cipher: aes256gcm\n",
+	} {
+		_, err := Parse([]byte(data))
+		require.Error(t, err, name)
+	}
+}
+
+func TestDiffCreatesMissingResources(t *testing.T) {
+	m, err := Parse([]byte(testManifest))
+	require.NoError(t, err)
+
+	plan, err := Diff(m, State{})
+	require.NoError(t, err)
+	require.Empty(t, plan.Warnings)
+	require.Equal(t, []Kind{CreateGroup, AddLocation, ConfigureGroup, CreateDatabase, CreateDatabase, ConfigureDatabase}, kinds(plan))
+
+	require.Equal(t, "fra", plan.Changes[0].Location)
+	require.Equal(t, "ams", plan.Changes[1].Location)
+	require.Equal(t, "events", plan.Changes[3].Name)
+	require.Equal(t, "fra", plan.Changes[3].Location)
+	require.Equal(t, []string{"10.0.0.0/8"}, plan.Changes[5].DatabaseConfig.AllowedIPList())
+	require.True(t, plan.Changes[5].DatabaseConfig.IsDeleteProtected())
+	require.Nil(t, plan.Changes[5].DatabaseConfig.AllowAttach)
+}
+
+func TestDiffOfMatchingStateIsEmpty(t *testing.T) {
+	m, err := Parse([]byte(testManifest))
+	require.NoError(t, err)
+
+	on := true
+	ips := []string{"10.0.0.0/8"}
+	state := State{
+		Groups: map[string]GroupState{
+			"eu": {Group: turso.Group{Name: "eu", Primary: "fra", Locations: []string{"ams", "fra"}}, Config: turso.GroupConfig{DeleteProtection: &on}},
+		},
+		Databases: map[string]DatabaseState{
+			"orders": {Database: turso.Database{Name: "orders", Group: "eu"}, Config: turso.DatabaseConfig{DeleteProtection: &on, AllowedIPs: &ips}},
+			"events": {Database: turso.Database{Name: "events", Group: "eu"}},
+		},
+	}
+	plan, err := Diff(m, state)
+	require.NoError(t, err)
+	require.True(t, plan.Empty(), kinds(plan))
+	require.Empty(t, plan.Warnings)
+}
+
+func TestDiffUpdatesExistingResources(t *testing.T) {
+	m, err := Parse([]byte(testManifest))
+	require.NoError(t, err)
+
+	state := State{
+		Groups: map[string]GroupState{
+			"eu": {Group: turso.Group{Name: "eu", Primary: "cdg", Locations: []string{"cdg", "lhr"}}},
+		},
+		Databases: map[string]DatabaseState{
+			"orders": {Database: turso.Database{Name: "orders", Group: "us"}},
+			"events": {Database: turso.Database{Name: "events", Group: "eu"}},
+		},
+	}
+	plan, err := Diff(m, state)
+	require.NoError(t, err)
+	require.Len(t, plan.Warnings, 2)
+	require.Equal(t, []Kind{AddLocation, AddLocation, ConfigureGroup, ConfigureDatabase, RemoveLocation}, kinds(plan))
+	require.Equal(t, "lhr", plan.Changes[4].Location)
+}
+
+func TestDiffRequiresKnownGroup(t *testing.T) {
+	m, err := Parse([]byte("databases:\n  db:\n    group: missing\n"))
+	require.NoError(t, err)
+	_, err = Diff(m, State{})
+	require.Error(t, err)
+}
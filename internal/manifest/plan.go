@@ -0,0 +1,202 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// State is the current state of the resources named in a manifest.
+type State struct {
+	Groups    map[string]GroupState
+	Databases map[string]DatabaseState
+}
+
+type GroupState struct {
+	Group  turso.Group
+	Config turso.GroupConfig
+}
+
+type DatabaseState struct {
+	Database turso.Database
+	Config   turso.DatabaseConfig
+}
+
+type Kind string
+
+const (
+	CreateGroup       Kind = "create_group"
+	AddLocation       Kind = "add_location"
+	RemoveLocation    Kind = "remove_location"
+	ConfigureGroup    Kind = "configure_group"
+	CreateDatabase    Kind = "create_database"
+	ConfigureDatabase Kind = "configure_database"
+)
+
+// Change is a single operation of a plan. Depending on its kind, it carries
+// the location to add or remove or to create a group or database at, the
+// database to create, or the configuration to update.
+type Change struct {
+	Kind        Kind
+	Name        string
+	Description string
+
+	Location       string
+	Database       *Database
+	GroupConfig    turso.GroupConfig
+	DatabaseConfig turso.DatabaseConfig
+}
+
+// Plan lists the changes in the order they must be applied. Warnings report
+// differences that can't be applied, such as moving a database to another
+// group.
+type Plan struct {
+	Changes  []Change
+	Warnings []string
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *Plan) warn(format string, args ...any) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Diff returns the changes that make state match the manifest.
+func Diff(m *Manifest, state State) (*Plan, error) {
+	plan := &Plan{}
+	var removals []Change
+
+	for _, name := range sortedKeys(m.Groups) {
+		spec := m.Groups[name]
+		current, exists := state.Groups[name]
+		if !exists {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:        CreateGroup,
+				Name:        name,
+				Description: fmt.Sprintf("create group %s at %s", name, spec.Primary),
+				Location:    spec.Primary,
+			})
+		} else if current.Group.Primary != spec.Primary {
+			plan.warn("group %s has primary location %s, not %s: the primary location of a group can't be changed", name, current.Group.Primary, spec.Primary)
+		}
+
+		for _, location := range spec.locations() {
+			if exists && contains(current.Group.Locations, location) || location == spec.Primary && !exists {
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{
+				Kind:        AddLocation,
+				Name:        name,
+				Description: fmt.Sprintf("add location %s to group %s", location, name),
+				Location:    location,
+			})
+		}
+		if exists && spec.Locations != nil {
+			for _, location := range current.Group.Locations {
+				if location == current.Group.Primary || contains(spec.locations(), location) {
+					continue
+				}
+				removals = append(removals, Change{
+					Kind:        RemoveLocation,
+					Name:        name,
+					Description: fmt.Sprintf("remove location %s from group %s", location, name),
+					Location:    location,
+				})
+			}
+		}
+
+		if spec.DeleteProtection != nil && *spec.DeleteProtection != current.Config.IsDeleteProtected() {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:        ConfigureGroup,
+				Name:        name,
+				Description: fmt.Sprintf("configure group %s: delete protection %s", name, internal.OnOffChange(current.Config.IsDeleteProtected(), *spec.DeleteProtection)),
+				GroupConfig: turso.GroupConfig{DeleteProtection: spec.DeleteProtection},
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(m.Databases) {
+		spec := m.Databases[name]
+		var location string
+		if group, ok := m.Groups[spec.Group]; ok {
+			location = group.Primary
+		} else if group, ok := state.Groups[spec.Group]; ok {
+			location = group.Group.Primary
+		} else {
+			return nil, fmt.Errorf("database %s: group %s is neither in the manifest nor in the organization", name, spec.Group)
+		}
+
+		current, exists := state.Databases[name]
+		if !exists {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:        CreateDatabase,
+				Name:        name,
+				Description: fmt.Sprintf("create database %s in group %s", name, spec.Group),
+				Location:    location,
+				Database:    spec,
+			})
+		} else {
+			if current.Database.Group != spec.Group {
+				plan.warn("database %s is in group %s, not %s: databases can't be moved to another group", name, current.Database.Group, spec.Group)
+			}
+			if spec.Encryption != nil && current.Database.EncryptionCipher != spec.Encryption.Cipher {
+				plan.warn("database %s is encrypted with %q, not %q: the encryption of a database can't be changed", name, current.Database.EncryptionCipher, spec.Encryption.Cipher)
+			}
+		}
+
+		if config, changes := diffDatabaseConfig(spec, current.Config); len(changes) > 0 {
+			plan.Changes = append(plan.Changes, Change{
+				Kind:           ConfigureDatabase,
+				Name:           name,
+				Description:    fmt.Sprintf("configure database %s: %s", name, strings.Join(changes, ", ")),
+				DatabaseConfig: config,
+			})
+		}
+	}
+
+	plan.Changes = append(plan.Changes, removals...)
+	return plan, nil
+}
+
+// diffDatabaseConfig returns the configuration to send to make current match
+// spec, with only the fields that differ set, and a description of them.
+func diffDatabaseConfig(spec *Database, current turso.DatabaseConfig) (turso.DatabaseConfig, []string) {
+	config := turso.DatabaseConfig{}
+	changes := []string{}
+	if spec.DeleteProtection != nil && *spec.DeleteProtection != current.IsDeleteProtected() {
+		config.DeleteProtection = spec.DeleteProtection
+		changes = append(changes, "delete protection "+internal.OnOffChange(current.IsDeleteProtected(), *spec.DeleteProtection))
+	}
+	allowAttach := current.AllowAttach != nil && *current.AllowAttach
+	if spec.AllowAttach != nil && *spec.AllowAttach != allowAttach {
+		config.AllowAttach = spec.AllowAttach
+		changes = append(changes, "attach "+internal.OnOffChange(allowAttach, *spec.AllowAttach))
+	}
+	if rules := spec.AllowRules; rules != nil {
+		if rules.IPs != nil && !internal.SameSet(*rules.IPs, current.AllowedIPList()) {
+			ips := append([]string{}, *rules.IPs...)
+			config.AllowedIPs = &ips
+			changes = append(changes, fmt.Sprintf("allowed IPs %s → %s", internal.ListOrNone(current.AllowedIPList()), internal.ListOrNone(ips)))
+		}
+		if rules.AwsVpcs != nil && !internal.SameSet(*rules.AwsVpcs, current.AllowedVpcIDList()) {
+			vpcs := append([]string{}, *rules.AwsVpcs...)
+			config.AllowedAwsVpcIDs = &vpcs
+			changes = append(changes, fmt.Sprintf("allowed AWS VPC endpoints %s → %s", internal.ListOrNone(current.AllowedVpcIDList()), internal.ListOrNone(vpcs)))
+		}
+	}
+	return config, changes
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
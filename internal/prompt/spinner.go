@@ -6,8 +6,18 @@ import (
 
 	spn "github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tursodatabase/turso-cli/internal/flags"
 )
 
+var interruptHandler = func() { os.Exit(130) }
+
+// OnInterrupt sets the function called when Ctrl-C is pressed while a spinner
+// is running. The spinner puts the terminal in raw mode, so the keypress does
+// not raise SIGINT. By default the process exits with status 130.
+func OnInterrupt(handler func()) {
+	interruptHandler = handler
+}
+
 type SpinnerT struct {
 	spinner   spn.Model
 	prefix    string
@@ -67,6 +77,9 @@ func (m *SpinnerT) Text(t string) {
 }
 
 func (m *SpinnerT) Start() {
+	if flags.StructuredOutput() {
+		return
+	}
 	if !isInteractive {
 		fmt.Println(m.View())
 		return
@@ -80,7 +93,7 @@ func (m *SpinnerT) Start() {
 		defer close(ch)
 		tea.NewProgram(m).Run()
 		if m.cancelled {
-			os.Exit(130)
+			interruptHandler()
 		}
 	}()
 }
@@ -0,0 +1,278 @@
+// Package s3 is a minimal client for S3-compatible object storage, enough to
+// upload objects in parts. Requests are signed with AWS Signature Version 4.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config is how to reach and authenticate to the object storage.
+type Config struct {
+	// Endpoint is the URL of an S3-compatible service, such as
+	// http://localhost:9000 for a local MinIO. When empty, AWS S3 is used.
+	// Buckets are addressed in the path of the URL with a custom endpoint,
+	// and in the host name with AWS.
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// ConfigFromEnv reads the configuration from the environment variables used by
+// the AWS CLI: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN,
+// AWS_REGION or AWS_DEFAULT_REGION, and AWS_ENDPOINT_URL_S3 or
+// AWS_ENDPOINT_URL.
+func ConfigFromEnv() Config {
+	return Config{
+		Endpoint:        firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"),
+		Region:          firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+const defaultRegion = "us-east-1"
+
+// Client sends requests to the object storage.
+type Client struct {
+	config     Config
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+// New returns a client for config, sending requests with httpClient.
+func New(config Config, httpClient *http.Client) (*Client, error) {
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("missing credentials, set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if config.Region == "" {
+		config.Region = defaultRegion
+	}
+	client := &Client{config: config, httpClient: httpClient}
+	if config.Endpoint != "" {
+		endpoint, err := url.Parse(config.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q, expected a URL such as https://s3.example.com", config.Endpoint)
+		}
+		client.endpoint = endpoint
+	}
+	return client, nil
+}
+
+// ParseURL splits an s3://bucket/prefix URL into its bucket and prefix. The
+// prefix, if any, ends with a slash.
+func ParseURL(s string) (bucket, prefix string, err error) {
+	rest, ok := strings.CutPrefix(s, "s3://")
+	if !ok {
+		return "", "", fmt.Errorf("invalid URL %q, expected s3://bucket/prefix", s)
+	}
+	bucket, prefix, _ = strings.Cut(rest, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("invalid URL %q, the bucket is missing", s)
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return bucket, prefix, nil
+}
+
+// Error is an error response of the object storage.
+type Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("object storage request failed with status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("object storage request failed with status code %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func parseError(statusCode int, body []byte) error {
+	e := &Error{StatusCode: statusCode}
+	_ = xml.Unmarshal(body, e)
+	return e
+}
+
+const (
+	maxAttempts      = 5
+	baseRetryDelay   = 500 * time.Millisecond
+	maxResponseBytes = 1 << 20
+)
+
+// objectURL returns the URL of key in bucket, and its escaped path.
+func (c *Client) objectURL(bucket, key string) (*url.URL, string) {
+	if c.endpoint == nil {
+		u := &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, c.config.Region)}
+		// Host names can't hold the dots of a bucket name with TLS
+		if strings.Contains(bucket, ".") {
+			u.Host = fmt.Sprintf("s3.%s.amazonaws.com", c.config.Region)
+			key = bucket + "/" + key
+		}
+		return u, "/" + uriEncode(key, false)
+	}
+	u := *c.endpoint
+	path := strings.TrimSuffix(u.EscapedPath(), "/") + "/" + uriEncode(bucket, true) + "/" + uriEncode(key, false)
+	return &u, path
+}
+
+// do sends a signed request for key in bucket and returns the response body.
+// Requests failing with a network error or a status code that may be
+// temporary are retried.
+func (c *Client) do(ctx context.Context, method, bucket, key string, query url.Values, body []byte) (http.Header, []byte, error) {
+	u, path := c.objectURL(bucket, key)
+	u.RawPath = path
+	u.Path, _ = url.PathUnescape(path)
+	u.RawQuery = query.Encode()
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		payloadHash = sha256Hex(body)
+	}
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(baseRetryDelay << (attempt - 1)):
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		}
+		var header http.Header
+		var responseBody []byte
+		var retry bool
+		header, responseBody, retry, err = c.send(ctx, method, u, path, payloadHash, body)
+		if err == nil || !retry {
+			return header, responseBody, err
+		}
+	}
+	return nil, nil, err
+}
+
+func (c *Client) send(ctx context.Context, method string, u *url.URL, path, payloadHash string, body []byte) (http.Header, []byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, path, payloadHash, time.Now())
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		var netErr net.Error
+		return nil, nil, ctx.Err() == nil && errors.As(err, &netErr), err
+	}
+	defer res.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBytes))
+	if err != nil {
+		return nil, nil, ctx.Err() == nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusRequestTimeout
+		return nil, nil, retry, parseError(res.StatusCode, responseBody)
+	}
+	return res.Header, responseBody, false, nil
+}
+
+// PutObject uploads data to key in bucket in a single request.
+func (c *Client) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	if _, _, err := c.do(ctx, http.MethodPut, bucket, key, nil, data); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// ObjectExists reports whether there is an object at key in bucket.
+func (c *Client) ObjectExists(ctx context.Context, bucket, key string) (bool, error) {
+	_, _, err := c.do(ctx, http.MethodHead, bucket, key, nil, nil)
+	var storageErr *Error
+	if errors.As(err, &storageErr) && storageErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (c *Client) createMultipartUpload(ctx context.Context, bucket, key string) (string, error) {
+	_, body, err := c.do(ctx, http.MethodPost, bucket, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to start multipart upload of %s: %w", key, err)
+	}
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("failed to start multipart upload of %s: invalid response", key)
+	}
+	return result.UploadID, nil
+}
+
+func (c *Client) uploadPart(ctx context.Context, bucket, key, uploadID string, partNumber int, data []byte) (string, error) {
+	query := url.Values{"partNumber": {fmt.Sprint(partNumber)}, "uploadId": {uploadID}}
+	header, _, err := c.do(ctx, http.MethodPut, bucket, key, query, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d of %s: %w", partNumber, key, err)
+	}
+	etag := header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("failed to upload part %d of %s: no ETag in response", partNumber, key)
+	}
+	return etag, nil
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (c *Client) completeMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []completedPart) error {
+	request, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	_, body, err := c.do(ctx, http.MethodPost, bucket, key, url.Values{"uploadId": {uploadID}}, request)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload of %s: %w", key, err)
+	}
+	// The request can fail after the response status was sent
+	if bytes.Contains(body, []byte("<Error>")) {
+		return fmt.Errorf("failed to complete multipart upload of %s: %w", key, parseError(http.StatusOK, body))
+	}
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
+	if _, _, err := c.do(ctx, http.MethodDelete, bucket, key, url.Values{"uploadId": {uploadID}}, nil); err != nil {
+		return fmt.Errorf("failed to abort multipart upload of %s: %w", key, err)
+	}
+	return nil
+}
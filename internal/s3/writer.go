@@ -0,0 +1,148 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinPartSize is the size of the first parts of a multipart upload. Every
+	// part but the last must be at least 5 MiB.
+	MinPartSize = 8 * 1024 * 1024
+	// partSizeGrowth is the number of parts after which the part size
+	// doubles, so that objects of unknown size fit in maxParts parts.
+	partSizeGrowth = 1000
+	maxParts       = 10000
+)
+
+// Writer uploads an object of unknown size as it is written, in parts of a
+// multipart upload. Objects smaller than a part are uploaded with a single
+// request on Close. Only one part is held in memory at once.
+type Writer struct {
+	ctx    context.Context
+	client *Client
+	bucket string
+	key    string
+
+	buf       []byte
+	uploadID  string
+	parts     []completedPart
+	size      int64
+	completed bool
+	err       error
+}
+
+// NewWriter returns a writer uploading to key in bucket. Nothing is uploaded
+// until the first part is written. Close must be called to complete the
+// upload, or Abort to cancel it.
+func (c *Client) NewWriter(ctx context.Context, bucket, key string) *Writer {
+	return &Writer{ctx: ctx, client: c, bucket: bucket, key: key}
+}
+
+// partSize returns the size of the part partNumber, counted from 1.
+func partSize(partNumber int) int {
+	return MinPartSize << ((partNumber - 1) / partSizeGrowth)
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	written := 0
+	for len(p) > 0 {
+		size := partSize(len(w.parts) + 1)
+		if w.buf == nil {
+			w.buf = make([]byte, 0, size)
+		}
+		n := min(len(p), size-len(w.buf))
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		w.size += int64(n)
+		if len(w.buf) == size {
+			if err := w.flush(); err != nil {
+				w.err = err
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// flush uploads the buffered data as the next part.
+func (w *Writer) flush() error {
+	if len(w.parts) == maxParts {
+		return fmt.Errorf("%s is too large for a multipart upload", w.key)
+	}
+	if w.uploadID == "" {
+		uploadID, err := w.client.createMultipartUpload(w.ctx, w.bucket, w.key)
+		if err != nil {
+			return err
+		}
+		w.uploadID = uploadID
+	}
+	partNumber := len(w.parts) + 1
+	etag, err := w.client.uploadPart(w.ctx, w.bucket, w.key, w.uploadID, partNumber, w.buf)
+	if err != nil {
+		return err
+	}
+	w.parts = append(w.parts, completedPart{PartNumber: partNumber, ETag: etag})
+	w.buf = w.buf[:0]
+	if cap(w.buf) < partSize(partNumber+1) {
+		w.buf = nil
+	}
+	return nil
+}
+
+// Size returns the number of bytes written.
+func (w *Writer) Size() int64 {
+	return w.size
+}
+
+// Close uploads what is left and completes the upload. The object only
+// exists once Close succeeds.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = errors.New("writer is closed")
+	if w.uploadID == "" {
+		if err := w.client.PutObject(w.ctx, w.bucket, w.key, w.buf); err != nil {
+			return err
+		}
+		w.completed = true
+		return nil
+	}
+	if len(w.buf) > 0 {
+		if err := w.flush(); err != nil {
+			_ = w.abort()
+			return err
+		}
+	}
+	if err := w.client.completeMultipartUpload(w.ctx, w.bucket, w.key, w.uploadID, w.parts); err != nil {
+		_ = w.abort()
+		return err
+	}
+	w.completed = true
+	return nil
+}
+
+// Abort cancels the upload, so that the uploaded parts are deleted and the
+// object is not created. It does nothing once Close succeeded.
+func (w *Writer) Abort() error {
+	if w.completed {
+		return nil
+	}
+	w.err = errors.New("writer is aborted")
+	if w.uploadID == "" {
+		return nil
+	}
+	return w.abort()
+}
+
+func (w *Writer) abort() error {
+	// Abort even if the upload was cancelled
+	ctx := context.WithoutCancel(w.ctx)
+	return w.client.abortMultipartUpload(ctx, w.bucket, w.key, w.uploadID)
+}
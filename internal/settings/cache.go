@@ -16,8 +16,10 @@ type Entry[T any] struct {
 
 var ErrExpired = errors.New("cache entry expired")
 
+// cacheKey returns the settings key of a cache entry. Each profile has its
+// own cache.
 func cacheKey(key string) string {
-	return "cache." + key
+	return settings.key("cache." + key)
 }
 
 func SetCacheRaw[T any](key string, value T) error {
@@ -33,9 +35,9 @@ func ClearCache() error {
 	if _, err := ReadSettings(); err != nil {
 		return err
 	}
-	viper.Set("cache", struct{}{})
+	viper.Set(settings.key("cache"), struct{}{})
 	settings.changed = true
-	return nil
+	return settings.InvalidateDatabaseTokens()
 }
 
 func SetCache[T any](key string, ttl int64, value T) error {
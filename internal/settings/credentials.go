@@ -0,0 +1,159 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CredentialStore keeps secrets, such as the platform token and database
+// tokens, out of the settings file. Keys are plain strings, so a store can be
+// backed by an OS keyring as well as by a file.
+type CredentialStore interface {
+	// Get returns the secret stored under key, or an empty string if there is
+	// none.
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+const (
+	tokenCredential          = "token"
+	databaseTokensCredential = "database_tokens"
+)
+
+// Credentials returns the store holding the secrets of every profile.
+func (s *Settings) Credentials() CredentialStore {
+	return s.credentials
+}
+
+func (s *Settings) SetToken(token string) error {
+	if token == "" {
+		return s.credentials.Delete(s.key(tokenCredential))
+	}
+	return s.credentials.Set(s.key(tokenCredential), token)
+}
+
+func (s *Settings) GetToken() (string, error) {
+	return s.credentials.Get(s.key(tokenCredential))
+}
+
+// databaseTokens returns the cached database tokens of the active profile,
+// keyed by database ID, without the expired ones.
+func (s *Settings) databaseTokens() (map[string]Entry[string], error) {
+	tokens := map[string]Entry[string]{}
+	value, err := s.credentials.Get(s.key(databaseTokensCredential))
+	if err != nil || value == "" {
+		return tokens, err
+	}
+	if err := json.Unmarshal([]byte(value), &tokens); err != nil {
+		return map[string]Entry[string]{}, nil
+	}
+	now := time.Now().Unix()
+	for id, entry := range tokens {
+		if entry.Expiration < now {
+			delete(tokens, id)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *Settings) SetDatabaseToken(dbID, token string, exp int64) error {
+	tokens, err := s.databaseTokens()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		delete(tokens, dbID)
+	} else {
+		tokens[dbID] = Entry[string]{Expiration: exp, Data: token}
+	}
+	if len(tokens) == 0 {
+		return s.InvalidateDatabaseTokens()
+	}
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	return s.credentials.Set(s.key(databaseTokensCredential), string(data))
+}
+
+func (s *Settings) DatabaseToken(dbID string) (string, error) {
+	tokens, err := s.databaseTokens()
+	if err != nil {
+		return "", err
+	}
+	return tokens[dbID].Data, nil
+}
+
+func (s *Settings) InvalidateDatabaseTokens() error {
+	return s.credentials.Delete(s.key(databaseTokensCredential))
+}
+
+// deleteProfileCredentials removes the secrets of a profile from the store.
+func (s *Settings) deleteProfileCredentials(profile string) error {
+	for _, name := range []string{tokenCredential, databaseTokensCredential} {
+		if err := s.credentials.Delete(profileKey(profile, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCredentials moves the platform tokens and cached database tokens
+// that older versions kept in plaintext in the settings file to the
+// credential store.
+func (s *Settings) migrateCredentials() error {
+	profiles := []string{DefaultProfile}
+	for name := range viper.GetStringMap("profiles") {
+		profiles = append(profiles, name)
+	}
+
+	migrated := false
+	for _, profile := range profiles {
+		if token := viper.GetString(profileKey(profile, "token")); token != "" {
+			if err := s.credentials.Set(profileKey(profile, tokenCredential), token); err != nil {
+				return err
+			}
+			viper.Set(profileKey(profile, "token"), "")
+			migrated = true
+		}
+
+		cacheKey := profileKey(profile, "cache.database_token")
+		cached := viper.GetStringMap(cacheKey)
+		if len(cached) == 0 {
+			continue
+		}
+		tokens := map[string]Entry[string]{}
+		now := time.Now().Unix()
+		for id := range cached {
+			var entry Entry[string]
+			if err := viper.UnmarshalKey(cacheKey+"."+id, &entry); err == nil && entry.Data != "" && entry.Expiration >= now {
+				tokens[id] = entry
+			}
+		}
+		if len(tokens) > 0 {
+			data, err := json.Marshal(tokens)
+			if err != nil {
+				return err
+			}
+			if err := s.credentials.Set(profileKey(profile, databaseTokensCredential), string(data)); err != nil {
+				return err
+			}
+		}
+		viper.Set(cacheKey, struct{}{})
+		migrated = true
+	}
+
+	if !migrated {
+		return nil
+	}
+	if err := TryToPersistChanges(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Moved the credentials in %s to the encrypted credential store.\n", Path())
+	return nil
+}
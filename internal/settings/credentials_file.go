@@ -0,0 +1,276 @@
+package settings
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	credentialsFileName = "credentials.enc"
+	credentialsKeyName  = "credentials.key"
+
+	kdfScrypt = "scrypt"
+	kdfHKDF   = "hkdf"
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// encryptedFile is the format of the credentials file. The secrets are a
+// JSON object encrypted with AES-256-GCM, with a key derived from a
+// passphrase with scrypt or from a key file with HKDF.
+type encryptedFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// credentialKey is the secret the encryption key is derived from.
+type credentialKey struct {
+	kdf    string
+	secret []byte
+	source string
+}
+
+// fileStore is a CredentialStore encrypting every secret in a single file,
+// so it works on headless machines without a keyring.
+type fileStore struct {
+	path    string
+	keyFunc func() (credentialKey, error)
+
+	mu      sync.Mutex
+	loaded  bool
+	key     credentialKey
+	salt    []byte
+	secrets map[string]string
+}
+
+func newFileStore(configPath string) *fileStore {
+	return &fileStore{
+		path: filepath.Join(configPath, credentialsFileName),
+		keyFunc: func() (credentialKey, error) {
+			return readCredentialKey(configPath)
+		},
+	}
+}
+
+// readCredentialKey returns the passphrase in TURSO_CREDENTIALS_PASSPHRASE or
+// else the key file in TURSO_CREDENTIALS_KEY_FILE. Without either, a key file
+// is created in the config directory.
+func readCredentialKey(configPath string) (credentialKey, error) {
+	if passphrase := os.Getenv("TURSO_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		return credentialKey{kdf: kdfScrypt, secret: []byte(passphrase), source: "TURSO_CREDENTIALS_PASSPHRASE"}, nil
+	}
+	if path := os.Getenv("TURSO_CREDENTIALS_KEY_FILE"); path != "" {
+		secret, err := os.ReadFile(path)
+		if err != nil {
+			return credentialKey{}, fmt.Errorf("could not read credentials key file: %w", err)
+		}
+		if len(strings.TrimSpace(string(secret))) == 0 {
+			return credentialKey{}, fmt.Errorf("credentials key file %s is empty", path)
+		}
+		return credentialKey{kdf: kdfHKDF, secret: secret, source: path}, nil
+	}
+
+	path := filepath.Join(configPath, credentialsKeyName)
+	secret, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return credentialKey{}, err
+		}
+		if err := writeFileAtomic(path, secret); err != nil {
+			return credentialKey{}, fmt.Errorf("could not create credentials key file: %w", err)
+		}
+	} else if err != nil {
+		return credentialKey{}, fmt.Errorf("could not read credentials key file: %w", err)
+	}
+	return credentialKey{kdf: kdfHKDF, secret: secret, source: path}, nil
+}
+
+func deriveKey(key credentialKey, salt []byte) ([]byte, error) {
+	switch key.kdf {
+	case kdfScrypt:
+		return scrypt.Key(key.secret, salt, scryptN, scryptR, scryptP, 32)
+	case kdfHKDF:
+		derived := make([]byte, 32)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, key.secret, salt, []byte("turso credentials")), derived); err != nil {
+			return nil, err
+		}
+		return derived, nil
+	default:
+		return nil, fmt.Errorf("unknown key derivation %s", key.kdf)
+	}
+}
+
+func newAEAD(key credentialKey, salt []byte) (cipher.AEAD, error) {
+	derived, err := deriveKey(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// load reads and decrypts the credentials file the first time it is needed,
+// so commands that use no secrets don't need the key.
+func (f *fileStore) load() error {
+	if f.loaded {
+		return nil
+	}
+	key, err := f.keyFunc()
+	if err != nil {
+		return err
+	}
+
+	secrets := map[string]string{}
+	data, err := os.ReadFile(f.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		f.salt = salt
+	case err != nil:
+		return fmt.Errorf("could not read credentials file: %w", err)
+	default:
+		var file encryptedFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("could not parse credentials file %s: %w", f.path, err)
+		}
+		if file.KDF != key.kdf {
+			if file.KDF == kdfScrypt {
+				return fmt.Errorf("credentials in %s are encrypted with a passphrase: set TURSO_CREDENTIALS_PASSPHRASE", f.path)
+			}
+			return fmt.Errorf("credentials in %s are encrypted with a key file: unset TURSO_CREDENTIALS_PASSPHRASE", f.path)
+		}
+		aead, err := newAEAD(key, file.Salt)
+		if err != nil {
+			return err
+		}
+		plaintext, err := aead.Open(nil, file.Nonce, file.Data, nil)
+		if err != nil {
+			return fmt.Errorf("could not decrypt credentials in %s: wrong passphrase or key file %s", f.path, key.source)
+		}
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return fmt.Errorf("could not parse credentials in %s: %w", f.path, err)
+		}
+		f.salt = file.Salt
+	}
+
+	f.key = key
+	f.secrets = secrets
+	f.loaded = true
+	return nil
+}
+
+func (f *fileStore) save() error {
+	plaintext, err := json.Marshal(f.secrets)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(f.key, f.salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data, err := json.Marshal(encryptedFile{
+		Version: 1,
+		KDF:     f.key.kdf,
+		Salt:    f.salt,
+		Nonce:   nonce,
+		Data:    aead.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(f.path, data); err != nil {
+		return fmt.Errorf("could not write credentials file: %w", err)
+	}
+	return nil
+}
+
+func (f *fileStore) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := os.Stat(f.path); !f.loaded && errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err := f.load(); err != nil {
+		return "", err
+	}
+	return f.secrets[key], nil
+}
+
+func (f *fileStore) Set(key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return err
+	}
+	if f.secrets[key] == value {
+		return nil
+	}
+	f.secrets[key] = value
+	return f.save()
+}
+
+func (f *fileStore) Delete(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := os.Stat(f.path); !f.loaded && errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err := f.load(); err != nil {
+		return err
+	}
+	if _, ok := f.secrets[key]; !ok {
+		return nil
+	}
+	delete(f.secrets, key)
+	return f.save()
+}
+
+// writeFileAtomic replaces path with data, readable only by the user.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(settingsFileMode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,149 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStoreEncryptsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "")
+	t.Setenv("TURSO_CREDENTIALS_KEY_FILE", "")
+
+	store := newFileStore(dir)
+	if got, err := store.Get("token"); err != nil || got != "" {
+		t.Fatalf("Get on a missing store = %q, %v", got, err)
+	}
+	if err := store.Set("token", "secret-token"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, credentialsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("credentials file contains the secret in plaintext")
+	}
+	for _, name := range []string{credentialsFileName, credentialsKeyName} {
+		st, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := st.Mode().Perm(); got != 0o600 {
+			t.Errorf("%s mode = %o, want 600", name, got)
+		}
+	}
+
+	reopened := newFileStore(dir)
+	if got, err := reopened.Get("token"); err != nil || got != "secret-token" {
+		t.Errorf("Get after reopening = %q, %v", got, err)
+	}
+	if err := reopened.Delete("token"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, err := newFileStore(dir).Get("token"); err != nil || got != "" {
+		t.Errorf("Get after Delete = %q, %v", got, err)
+	}
+}
+
+func TestFileStorePassphrase(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TURSO_CREDENTIALS_KEY_FILE", "")
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "correct horse")
+
+	if err := newFileStore(dir).Set("token", "secret-token"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, err := newFileStore(dir).Get("token"); err != nil || got != "secret-token" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "wrong horse")
+	if _, err := newFileStore(dir).Get("token"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Get with a wrong passphrase = %v", err)
+	}
+
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "")
+	if _, err := newFileStore(dir).Get("token"); err == nil || !strings.Contains(err.Error(), "TURSO_CREDENTIALS_PASSPHRASE") {
+		t.Errorf("Get without the passphrase = %v", err)
+	}
+}
+
+func TestMigrateCredentialsFromSettingsFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "")
+	t.Setenv("TURSO_CREDENTIALS_KEY_FILE", "")
+
+	exp := time.Now().Add(time.Hour).Unix()
+	legacy := `{
+		"token": "personal-token",
+		"username": "alice",
+		"cache": {"database_token": {"db-id": {"expiration": ` + strconv.FormatInt(exp, 10) + `, "data": "db-token"}}},
+		"profiles": {"work": {"token": "work-token"}}
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "settings.json"), []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := readTestSettingsFrom(t, dir)
+	if got, err := s.GetToken(); err != nil || got != "personal-token" {
+		t.Errorf("migrated token = %q, %v", got, err)
+	}
+	if got, err := s.DatabaseToken("db-id"); err != nil || got != "db-token" {
+		t.Errorf("migrated database token = %q, %v", got, err)
+	}
+	if got, err := s.Credentials().Get(profileKey("work", tokenCredential)); err != nil || got != "work-token" {
+		t.Errorf("migrated work token = %q, %v", got, err)
+	}
+	if got := s.GetUsername(); got != "alice" {
+		t.Errorf("username = %q, want alice", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"personal-token", "work-token", "db-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("settings file still contains %s after the migration", secret)
+		}
+	}
+
+	if err := s.DeleteProfile("work"); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	if got, err := s.Credentials().Get(profileKey("work", tokenCredential)); err != nil || got != "" {
+		t.Errorf("work token after deleting the profile = %q, %v", got, err)
+	}
+}
+
+func TestDatabaseTokensExpire(t *testing.T) {
+	t.Setenv("TURSO_CREDENTIALS_PASSPHRASE", "")
+	t.Setenv("TURSO_CREDENTIALS_KEY_FILE", "")
+	s := readTestSettings(t)
+
+	if err := s.SetDatabaseToken("fresh", "fresh-token", time.Now().Add(time.Hour).Unix()); err != nil {
+		t.Fatalf("SetDatabaseToken: %v", err)
+	}
+	if err := s.SetDatabaseToken("stale", "stale-token", time.Now().Add(-time.Hour).Unix()); err != nil {
+		t.Fatalf("SetDatabaseToken: %v", err)
+	}
+	if got, _ := s.DatabaseToken("fresh"); got != "fresh-token" {
+		t.Errorf("fresh token = %q", got)
+	}
+	if got, _ := s.DatabaseToken("stale"); got != "" {
+		t.Errorf("expired token = %q, want none", got)
+	}
+
+	if err := s.InvalidateDatabaseTokens(); err != nil {
+		t.Fatalf("InvalidateDatabaseTokens: %v", err)
+	}
+	if got, _ := s.DatabaseToken("fresh"); got != "" {
+		t.Errorf("token after invalidation = %q, want none", got)
+	}
+}
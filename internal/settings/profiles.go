@@ -0,0 +1,131 @@
+package settings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/viper"
+	"github.com/tursodatabase/turso-cli/internal"
+	"github.com/tursodatabase/turso-cli/internal/flags"
+)
+
+// DefaultProfile is the profile used when none is selected. Its values are
+// stored at the top level of the settings file, where they were kept before
+// profiles existed.
+const DefaultProfile = "default"
+
+// Profile names are lowercase because viper keys are case insensitive.
+var profileNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type Profile struct {
+	Name         string
+	Username     string
+	Organization string
+	BaseURL      string
+}
+
+// Profile returns the name of the active profile: the one given with
+// --profile or TURSO_PROFILE, or else the one selected with SetProfile.
+func (s *Settings) Profile() string {
+	if profile := flags.Profile(); profile != "" {
+		return profile
+	}
+	if profile := viper.GetString("profile"); profile != "" {
+		return profile
+	}
+	return DefaultProfile
+}
+
+// key returns the settings key holding the value of name for the active
+// profile.
+func (s *Settings) key(name string) string {
+	return profileKey(s.Profile(), name)
+}
+
+func profileKey(profile, name string) string {
+	if profile == DefaultProfile {
+		return name
+	}
+	return "profiles." + profile + "." + name
+}
+
+func (s *Settings) ProfileExists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	_, ok := viper.GetStringMap("profiles")[name]
+	return ok
+}
+
+func (s *Settings) CreateProfile(name, baseURL string) error {
+	if !profileNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid profile name %s: use lowercase letters, digits, dashes and underscores", internal.Emph(name))
+	}
+	if s.ProfileExists(name) {
+		return fmt.Errorf("profile %s already exists", internal.Emph(name))
+	}
+	profile := map[string]interface{}{}
+	if baseURL != "" {
+		profile["baseURL"] = baseURL
+	}
+	viper.Set("profiles."+name, profile)
+	s.changed = true
+	return nil
+}
+
+// SetProfile makes name the active profile for the next commands.
+func (s *Settings) SetProfile(name string) error {
+	if !s.ProfileExists(name) {
+		return fmt.Errorf("profile %s does not exist", internal.Emph(name))
+	}
+	if name == DefaultProfile {
+		name = ""
+	}
+	viper.Set("profile", name)
+	s.changed = true
+	return nil
+}
+
+// DeleteProfile removes a profile with its credentials and caches. If it was
+// the active profile, the default profile becomes active.
+func (s *Settings) DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile can't be deleted", internal.Emph(DefaultProfile))
+	}
+	if !s.ProfileExists(name) {
+		return fmt.Errorf("profile %s does not exist", internal.Emph(name))
+	}
+	if err := s.deleteProfileCredentials(name); err != nil {
+		return fmt.Errorf("could not delete the credentials of profile %s: %w", internal.Emph(name), err)
+	}
+	profiles := viper.GetStringMap("profiles")
+	delete(profiles, name)
+	viper.Set("profiles", profiles)
+	if viper.GetString("profile") == name {
+		viper.Set("profile", "")
+	}
+	s.changed = true
+	return nil
+}
+
+// Profiles lists every profile, the default one first.
+func (s *Settings) Profiles() []Profile {
+	names := []string{}
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	names = append([]string{DefaultProfile}, names...)
+
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, Profile{
+			Name:         name,
+			Username:     viper.GetString(profileKey(name, "username")),
+			Organization: viper.GetString(profileKey(name, "organization")),
+			BaseURL:      viper.GetString(profileKey(name, "baseURL")),
+		})
+	}
+	return profiles
+}
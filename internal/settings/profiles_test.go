@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// readTestSettings reads fresh settings from a temporary config folder and
+// forgets them when the test ends, so tests don't share the singleton.
+func readTestSettings(t *testing.T) *Settings {
+	t.Helper()
+	return readTestSettingsFrom(t, t.TempDir())
+}
+
+func readTestSettingsFrom(t *testing.T, dir string) *Settings {
+	t.Helper()
+	t.Setenv("TURSO_CONFIG_FOLDER", dir)
+	reset := func() {
+		settings = nil
+		viper.Reset()
+	}
+	reset()
+	t.Cleanup(reset)
+
+	s, err := ReadSettings()
+	if err != nil {
+		t.Fatalf("ReadSettings: %v", err)
+	}
+	return s
+}
+
+func TestProfilesIsolateCredentialsAndCaches(t *testing.T) {
+	t.Setenv("TURSO_API_BASEURL", "")
+	s := readTestSettings(t)
+
+	if err := s.SetToken("personal-token"); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+	s.SetOrganization("")
+	if err := SetCache("key", 60, "personal"); err != nil {
+		t.Fatalf("SetCache: %v", err)
+	}
+
+	if err := s.CreateProfile("work", "https://api.staging.example.com"); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	if err := s.CreateProfile("work", ""); err == nil {
+		t.Errorf("creating an existing profile should fail")
+	}
+	if err := s.SetProfile("work"); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	if got, err := s.GetToken(); err != nil || got != "" {
+		t.Errorf("new profile token = %q, %v, want empty", got, err)
+	}
+	if got := s.GetBaseURL(); got != "https://api.staging.example.com" {
+		t.Errorf("base URL = %q", got)
+	}
+	if _, err := GetCache[string]("key"); err == nil {
+		t.Errorf("cache of the default profile leaked into the work profile")
+	}
+	if err := s.SetToken("work-token"); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+	s.SetOrganization("acme")
+
+	if err := s.SetProfile(DefaultProfile); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	if got, err := s.GetToken(); err != nil || got != "personal-token" {
+		t.Errorf("default profile token = %q, %v, want personal-token", got, err)
+	}
+	if got := s.Organization(); got != "" {
+		t.Errorf("default profile organization = %q, want empty", got)
+	}
+	if got, err := GetCache[string]("key"); err != nil || got != "personal" {
+		t.Errorf("default profile cache = %q, %v", got, err)
+	}
+
+	profiles := s.Profiles()
+	if len(profiles) != 2 || profiles[0].Name != DefaultProfile || profiles[1].Organization != "acme" {
+		t.Errorf("unexpected profiles %+v", profiles)
+	}
+
+	if err := s.DeleteProfile(DefaultProfile); err == nil {
+		t.Errorf("deleting the default profile should fail")
+	}
+	if err := s.DeleteProfile("work"); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	if s.ProfileExists("work") {
+		t.Errorf("work profile still exists after deletion")
+	}
+}
+
+func TestCreateProfileRejectsInvalidNames(t *testing.T) {
+	s := readTestSettings(t)
+	for _, name := range []string{"", "Work", "a.b", "-x"} {
+		if err := s.CreateProfile(name, ""); err == nil {
+			t.Errorf("CreateProfile(%q) should fail", name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the name of the file pinning the context of a project.
+// It is looked up from the working directory up to the filesystem root.
+const ProjectFileName = ".turso.yaml"
+
+// Project is the context pinned by a project file. Its values apply when the
+// corresponding flag is not given, and its organization takes precedence
+// over the one selected with 'turso org switch'.
+type Project struct {
+	Path         string `yaml:"-"`
+	Organization string `yaml:"organization"`
+	Group        string `yaml:"group"`
+	Location     string `yaml:"location"`
+	Database     string `yaml:"database"`
+}
+
+// FindProject returns the project file closest to dir, or nil if there is
+// none in dir or its parents.
+func FindProject(dir string) (*Project, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return parseProject(path, data)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("could not read project file %s: %w", path, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func parseProject(path string, data []byte) (*Project, error) {
+	project := &Project{Path: path}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(project); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid project file %s: %w", path, err)
+	}
+	return project, nil
+}
+
+var currentProject = sync.OnceValues(func() (*Project, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	return FindProject(wd)
+})
+
+// CurrentProject returns the project file found from the working directory,
+// or nil if there is none.
+func CurrentProject() (*Project, error) {
+	return currentProject()
+}
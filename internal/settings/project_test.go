@@ -0,0 +1,55 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectWalksUpFromDirectory(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api", "src")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, "services", ProjectFileName)
+	content := "organization: acme\ngroup: eu\nlocation: fra\ndatabase: api-db\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := FindProject(nested)
+	if err != nil {
+		t.Fatalf("FindProject: %v", err)
+	}
+	want := Project{Path: file, Organization: "acme", Group: "eu", Location: "fra", Database: "api-db"}
+	if project == nil || *project != want {
+		t.Fatalf("FindProject = %+v, want %+v", project, want)
+	}
+
+	project, err = FindProject(root)
+	if err != nil || project != nil {
+		t.Errorf("FindProject above the project file = %+v, %v, want none", project, err)
+	}
+}
+
+func TestFindProjectRejectsUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectFileName), []byte("databse: typo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindProject(dir); err == nil {
+		t.Errorf("FindProject should fail on unknown fields")
+	}
+}
+
+func TestFindProjectAcceptsEmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectFileName), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project, err := FindProject(dir)
+	if err != nil || project == nil || project.Database != "" {
+		t.Errorf("FindProject = %+v, %v", project, err)
+	}
+}
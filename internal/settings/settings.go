@@ -19,7 +19,8 @@ const (
 )
 
 type Settings struct {
-	changed bool
+	changed     bool
+	credentials CredentialStore
 }
 
 var (
@@ -83,6 +84,11 @@ func ReadSettings() (*Settings, error) {
 		}
 	}
 
+	settings.credentials = newFileStore(configPath)
+	if err := settings.migrateCredentials(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: could not move credentials out of %s: %v\n", internal.Warn("Warning"), internal.Emph(configFile), err)
+	}
+
 	return settings, nil
 }
 
@@ -123,34 +129,39 @@ func (s *Settings) RegisterUse(cmd string) bool {
 }
 
 func (s *Settings) SetOrganization(org string) {
-	viper.Set("organization", org)
+	viper.Set(s.key("organization"), org)
 	s.changed = true
 }
 
+// Organization returns the organization pinned by the project file, if any,
+// or else the one selected with 'turso org switch'.
 func (s *Settings) Organization() string {
-	return viper.GetString("organization")
-}
-
-func (s *Settings) SetToken(token string) {
-	viper.Set("token", token)
-	s.changed = true
+	if project, _ := CurrentProject(); project != nil && project.Organization != "" {
+		return project.Organization
+	}
+	return s.SelectedOrganization()
 }
 
-func (s *Settings) GetToken() string {
-	return viper.GetString("token")
+// SelectedOrganization returns the organization selected with
+// 'turso org switch', ignoring the project file.
+func (s *Settings) SelectedOrganization() string {
+	return viper.GetString(s.key("organization"))
 }
 
 func (s *Settings) SetUsername(username string) {
-	viper.Set("username", username)
+	viper.Set(s.key("username"), username)
 	s.changed = true
 }
 
 func (s *Settings) GetUsername() string {
-	return viper.GetString("username")
+	return viper.GetString(s.key("username"))
 }
 
 func (s *Settings) GetBaseURL() string {
-	return viper.GetString("baseURL")
+	if url := os.Getenv("TURSO_API_BASEURL"); url != "" {
+		return url
+	}
+	return viper.GetString(s.key("baseURL"))
 }
 
 func (s *Settings) SetAutoupdate(autoupdate string) {
@@ -201,3 +212,50 @@ func (s *Settings) GetAutoupdate() string {
 	value := config["autoupdate"]
 	return value.(string)
 }
+
+// setConfigValue sets a configuration value of the active profile. An empty
+// value removes it.
+func (s *Settings) setConfigValue(key, value string) {
+	config := viper.GetStringMap(s.key("config"))
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	if value == "" {
+		delete(config, key)
+	} else {
+		config[key] = value
+	}
+	viper.Set(s.key("config"), config)
+	s.changed = true
+}
+
+func (s *Settings) getConfigString(key string) string {
+	config := viper.GetStringMap(s.key("config"))
+	value, _ := config[key].(string)
+	return value
+}
+
+func (s *Settings) SetCABundle(path string) {
+	s.setConfigValue("ca_bundle", path)
+}
+
+func (s *Settings) GetCABundle() string {
+	return s.getConfigString("ca_bundle")
+}
+
+func (s *Settings) SetClientCertificate(cert, key string) {
+	s.setConfigValue("client_cert", cert)
+	s.setConfigValue("client_key", key)
+}
+
+func (s *Settings) GetClientCertificate() (cert, key string) {
+	return s.getConfigString("client_cert"), s.getConfigString("client_key")
+}
+
+func (s *Settings) SetProxy(proxy string) {
+	s.setConfigValue("proxy", proxy)
+}
+
+func (s *Settings) GetProxy() string {
+	return s.getConfigString("proxy")
+}
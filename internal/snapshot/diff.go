@@ -0,0 +1,139 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tursodatabase/turso-cli/internal"
+)
+
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Drift is a difference between a snapshot and the current state of the
+// organization.
+type Drift struct {
+	Kind        Kind
+	Resource    string
+	Name        string
+	Description string
+}
+
+// Diff returns how live drifted from saved, groups first, then databases,
+// members and API tokens, each sorted by name.
+func Diff(saved, live *Snapshot) []Drift {
+	drifts := []Drift{}
+	drifts = append(drifts, diffResources("group", saved.Groups, live.Groups, func(g Group) string { return g.Name }, diffGroup)...)
+	drifts = append(drifts, diffResources("database", saved.Databases, live.Databases, func(d Database) string { return d.Name }, diffDatabase)...)
+	drifts = append(drifts, diffResources("member", saved.Members, live.Members, func(m Member) string { return m.Name }, diffMember)...)
+	drifts = append(drifts, diffResources("API token", saved.APITokens, live.APITokens, func(name string) string { return name }, nil)...)
+	return drifts
+}
+
+func diffResources[T any](resource string, saved, live []T, name func(T) string, diff func(a, b T) []string) []Drift {
+	before := map[string]T{}
+	for _, r := range saved {
+		before[name(r)] = r
+	}
+	after := map[string]T{}
+	for _, r := range live {
+		after[name(r)] = r
+	}
+
+	names := []string{}
+	for n := range before {
+		names = append(names, n)
+	}
+	for n := range after {
+		if _, ok := before[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	drifts := []Drift{}
+	for _, n := range names {
+		a, inSaved := before[n]
+		b, inLive := after[n]
+		switch {
+		case !inSaved:
+			drifts = append(drifts, Drift{Kind: Added, Resource: resource, Name: n, Description: fmt.Sprintf("%s %s was added", resource, n)})
+		case !inLive:
+			drifts = append(drifts, Drift{Kind: Removed, Resource: resource, Name: n, Description: fmt.Sprintf("%s %s was removed", resource, n)})
+		case diff != nil:
+			if changes := diff(a, b); len(changes) > 0 {
+				drifts = append(drifts, Drift{Kind: Changed, Resource: resource, Name: n, Description: fmt.Sprintf("%s %s: %s", resource, n, strings.Join(changes, ", "))})
+			}
+		}
+	}
+	return drifts
+}
+
+func diffGroup(a, b Group) []string {
+	changes := []string{}
+	if a.Primary != b.Primary {
+		changes = append(changes, fmt.Sprintf("primary location %s → %s", a.Primary, b.Primary))
+	}
+	if !internal.SameSet(a.Locations, b.Locations) {
+		changes = append(changes, fmt.Sprintf("locations %s → %s", internal.ListOrNone(a.Locations), internal.ListOrNone(b.Locations)))
+	}
+	if a.Archived != b.Archived {
+		changes = append(changes, "archived "+internal.OnOffChange(a.Archived, b.Archived))
+	}
+	if a.Config.IsDeleteProtected() != b.Config.IsDeleteProtected() {
+		changes = append(changes, "delete protection "+internal.OnOffChange(a.Config.IsDeleteProtected(), b.Config.IsDeleteProtected()))
+	}
+	return changes
+}
+
+func diffDatabase(a, b Database) []string {
+	changes := []string{}
+	if a.ID != b.ID {
+		changes = append(changes, fmt.Sprintf("recreated with ID %s, was %s", b.ID, a.ID))
+	}
+	if a.Group != b.Group {
+		changes = append(changes, fmt.Sprintf("group %s → %s", a.Group, b.Group))
+	}
+	if a.IsSchema != b.IsSchema {
+		changes = append(changes, "schema database "+internal.OnOffChange(a.IsSchema, b.IsSchema))
+	}
+	if a.Schema != b.Schema {
+		changes = append(changes, fmt.Sprintf("schema %s → %s", orNone(a.Schema), orNone(b.Schema)))
+	}
+	if a.EncryptionCipher != b.EncryptionCipher {
+		changes = append(changes, fmt.Sprintf("encryption %s → %s", orNone(a.EncryptionCipher), orNone(b.EncryptionCipher)))
+	}
+	if a.Config.IsDeleteProtected() != b.Config.IsDeleteProtected() {
+		changes = append(changes, "delete protection "+internal.OnOffChange(a.Config.IsDeleteProtected(), b.Config.IsDeleteProtected()))
+	}
+	if a.Config.AttachAllowed() != b.Config.AttachAllowed() {
+		changes = append(changes, "attach "+internal.OnOffChange(a.Config.AttachAllowed(), b.Config.AttachAllowed()))
+	}
+	if !internal.SameSet(a.Config.AllowedIPList(), b.Config.AllowedIPList()) {
+		changes = append(changes, fmt.Sprintf("allowed IPs %s → %s", internal.ListOrNone(a.Config.AllowedIPList()), internal.ListOrNone(b.Config.AllowedIPList())))
+	}
+	if !internal.SameSet(a.Config.AllowedVpcIDList(), b.Config.AllowedVpcIDList()) {
+		changes = append(changes, fmt.Sprintf("allowed AWS VPC endpoints %s → %s", internal.ListOrNone(a.Config.AllowedVpcIDList()), internal.ListOrNone(b.Config.AllowedVpcIDList())))
+	}
+	return changes
+}
+
+func diffMember(a, b Member) []string {
+	if a.Role != b.Role {
+		return []string{fmt.Sprintf("role %s → %s", a.Role, b.Role)}
+	}
+	return nil
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+// Package snapshot records the groups, databases, members and API tokens of
+// an organization in a JSON document, and reports how an organization drifted
+// from such a snapshot.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+// Version is the version of the snapshot document format.
+const Version = 1
+
+// Snapshot is the state of an organization at a point in time. Only settings
+// that are changed by users are recorded, so that a snapshot of an unchanged
+// organization is identical to the previous one, apart from TakenAt.
+type Snapshot struct {
+	Version      int        `json:"version"`
+	Organization string     `json:"organization"`
+	TakenAt      time.Time  `json:"taken_at"`
+	Groups       []Group    `json:"groups"`
+	Databases    []Database `json:"databases"`
+	Members      []Member   `json:"members"`
+	// APITokens are the names of the API tokens of the user who took the
+	// snapshot. Tokens themselves are never recorded.
+	APITokens []string `json:"api_tokens"`
+}
+
+type Group struct {
+	Name      string            `json:"name"`
+	Primary   string            `json:"primary"`
+	Locations []string          `json:"locations"`
+	Archived  bool              `json:"archived"`
+	Config    turso.GroupConfig `json:"config"`
+}
+
+type Database struct {
+	Name             string               `json:"name"`
+	ID               string               `json:"id"`
+	Group            string               `json:"group"`
+	IsSchema         bool                 `json:"is_schema"`
+	Schema           string               `json:"schema"`
+	EncryptionCipher string               `json:"encryption_cipher"`
+	Config           turso.DatabaseConfig `json:"config"`
+}
+
+type Member struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// Sort orders every list of the snapshot, so that snapshots of the same state
+// are written identically.
+func (s *Snapshot) Sort() {
+	sort.Slice(s.Groups, func(i, j int) bool { return s.Groups[i].Name < s.Groups[j].Name })
+	sort.Slice(s.Databases, func(i, j int) bool { return s.Databases[i].Name < s.Databases[j].Name })
+	sort.Slice(s.Members, func(i, j int) bool { return s.Members[i].Name < s.Members[j].Name })
+	sort.Strings(s.APITokens)
+	for i := range s.Groups {
+		sort.Strings(s.Groups[i].Locations)
+	}
+}
+
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot: %w", err)
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("snapshot %s has version %d, but only version %d is supported", path, s.Version, Version)
+	}
+	return s, nil
+}
+
+func (s *Snapshot) Save(path string) error {
+	s.Sort()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("could not write snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+func testSnapshot() *Snapshot {
+	on := true
+	ips := []string{"10.0.0.0/8"}
+	return &Snapshot{
+		Version:      Version,
+		Organization: "acme",
+		TakenAt:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Groups: []Group{
+			{Name: "eu", Primary: "fra", Locations: []string{"ams", "fra"}, Config: turso.GroupConfig{DeleteProtection: &on}},
+		},
+		Databases: []Database{
+			{Name: "orders", ID: "1", Group: "eu", Config: turso.DatabaseConfig{AllowedIPs: &ips}},
+			{Name: "events", ID: "2", Group: "eu"},
+		},
+		Members:   []Member{{Name: "alice", Role: "owner"}, {Name: "bob", Role: "member"}},
+		APITokens: []string{"ci"},
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	saved := testSnapshot()
+	require.NoError(t, saved.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, saved, loaded)
+	require.Equal(t, "events", loaded.Databases[0].Name)
+	require.Equal(t, []string{"ams", "fra"}, loaded.Groups[0].Locations)
+	require.Empty(t, Diff(saved, loaded))
+}
+
+func TestDiffReportsDrift(t *testing.T) {
+	saved := testSnapshot()
+	live := testSnapshot()
+	live.Groups[0].Locations = []string{"fra"}
+	live.Groups[0].Config = turso.GroupConfig{}
+	live.Databases = live.Databases[:1]
+	live.Databases[0].Config = turso.DatabaseConfig{}
+	live.Members[1].Role = "admin"
+	live.APITokens = []string{"ci", "deploy"}
+	live.Groups = append(live.Groups, Group{Name: "us", Primary: "iad"})
+
+	drifts := Diff(saved, live)
+	require.Equal(t, []Drift{
+		{Kind: Changed, Resource: "group", Name: "eu", Description: "group eu: locations ams, fra → fra, delete protection on → off"},
+		{Kind: Added, Resource: "group", Name: "us", Description: "group us was added"},
+		{Kind: Removed, Resource: "database", Name: "events", Description: "database events was removed"},
+		{Kind: Changed, Resource: "database", Name: "orders", Description: "database orders: allowed IPs 10.0.0.0/8 → none"},
+		{Kind: Changed, Resource: "member", Name: "bob", Description: "member bob: role member → admin"},
+		{Kind: Added, Resource: "API token", Name: "deploy", Description: "API token deploy was added"},
+	}, drifts)
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	s := testSnapshot()
+	s.Version = Version + 1
+	require.NoError(t, s.Save(path))
+	_, err := Load(path)
+	require.Error(t, err)
+}
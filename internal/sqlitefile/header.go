@@ -0,0 +1,110 @@
+// Package sqlitefile inspects SQLite database files without the sqlite3
+// command: the settings stored in the file header, and the integrity of the
+// database with PRAGMA quick_check. It also converts copies of databases to
+// the settings required to import them.
+package sqlitefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// The database header, as described in https://www.sqlite.org/fileformat.html
+const (
+	headerSize  = 100
+	magicString = "SQLite format 3\x00"
+)
+
+// ErrNotDatabase is returned for files that are not SQLite databases.
+var ErrNotDatabase = errors.New("file is not a database")
+
+// Text encodings of the database, as reported by PRAGMA encoding.
+const (
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF16le = "UTF-16le"
+	EncodingUTF16be = "UTF-16be"
+)
+
+// Header holds the settings of a database stored in its file header.
+type Header struct {
+	PageSize int
+	// WriteVersion and ReadVersion are 1 in rollback journal mode, and 2 in
+	// WAL mode.
+	WriteVersion int
+	ReadVersion  int
+	// ReservedBytes is the space reserved at the end of each page, as set
+	// with .filectrl reserve_bytes.
+	ReservedBytes int
+	Encoding      string
+	// AutoVacuum is 0 when auto-vacuum is disabled, 1 when it is full and 2
+	// when it is incremental, as reported by PRAGMA auto_vacuum.
+	AutoVacuum int
+}
+
+// WAL reports whether the database is in WAL mode.
+func (h Header) WAL() bool {
+	return h.WriteVersion == 2 && h.ReadVersion == 2
+}
+
+// ReadHeader reads the header of the database file at path.
+func ReadHeader(path string) (Header, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Header{}, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, headerSize)
+	if _, err := io.ReadFull(file, buf); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return Header{}, ErrNotDatabase
+	} else if err != nil {
+		return Header{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseHeader(buf)
+}
+
+func parseHeader(buf []byte) (Header, error) {
+	if !bytes.HasPrefix(buf, []byte(magicString)) {
+		return Header{}, ErrNotDatabase
+	}
+	h := Header{
+		PageSize:      int(binary.BigEndian.Uint16(buf[16:18])),
+		WriteVersion:  int(buf[18]),
+		ReadVersion:   int(buf[19]),
+		ReservedBytes: int(buf[20]),
+	}
+	// 1 stands for 65536, which does not fit in two bytes
+	if h.PageSize == 1 {
+		h.PageSize = 65536
+	}
+	if h.PageSize < 512 || h.PageSize&(h.PageSize-1) != 0 {
+		return Header{}, fmt.Errorf("%w: invalid page size %d", ErrNotDatabase, h.PageSize)
+	}
+
+	switch binary.BigEndian.Uint32(buf[56:60]) {
+	// The encoding is only set once the schema is written, UTF-8 is the
+	// default
+	case 0, 1:
+		h.Encoding = EncodingUTF8
+	case 2:
+		h.Encoding = EncodingUTF16le
+	case 3:
+		h.Encoding = EncodingUTF16be
+	default:
+		return Header{}, fmt.Errorf("%w: invalid text encoding", ErrNotDatabase)
+	}
+
+	// The largest root page is only set with auto-vacuum, and the
+	// incremental vacuum flag tells which kind
+	if binary.BigEndian.Uint32(buf[52:56]) != 0 {
+		h.AutoVacuum = 1
+		if binary.BigEndian.Uint32(buf[64:68]) != 0 {
+			h.AutoVacuum = 2
+		}
+	}
+	return h, nil
+}
@@ -0,0 +1,73 @@
+package sqlitefile
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testHeader returns the header of a WAL database with 4 KiB pages.
+func testHeader() []byte {
+	buf := make([]byte, headerSize)
+	copy(buf, magicString)
+	binary.BigEndian.PutUint16(buf[16:18], 4096)
+	buf[18], buf[19] = 2, 2
+	binary.BigEndian.PutUint32(buf[56:60], 1)
+	return buf
+}
+
+func TestParseHeader(t *testing.T) {
+	h, err := parseHeader(testHeader())
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 4096, WriteVersion: 2, ReadVersion: 2, Encoding: EncodingUTF8}, h)
+	require.True(t, h.WAL())
+
+	buf := testHeader()
+	binary.BigEndian.PutUint16(buf[16:18], 1)
+	buf[18], buf[19] = 1, 1
+	buf[20] = 28
+	binary.BigEndian.PutUint32(buf[52:56], 3)
+	binary.BigEndian.PutUint32(buf[56:60], 2)
+	binary.BigEndian.PutUint32(buf[64:68], 1)
+	h, err = parseHeader(buf)
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 65536, WriteVersion: 1, ReadVersion: 1, ReservedBytes: 28, Encoding: EncodingUTF16le, AutoVacuum: 2}, h)
+	require.False(t, h.WAL())
+}
+
+func TestParseHeader_Invalid(t *testing.T) {
+	buf := testHeader()
+	buf[0] = 'X'
+	_, err := parseHeader(buf)
+	require.ErrorIs(t, err, ErrNotDatabase)
+
+	buf = testHeader()
+	binary.BigEndian.PutUint16(buf[16:18], 1000)
+	_, err = parseHeader(buf)
+	require.ErrorIs(t, err, ErrNotDatabase)
+
+	buf = testHeader()
+	binary.BigEndian.PutUint32(buf[56:60], 4)
+	_, err = parseHeader(buf)
+	require.ErrorIs(t, err, ErrNotDatabase)
+}
+
+func TestReadHeader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+	require.NoError(t, os.WriteFile(path, append(testHeader(), make([]byte, 4096-headerSize)...), 0o644))
+	h, err := ReadHeader(path)
+	require.NoError(t, err)
+	require.Equal(t, 4096, h.PageSize)
+
+	short := filepath.Join(dir, "short.db")
+	require.NoError(t, os.WriteFile(short, []byte("SQLite format 3\x00"), 0o644))
+	_, err = ReadHeader(short)
+	require.ErrorIs(t, err, ErrNotDatabase)
+
+	_, err = ReadHeader(filepath.Join(dir, "missing.db"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
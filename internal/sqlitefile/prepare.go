@@ -0,0 +1,324 @@
+package sqlitefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// PageSize is the page size of a prepared database.
+const PageSize = 4096
+
+// Change is a setting of a database that Prepare changes.
+type Change struct {
+	Setting string
+	From    string
+	To      string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Setting, c.From, c.To)
+}
+
+// Changes returns the settings of the database with header h that Prepare
+// changes: the journal mode to WAL, the page size to 4 KiB, auto-vacuum to
+// none, the encoding to UTF-8 and the reserved bytes to reservedBytes. The
+// reserved bytes are kept when reservedBytes is negative.
+func Changes(h Header, reservedBytes int) []Change {
+	var changes []Change
+	if !h.WAL() {
+		changes = append(changes, Change{Setting: "journal mode", From: "rollback journal", To: "WAL"})
+	}
+	if h.PageSize != PageSize {
+		changes = append(changes, Change{Setting: "page size", From: strconv.Itoa(h.PageSize), To: strconv.Itoa(PageSize)})
+	}
+	if h.AutoVacuum != 0 {
+		changes = append(changes, Change{Setting: "auto-vacuum", From: autoVacuumName(h.AutoVacuum), To: autoVacuumName(0)})
+	}
+	if h.Encoding != EncodingUTF8 {
+		changes = append(changes, Change{Setting: "encoding", From: h.Encoding, To: EncodingUTF8})
+	}
+	if reservedBytes >= 0 && h.ReservedBytes != reservedBytes {
+		changes = append(changes, Change{Setting: "reserved bytes", From: strconv.Itoa(h.ReservedBytes), To: strconv.Itoa(reservedBytes)})
+	}
+	return changes
+}
+
+func autoVacuumName(mode int) string {
+	switch mode {
+	case 0:
+		return "none"
+	case 1:
+		return "full"
+	case 2:
+		return "incremental"
+	}
+	return strconv.Itoa(mode)
+}
+
+// Prepare copies the database at src, with the changes left in its WAL, to
+// dst and converts the copy with the changes returned by Changes. src is only
+// read. dst must not exist.
+func Prepare(src, dst string, reservedBytes int) error {
+	header, err := ReadHeader(src)
+	if err != nil {
+		return err
+	}
+	if err := copyDatabase(src, dst); err != nil {
+		return err
+	}
+	// VACUUM keeps the encoding, so the database is rebuilt to change it
+	if header.Encoding != EncodingUTF8 {
+		converted := dst + "-utf8"
+		if err := convertEncoding(dst, converted); err != nil {
+			removeDatabase(converted)
+			return err
+		}
+		removeDatabase(dst)
+		if err := os.Rename(converted, dst); err != nil {
+			return err
+		}
+	}
+	return normalize(dst, reservedBytes)
+}
+
+// copyDatabase copies the database at src and its WAL, if any, to dst.
+func copyDatabase(src, dst string) error {
+	if err := copyFile(src, dst); err != nil {
+		return err
+	}
+	err := copyFile(src+"-wal", dst+"-wal")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	return out.Close()
+}
+
+// removeDatabase removes the database at path and its WAL and shared memory
+// files.
+func removeDatabase(path string) {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		_ = os.Remove(path + suffix)
+	}
+}
+
+// normalize sets the settings of the database at path. The page size,
+// auto-vacuum and reserved bytes only apply once the database is vacuumed,
+// which can't change the page size in WAL mode.
+func normalize(path string, reservedBytes int) error {
+	// The file control and the pragmas only apply to their connection
+	conn, err := openDatabase(path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.Exec("PRAGMA journal_mode = DELETE"); err != nil {
+		return fmt.Errorf("failed to leave WAL mode: %w", mapError(err))
+	}
+	for _, statement := range []string{fmt.Sprintf("PRAGMA page_size = %d", PageSize), "PRAGMA auto_vacuum = NONE"} {
+		if err := conn.Exec(statement); err != nil {
+			return fmt.Errorf("failed to run %s: %w", statement, mapError(err))
+		}
+	}
+	// Setting the page size resets the reserved bytes
+	if reservedBytes >= 0 {
+		if _, err := conn.FileControl("main", sqlite3.FCNTL_RESERVE_BYTES, reservedBytes); err != nil {
+			return fmt.Errorf("failed to set reserved bytes: %w", err)
+		}
+	}
+	if err := conn.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum: %w", mapError(err))
+	}
+	if err := conn.Exec("PRAGMA journal_mode = WAL"); err != nil {
+		return fmt.Errorf("failed to set WAL mode: %w", mapError(err))
+	}
+	return conn.Close()
+}
+
+// convertEncoding copies the database at src to a new UTF-8 database at dst.
+// Databases with different encodings can't be attached to one another, so
+// the rows are copied from one connection to the other.
+func convertEncoding(src, dst string) error {
+	srcConn, err := openDatabase(src)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+	dstConn, err := sqlite3.OpenFlags(dst, sqlite3.OPEN_READWRITE|sqlite3.OPEN_CREATE)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	// The encoding only applies before the database is created
+	if err := dstConn.Exec("PRAGMA encoding = 'UTF-8'"); err != nil {
+		return err
+	}
+
+	schema, err := readSchema(srcConn)
+	if err != nil {
+		return mapError(err)
+	}
+	// The transaction is rolled back when the connection is closed on error
+	if err := dstConn.Exec("BEGIN"); err != nil {
+		return err
+	}
+
+	// Tables are filled before the indexes and triggers are created, so that
+	// the triggers don't fire
+	for _, object := range schema {
+		if object.typ != "table" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(object.sql), "CREATE VIRTUAL TABLE") {
+			return fmt.Errorf("virtual table %s can't be converted to %s", object.name, EncodingUTF8)
+		}
+		if err := dstConn.Exec(object.sql); err != nil {
+			return fmt.Errorf("failed to create table %s: %w", object.name, err)
+		}
+		if err := copyRows(srcConn, dstConn, object.name); err != nil {
+			return fmt.Errorf("failed to copy table %s: %w", object.name, err)
+		}
+	}
+	if err := copySequences(srcConn, dstConn); err != nil {
+		return err
+	}
+	for _, object := range schema {
+		if object.typ == "table" {
+			continue
+		}
+		if err := dstConn.Exec(object.sql); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", object.typ, object.name, err)
+		}
+	}
+	for _, pragma := range []string{"user_version", "application_id"} {
+		var value int64
+		err := query(srcConn, "PRAGMA "+pragma, func(stmt *sqlite3.Stmt) error {
+			value = stmt.ColumnInt64(0)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := dstConn.Exec(fmt.Sprintf("PRAGMA %s = %d", pragma, value)); err != nil {
+			return err
+		}
+	}
+	if err := dstConn.Exec("COMMIT"); err != nil {
+		return err
+	}
+	return dstConn.Close()
+}
+
+type schemaObject struct {
+	typ  string
+	name string
+	sql  string
+}
+
+// readSchema returns the objects of the database in the order they were
+// created, but the internal tables and indexes, which SQLite creates itself.
+func readSchema(conn *sqlite3.Conn) ([]schemaObject, error) {
+	var schema []schemaObject
+	err := query(conn, "SELECT type, name, sql FROM sqlite_schema WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\' ORDER BY rowid", func(stmt *sqlite3.Stmt) error {
+		schema = append(schema, schemaObject{typ: stmt.ColumnText(0), name: stmt.ColumnText(1), sql: stmt.ColumnText(2)})
+		return nil
+	})
+	return schema, err
+}
+
+// copyRows copies the rows of table from src to dst. As with VACUUM, the
+// rowids are only kept when they are an INTEGER PRIMARY KEY column.
+func copyRows(src, dst *sqlite3.Conn, table string) error {
+	columns, err := insertableColumns(src, table)
+	if err != nil {
+		return err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	insert, _, err := dst.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(table), strings.Join(columns, ", "), placeholders))
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	values := make([]any, len(columns))
+	return query(src, fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), quoteIdentifier(table)), func(stmt *sqlite3.Stmt) error {
+		if err := stmt.Columns(values...); err != nil {
+			return err
+		}
+		if err := bind(insert, values...); err != nil {
+			return err
+		}
+		return insert.Exec()
+	})
+}
+
+// insertableColumns returns the quoted columns of table, but the generated
+// ones, which can't be inserted.
+func insertableColumns(conn *sqlite3.Conn, table string) ([]string, error) {
+	var columns []string
+	err := query(conn, "SELECT name FROM pragma_table_xinfo(?) WHERE hidden = 0", func(stmt *sqlite3.Stmt) error {
+		columns = append(columns, quoteIdentifier(stmt.ColumnText(0)))
+		return nil
+	}, table)
+	return columns, err
+}
+
+// copySequences copies the last AUTOINCREMENT values from src to dst, which
+// inserting the rows has set to the largest rowids instead.
+func copySequences(src, dst *sqlite3.Conn) error {
+	var exists bool
+	err := query(src, "SELECT count(*) > 0 FROM sqlite_schema WHERE name = 'sqlite_sequence'", func(stmt *sqlite3.Stmt) error {
+		exists = stmt.ColumnBool(0)
+		return nil
+	})
+	if err != nil || !exists {
+		return err
+	}
+	if err := dst.Exec("DELETE FROM sqlite_sequence"); err != nil {
+		return err
+	}
+	insert, _, err := dst.Prepare("INSERT INTO sqlite_sequence (name, seq) VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+	return query(src, "SELECT name, seq FROM sqlite_sequence", func(stmt *sqlite3.Stmt) error {
+		name := stmt.ColumnText(0)
+		if err := bind(insert, name, stmt.ColumnInt64(1)); err != nil {
+			return err
+		}
+		if err := insert.Exec(); err != nil {
+			return fmt.Errorf("failed to copy sequence of table %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,172 @@
+package sqlitefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/ext/fts5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanges(t *testing.T) {
+	prepared := Header{PageSize: 4096, WriteVersion: 2, ReadVersion: 2, Encoding: EncodingUTF8}
+	require.Empty(t, Changes(prepared, -1))
+	require.Empty(t, Changes(prepared, 0))
+
+	// The reserved bytes are only changed for a cipher
+	withReservedBytes := prepared
+	withReservedBytes.ReservedBytes = 28
+	require.Empty(t, Changes(withReservedBytes, -1))
+	require.Equal(t, []Change{{Setting: "reserved bytes", From: "28", To: "48"}}, Changes(withReservedBytes, 48))
+
+	h := Header{PageSize: 8192, WriteVersion: 1, ReadVersion: 1, Encoding: EncodingUTF16le, AutoVacuum: 2}
+	require.Equal(t, []Change{
+		{Setting: "journal mode", From: "rollback journal", To: "WAL"},
+		{Setting: "page size", From: "8192", To: "4096"},
+		{Setting: "auto-vacuum", From: "incremental", To: "none"},
+		{Setting: "encoding", From: EncodingUTF16le, To: EncodingUTF8},
+		{Setting: "reserved bytes", From: "0", To: "28"},
+	}, Changes(h, 28))
+	require.Equal(t, "page size: 8192 -> 4096", Changes(h, 28)[1].String())
+}
+
+// queryRows returns the rows of query on the database at path, formatted as
+// strings.
+func queryRows(t *testing.T, path, q string) []string {
+	t.Helper()
+	conn, err := sqlite3.Open(path)
+	require.NoError(t, err)
+	defer conn.Close()
+	var result []string
+	err = query(conn, q, func(stmt *sqlite3.Stmt) error {
+		result = append(result, stmt.ColumnText(0))
+		return nil
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestPrepare(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.db")
+	createDatabase(t, src,
+		"PRAGMA page_size = 8192",
+		"PRAGMA auto_vacuum = FULL",
+		"PRAGMA journal_mode = WAL",
+		"CREATE TABLE data (id INTEGER PRIMARY KEY, blob BLOB)",
+		"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 100) INSERT INTO data (blob) SELECT randomblob(1000) FROM n",
+	)
+
+	// Keep changes in the WAL of the source
+	conn, err := sqlite3.Open(src)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Exec("PRAGMA wal_autocheckpoint = 0"))
+	require.NoError(t, conn.Exec("DELETE FROM data WHERE id > 50"))
+	srcHeader, err := ReadHeader(src)
+	require.NoError(t, err)
+	srcData, err := os.ReadFile(src)
+	require.NoError(t, err)
+	srcWAL, err := os.ReadFile(src + "-wal")
+	require.NoError(t, err)
+	require.NotEmpty(t, srcWAL)
+
+	dst := filepath.Join(dir, "dst.db")
+	require.NoError(t, Prepare(src, dst, 28))
+
+	h, err := ReadHeader(dst)
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 4096, WriteVersion: 2, ReadVersion: 2, ReservedBytes: 28, Encoding: EncodingUTF8}, h)
+	require.Empty(t, Changes(h, 28))
+	require.NoError(t, QuickCheck(dst))
+	require.Equal(t, []string{"50"}, queryRows(t, dst, "SELECT count(*) FROM data"))
+
+	// The source is untouched
+	h, err = ReadHeader(src)
+	require.NoError(t, err)
+	require.Equal(t, srcHeader, h)
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.Equal(t, srcData, data)
+	wal, err := os.ReadFile(src + "-wal")
+	require.NoError(t, err)
+	require.Equal(t, srcWAL, wal)
+}
+
+func TestPrepare_KeepsReservedBytes(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.db")
+	createDatabase(t, src,
+		"PRAGMA page_size = 8192",
+		"CREATE TABLE data (id INTEGER PRIMARY KEY)",
+	)
+	dst := filepath.Join(dir, "dst.db")
+	require.NoError(t, Prepare(src, dst, 32))
+	dst2 := filepath.Join(dir, "dst2.db")
+	require.NoError(t, Prepare(dst, dst2, -1))
+
+	h, err := ReadHeader(dst2)
+	require.NoError(t, err)
+	require.Equal(t, 32, h.ReservedBytes)
+	require.True(t, h.WAL())
+
+	// The destination is never overwritten
+	require.Error(t, Prepare(src, dst, -1))
+}
+
+func TestPrepare_Encoding(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.db")
+	createDatabase(t, src,
+		"PRAGMA encoding = 'UTF-16le'",
+		"PRAGMA user_version = 7",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, created DATE, avatar BLOB)",
+		"CREATE TABLE log (message TEXT, length INTEGER GENERATED ALWAYS AS (length(message)))",
+		"CREATE TABLE tags (tag TEXT PRIMARY KEY, count INTEGER) WITHOUT ROWID",
+		"CREATE INDEX users_name ON users (name)",
+		"CREATE VIEW user_names AS SELECT name FROM users",
+		"CREATE TRIGGER users_log AFTER INSERT ON users BEGIN INSERT INTO log (message) VALUES (new.name); END",
+		"INSERT INTO users (name, created, avatar) VALUES ('zoé', '2024-01-02', x'00ff'), ('名前', 'not a date', NULL), ('removed', NULL, NULL)",
+		"DELETE FROM users WHERE name = 'removed'",
+		"DELETE FROM log WHERE rowid = 1",
+		"INSERT INTO tags VALUES ('a', 1), ('b', 2)",
+	)
+	dst := filepath.Join(dir, "dst.db")
+	require.NoError(t, Prepare(src, dst, -1))
+
+	h, err := ReadHeader(dst)
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 4096, WriteVersion: 2, ReadVersion: 2, Encoding: EncodingUTF8}, h)
+	require.NoError(t, QuickCheck(dst))
+	require.NoFileExists(t, dst+"-utf8")
+
+	require.Equal(t, []string{"1|zoé|2024-01-02|00FF", "2|名前|not a date|"},
+		queryRows(t, dst, "SELECT id || '|' || name || '|' || ifnull(created, '') || '|' || ifnull(hex(avatar), '') FROM users ORDER BY id"))
+	require.Equal(t, []string{"名前|2", "removed|7"}, queryRows(t, dst, "SELECT message || '|' || length FROM log ORDER BY rowid"))
+	require.Equal(t, []string{"a1", "b2"}, queryRows(t, dst, "SELECT tag || count FROM tags"))
+	require.Equal(t, []string{"users|3"}, queryRows(t, dst, "SELECT name || '|' || seq FROM sqlite_sequence"))
+	require.Equal(t, []string{"zoé", "名前"}, queryRows(t, dst, "SELECT name FROM user_names ORDER BY name"))
+	require.Equal(t, []string{"7"}, queryRows(t, dst, "PRAGMA user_version"))
+	require.Equal(t, []string{"users_name"}, queryRows(t, dst, "SELECT name FROM sqlite_schema WHERE type = 'index'"))
+
+	// The trigger is kept, and didn't fire while copying
+	require.Equal(t, []string{"2"}, queryRows(t, dst, "SELECT count(*) FROM log"))
+	createDatabase(t, dst, "INSERT INTO users (name) VALUES ('new')")
+	require.Equal(t, []string{"4"}, queryRows(t, dst, "SELECT max(id) FROM users"))
+	require.Equal(t, []string{"3"}, queryRows(t, dst, "SELECT count(*) FROM log"))
+}
+
+func TestPrepare_VirtualTable(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.db")
+	conn, err := sqlite3.Open(src)
+	require.NoError(t, err)
+	require.NoError(t, fts5.Register(conn))
+	require.NoError(t, conn.Exec("PRAGMA encoding = 'UTF-16be'"))
+	require.NoError(t, conn.Exec("CREATE VIRTUAL TABLE docs USING fts5(body)"))
+	require.NoError(t, conn.Close())
+	dst := filepath.Join(dir, "dst.db")
+	require.ErrorContains(t, Prepare(src, dst, -1), "virtual table docs")
+}
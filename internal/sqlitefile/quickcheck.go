@@ -0,0 +1,116 @@
+package sqlitefile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// IntegrityError is returned by QuickCheck when the database is corrupted.
+type IntegrityError struct {
+	// Problems are the rows returned by PRAGMA quick_check.
+	Problems []string
+}
+
+func (e *IntegrityError) Error() string {
+	return "database is corrupted: " + strings.Join(e.Problems, "; ")
+}
+
+// maxProblems is how many problems PRAGMA quick_check reports at most.
+const maxProblems = 10
+
+// openDatabase opens the database at path with the SQLite library embedded in
+// the CLI, which is built from Go and doesn't need cgo. The database is
+// opened for reading and writing, without being created if it does not
+// exist. It is not opened read only, so that its WAL is checkpointed when it
+// is closed, as the sqlite3 command does.
+func openDatabase(path string) (*sqlite3.Conn, error) {
+	conn, err := sqlite3.OpenFlags(path, sqlite3.OPEN_READWRITE)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return conn, nil
+}
+
+// query runs query on conn with args, and calls fn for every row.
+func query(conn *sqlite3.Conn, query string, fn func(stmt *sqlite3.Stmt) error, args ...any) error {
+	stmt, _, err := conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	if err := bind(stmt, args...); err != nil {
+		return err
+	}
+	for stmt.Step() {
+		if err := fn(stmt); err != nil {
+			return err
+		}
+	}
+	return stmt.Err()
+}
+
+// bind binds args to the parameters of stmt. The values are the ones
+// returned by Stmt.Columns.
+func bind(stmt *sqlite3.Stmt, args ...any) error {
+	for i, arg := range args {
+		param := i + 1
+		var err error
+		switch value := arg.(type) {
+		case nil:
+			err = stmt.BindNull(param)
+		case int64:
+			err = stmt.BindInt64(param, value)
+		case float64:
+			err = stmt.BindFloat(param, value)
+		case string:
+			err = stmt.BindText(param, value)
+		case []byte:
+			err = stmt.BindBlob(param, value)
+		default:
+			err = fmt.Errorf("unsupported value of type %T", arg)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuickCheck runs PRAGMA quick_check on the database at path. It returns an
+// *IntegrityError if the database is corrupted, and ErrNotDatabase if the
+// file is not a database.
+func QuickCheck(path string) error {
+	conn, err := openDatabase(path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var problems []string
+	err = query(conn, fmt.Sprintf("PRAGMA quick_check(%d)", maxProblems), func(stmt *sqlite3.Stmt) error {
+		if result := stmt.ColumnText(0); result != "ok" {
+			problems = append(problems, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return mapError(err)
+	}
+	if len(problems) > 0 {
+		return &IntegrityError{Problems: problems}
+	}
+	return nil
+}
+
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, sqlite3.NOTADB):
+		return ErrNotDatabase
+	case errors.Is(err, sqlite3.CORRUPT):
+		return &IntegrityError{Problems: []string{err.Error()}}
+	}
+	return err
+}
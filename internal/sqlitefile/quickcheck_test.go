@@ -0,0 +1,85 @@
+package sqlitefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ncruces/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// createDatabase creates a database at path with statements.
+func createDatabase(t *testing.T, path string, statements ...string) {
+	t.Helper()
+	conn, err := sqlite3.Open(path)
+	require.NoError(t, err)
+	defer conn.Close()
+	for _, statement := range statements {
+		require.NoError(t, conn.Exec(statement))
+	}
+}
+
+func TestQuickCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	createDatabase(t, path,
+		"PRAGMA page_size = 4096",
+		"PRAGMA journal_mode = WAL",
+		"CREATE TABLE data (id INTEGER PRIMARY KEY, blob BLOB)",
+		"INSERT INTO data (blob) VALUES (randomblob(1000)), (randomblob(1000))",
+	)
+	require.NoError(t, QuickCheck(path))
+
+	h, err := ReadHeader(path)
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 4096, WriteVersion: 2, ReadVersion: 2, Encoding: EncodingUTF8}, h)
+}
+
+func TestQuickCheck_Settings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	createDatabase(t, path,
+		"PRAGMA page_size = 8192",
+		"PRAGMA auto_vacuum = INCREMENTAL",
+		"PRAGMA encoding = 'UTF-16be'",
+		"CREATE TABLE data (id INTEGER PRIMARY KEY)",
+	)
+	h, err := ReadHeader(path)
+	require.NoError(t, err)
+	require.Equal(t, Header{PageSize: 8192, WriteVersion: 1, ReadVersion: 1, Encoding: EncodingUTF16be, AutoVacuum: 2}, h)
+}
+
+func TestQuickCheck_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	createDatabase(t, path,
+		"PRAGMA page_size = 4096",
+		"CREATE TABLE data (id INTEGER PRIMARY KEY, value TEXT)",
+		"CREATE INDEX data_value ON data (value)",
+		"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 500) INSERT INTO data (value) SELECT hex(randomblob(100)) FROM n",
+	)
+	// Overwrite the pages after the schema with garbage
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	garbage := make([]byte, 4*4096)
+	for i := range garbage {
+		garbage[i] = 0xAA
+	}
+	_, err = file.WriteAt(garbage, 2*4096)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	var integrityErr *IntegrityError
+	require.ErrorAs(t, QuickCheck(path), &integrityErr)
+	require.NotEmpty(t, integrityErr.Problems)
+}
+
+func TestQuickCheck_NotDatabase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "garbage.db")
+	require.NoError(t, os.WriteFile(path, []byte("not a valid sqlite database content here, not at all, really not"), 0o644))
+	require.ErrorIs(t, QuickCheck(path), ErrNotDatabase)
+
+	// Missing databases are not created
+	missing := filepath.Join(dir, "missing.db")
+	require.Error(t, QuickCheck(missing))
+	require.NoFileExists(t, missing)
+}
@@ -0,0 +1,215 @@
+// Package terraform writes Terraform configuration for existing groups and
+// databases, so they can be brought under Terraform without recreating them.
+// The configuration targets the celest-dev/turso provider of the Terraform
+// registry.
+package terraform
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/tursodatabase/turso-cli/internal/snapshot"
+)
+
+const (
+	// ProviderSource is the registry address of the provider the
+	// configuration is written for.
+	ProviderSource = "celest-dev/turso"
+
+	GroupResource    = "turso_group"
+	DatabaseResource = "turso_database"
+)
+
+// Resource is a resource block of the configuration and the ID to import it
+// with.
+type Resource struct {
+	Type  string
+	Label string
+	ID    string
+}
+
+func (r Resource) Address() string {
+	return r.Type + "." + r.Label
+}
+
+// ImportCommand returns the command that adds the existing resource to the
+// Terraform state.
+func (r Resource) ImportCommand() string {
+	return fmt.Sprintf("terraform import %s %s", r.Address(), r.ID)
+}
+
+// Config is the Terraform configuration of groups and databases.
+type Config struct {
+	Groups    []snapshot.Group
+	Databases []snapshot.Database
+}
+
+// Resources returns the resources of the configuration in the order they are
+// written, groups first.
+func (c *Config) Resources() []Resource {
+	labels := newLabeler()
+	resources := []Resource{}
+	for _, g := range c.Groups {
+		resources = append(resources, Resource{Type: GroupResource, Label: labels.label(GroupResource, g.Name), ID: g.Name})
+	}
+	for _, db := range c.Databases {
+		resources = append(resources, Resource{Type: DatabaseResource, Label: labels.label(DatabaseResource, db.Name), ID: db.Name})
+	}
+	return resources
+}
+
+// Write writes the configuration as HCL, preceded by a comment with the
+// commands that import the resources and by the provider requirement.
+// Settings that the provider doesn't manage are written as comments above
+// their resource, so that they are not lost.
+func (c *Config) Write(w io.Writer) error {
+	resources := c.Resources()
+	groups := map[string]Resource{}
+	for i, g := range c.Groups {
+		groups[g.Name] = resources[i]
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("# Import the existing resources into the Terraform state with:\n#\n")
+	for _, r := range resources {
+		fmt.Fprintf(b, "#   %s\n", r.ImportCommand())
+	}
+	fmt.Fprintf(b, "\nterraform {\n  required_providers {\n    turso = {\n      source = %q\n    }\n  }\n}\n", ProviderSource)
+
+	for i, g := range c.Groups {
+		block := newBlock(resources[i])
+		block.attr("name", quote(g.Name))
+		block.attr("location", quote(g.Primary))
+		if len(g.Locations) > 1 {
+			block.unmanaged("locations", quoteList(g.Locations))
+		}
+		if g.Config.IsDeleteProtected() {
+			block.unmanaged("delete_protection", "true")
+		}
+		block.writeTo(b)
+	}
+
+	for i, db := range c.Databases {
+		block := newBlock(resources[len(c.Groups)+i])
+		block.attr("name", quote(db.Name))
+		if group, ok := groups[db.Group]; ok {
+			block.attr("group", group.Address()+".name")
+		} else {
+			block.attr("group", quote(db.Group))
+		}
+		if db.IsSchema {
+			block.attr("is_schema", "true")
+		}
+		if db.Schema != "" {
+			block.attr("schema", quote(db.Schema))
+		}
+		if db.EncryptionCipher != "" {
+			block.unmanaged("encryption_cipher", quote(db.EncryptionCipher))
+		}
+		if db.Config.IsDeleteProtected() {
+			block.unmanaged("delete_protection", "true")
+		}
+		if db.Config.AttachAllowed() {
+			block.unmanaged("allow_attach", "true")
+		}
+		if ips := db.Config.AllowedIPList(); len(ips) > 0 {
+			block.unmanaged("allowed_ips", quoteList(ips))
+		}
+		if vpcs := db.Config.AllowedVpcIDList(); len(vpcs) > 0 {
+			block.unmanaged("allowed_aws_vpc_ids", quoteList(vpcs))
+		}
+		block.writeTo(b)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type block struct {
+	resource Resource
+	names    []string
+	values   []string
+	// comments are the settings the provider doesn't manage.
+	comments []string
+}
+
+func newBlock(resource Resource) *block {
+	return &block{resource: resource}
+}
+
+func (b *block) attr(name, value string) {
+	b.names = append(b.names, name)
+	b.values = append(b.values, value)
+}
+
+func (b *block) unmanaged(name, value string) {
+	b.comments = append(b.comments, name+" = "+value)
+}
+
+// writeTo writes the block with its equal signs aligned, as terraform fmt
+// does.
+func (b *block) writeTo(w *strings.Builder) {
+	width := 0
+	for _, name := range b.names {
+		width = max(width, len(name))
+	}
+	w.WriteString("\n")
+	if len(b.comments) > 0 {
+		fmt.Fprintf(w, "# Not managed by the %s provider:\n", ProviderSource)
+		for _, comment := range b.comments {
+			fmt.Fprintf(w, "#   %s\n", comment)
+		}
+	}
+	fmt.Fprintf(w, "resource %q %q {\n", b.resource.Type, b.resource.Label)
+	for i, name := range b.names {
+		fmt.Fprintf(w, "  %-*s = %s\n", width, name, b.values[i])
+	}
+	w.WriteString("}\n")
+}
+
+// quote returns value as an HCL string. Template sequences are escaped so
+// that they are not interpolated.
+func quote(value string) string {
+	value = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(value)
+	return `"` + value + `"`
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// labeler derives unique resource labels from resource names.
+type labeler struct {
+	used map[string]bool
+}
+
+func newLabeler() *labeler {
+	return &labeler{used: map[string]bool{}}
+}
+
+func (l *labeler) label(resourceType, name string) string {
+	label := invalidLabelChars.ReplaceAllString(name, "_")
+	if label == "" || !(label[0] == '_' || label[0] >= 'A' && label[0] <= 'Z' || label[0] >= 'a' && label[0] <= 'z') {
+		label = "_" + label
+	}
+	unique := label
+	for i := 2; l.used[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	l.used[resourceType+"."+unique] = true
+	return unique
+}
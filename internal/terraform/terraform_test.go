@@ -0,0 +1,74 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tursodatabase/turso-cli/internal/snapshot"
+	"github.com/tursodatabase/turso-cli/internal/turso"
+)
+
+func TestWrite(t *testing.T) {
+	on := true
+	ips := []string{"10.0.0.0/8"}
+	config := Config{
+		Groups: []snapshot.Group{
+			{Name: "eu", Primary: "fra", Locations: []string{"ams", "fra"}, Config: turso.GroupConfig{DeleteProtection: &on}},
+		},
+		Databases: []snapshot.Database{
+			{Name: "orders", Group: "eu", Config: turso.DatabaseConfig{AllowedIPs: &ips}},
+			{Name: "1st", Group: "archived", EncryptionCipher: "aegis256"},
+		},
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, config.Write(out))
+	require.Equal(t, `# Import the existing resources into the Terraform state with:
+#
+#   terraform import turso_group.eu eu
+#   terraform import turso_database.orders orders
+#   terraform import turso_database._1st 1st
+
+terraform {
+  required_providers {
+    turso = {
+      source = "celest-dev/turso"
+    }
+  }
+}
+
+# Not managed by the celest-dev/turso provider:
+#   locations = ["ams", "fra"]
+#   delete_protection = true
+resource "turso_group" "eu" {
+  name     = "eu"
+  location = "fra"
+}
+
+# Not managed by the celest-dev/turso provider:
+#   allowed_ips = ["10.0.0.0/8"]
+resource "turso_database" "orders" {
+  name  = "orders"
+  group = turso_group.eu.name
+}
+
+# Not managed by the celest-dev/turso provider:
+#   encryption_cipher = "aegis256"
+resource "turso_database" "_1st" {
+  name  = "1st"
+  group = "archived"
+}
+`, out.String())
+}
+
+func TestLabelsAreUnique(t *testing.T) {
+	l := newLabeler()
+	require.Equal(t, "a_b", l.label(DatabaseResource, "a.b"))
+	require.Equal(t, "a_b_2", l.label(DatabaseResource, "a_b"))
+	require.Equal(t, "a_b", l.label(GroupResource, "a_b"))
+}
+
+func TestQuoteEscapesTemplates(t *testing.T) {
+	require.Equal(t, `"a\"b$${c}%%{d}"`, quote(`a"b${c}%{d}`))
+}
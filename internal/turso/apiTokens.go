@@ -2,6 +2,7 @@ package turso
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,15 +21,15 @@ type ApiToken struct {
 
 type ApiTokensClient client
 
-func (a *ApiTokensClient) List() ([]ApiToken, error) {
-	res, err := a.client.Get("/v1/auth/api-tokens", nil)
+func (a *ApiTokensClient) List(ctx context.Context) ([]ApiToken, error) {
+	res, err := a.client.Get(ctx, "/v1/auth/api-tokens", nil)
 	if err != nil {
-		return []ApiToken{}, fmt.Errorf("failed to get api tokens list: %s", err)
+		return []ApiToken{}, fmt.Errorf("failed to get api tokens list: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get api tokens list: %s", res.Status)
+		return nil, fmt.Errorf("failed to get api tokens list: %w", parseResponseError(res))
 	}
 
 	type ListResponse struct {
@@ -44,12 +45,12 @@ type CreateApiToken struct {
 	Value string `json:"value"`
 }
 
-func (a *ApiTokensClient) Create(name string) (CreateApiToken, error) {
-	return a.CreateWithOrg(name, "")
+func (a *ApiTokensClient) Create(ctx context.Context, name string) (CreateApiToken, error) {
+	return a.CreateWithOrg(ctx, name, "")
 }
 
-func (a *ApiTokensClient) CreateWithOrg(name string, organization string) (CreateApiToken, error) {
-	return a.CreateScoped(name, organization, "", nil)
+func (a *ApiTokensClient) CreateWithOrg(ctx context.Context, name string, organization string) (CreateApiToken, error) {
+	return a.CreateScoped(ctx, name, organization, "", nil)
 }
 
 // CreateScoped mints an API token with optional restrictions. Empty
@@ -61,7 +62,7 @@ func (a *ApiTokensClient) CreateWithOrg(name string, organization string) (Creat
 // scopes may contain individual scope labels (see AllScopes) or the
 // preset names "read-only" / "full-access" — the platform expands the
 // presets server-side.
-func (a *ApiTokensClient) CreateScoped(name, organization, group string, scopes []string) (CreateApiToken, error) {
+func (a *ApiTokensClient) CreateScoped(ctx context.Context, name, organization, group string, scopes []string) (CreateApiToken, error) {
 	url := fmt.Sprintf("/v2/auth/api-tokens/%s", name)
 
 	var res *http.Response
@@ -82,13 +83,13 @@ func (a *ApiTokensClient) CreateScoped(name, organization, group string, scopes
 			return CreateApiToken{}, fmt.Errorf("failed to marshal request body: %w", marshalErr)
 		}
 		body := bytes.NewReader(jsonData)
-		res, err = a.client.Post(url, body)
+		res, err = a.client.Post(ctx, url, body)
 	} else {
-		res, err = a.client.Post(url, nil)
+		res, err = a.client.Post(ctx, url, nil)
 	}
 
 	if err != nil {
-		return CreateApiToken{}, fmt.Errorf("failed to create token: %s", err)
+		return CreateApiToken{}, fmt.Errorf("failed to create token: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -108,12 +109,12 @@ func (a *ApiTokensClient) CreateScoped(name, organization, group string, scopes
 	return data.ApiToken, nil
 }
 
-func (a *ApiTokensClient) Revoke(name string) error {
+func (a *ApiTokensClient) Revoke(ctx context.Context, name string) error {
 	url := fmt.Sprintf("/v1/auth/api-tokens/%s", name)
 
-	res, err := a.client.Delete(url, nil)
+	res, err := a.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to revoke API token: %s", err)
+		return fmt.Errorf("failed to revoke API token: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -0,0 +1,48 @@
+package turso
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bandwidthBurst is the most bytes read at once from a rate limited reader,
+// so that the rate is smooth even when the limit is low.
+const bandwidthBurst = 32 * 1024
+
+// bandwidthLimiter limits the rate of bytes shared by concurrent readers. A
+// nil limiter does not limit anything.
+type bandwidthLimiter struct {
+	mu             sync.Mutex
+	bytesPerSecond int64
+	// next is when the bytes reserved so far are all allowed.
+	next time.Time
+}
+
+// newBandwidthLimiter returns a limiter allowing bytesPerSecond, or nil when
+// bytesPerSecond is not positive.
+func newBandwidthLimiter(bytesPerSecond int64) *bandwidthLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &bandwidthLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// wait reserves n bytes and blocks until they are allowed, or ctx is done.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.bytesPerSecond))
+	l.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	return sleepContext(ctx, delay)
+}
@@ -0,0 +1,51 @@
+package turso
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthLimiter(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		limiter := newBandwidthLimiter(1000)
+		start := time.Now()
+
+		// The limit is shared by concurrent readers
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 5 {
+					require.NoError(t, limiter.wait(t.Context(), 100))
+				}
+			}()
+		}
+		wg.Wait()
+
+		// The first 100 bytes are allowed right away
+		require.Equal(t, 1900*time.Millisecond, time.Since(start))
+	})
+}
+
+func TestBandwidthLimiter_Cancel(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		limiter := newBandwidthLimiter(100)
+		require.NoError(t, limiter.wait(t.Context(), 1000))
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		require.ErrorIs(t, limiter.wait(ctx, 100), context.DeadlineExceeded)
+	})
+}
+
+func TestBandwidthLimiter_Unlimited(t *testing.T) {
+	require.Nil(t, newBandwidthLimiter(0))
+	var limiter *bandwidthLimiter
+	require.NoError(t, limiter.wait(context.Background(), 1<<30))
+}
@@ -2,6 +2,7 @@ package turso
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -65,22 +66,22 @@ type ListResponse struct {
 	Pagination *Pagination `json:"pagination,omitempty"`
 }
 
-func (d *DatabasesClient) List(options DatabaseListOptions) (ListResponse, error) {
+func (d *DatabasesClient) List(ctx context.Context, options DatabaseListOptions) (ListResponse, error) {
 	path := d.URL("")
 
 	if options := options.Encode(); options != "" {
 		path += "?" + options
 	}
 
-	r, err := d.client.Get(path, nil)
+	r, err := d.client.Get(ctx, path, nil)
 	if err != nil {
-		return ListResponse{}, fmt.Errorf("failed to get database listing: %s", err)
+		return ListResponse{}, fmt.Errorf("failed to get database listing: %w", err)
 	}
 	defer r.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return ListResponse{}, notMemberErr(org)
+		return ListResponse{}, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -95,8 +96,8 @@ func (d *DatabasesClient) List(options DatabaseListOptions) (ListResponse, error
 	return resp, nil
 }
 
-func (d *DatabasesClient) Get(name string) (Database, error) {
-	r, err := d.client.Get(d.URL("/"+name), nil)
+func (d *DatabasesClient) Get(ctx context.Context, name string) (Database, error) {
+	r, err := d.client.Get(ctx, d.URL("/"+name), nil)
 	if err != nil {
 		return Database{}, fmt.Errorf("failed to get database %s: %w", name, err)
 	}
@@ -104,11 +105,11 @@ func (d *DatabasesClient) Get(name string) (Database, error) {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return Database{}, notMemberErr(org)
+		return Database{}, notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return Database{}, fmt.Errorf("database %s not found. List known databases using %s", internal.Emph(name), internal.Emph("turso db list"))
+		return Database{}, apiErrorf(r, "database %s not found. List known databases using %s", internal.Emph(name), internal.Emph("turso db list"))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -125,21 +126,21 @@ func (d *DatabasesClient) Get(name string) (Database, error) {
 	return resp.Database, nil
 }
 
-func (d *DatabasesClient) Delete(database string) error {
+func (d *DatabasesClient) Delete(ctx context.Context, database string) error {
 	url := d.URL("/" + database)
-	r, err := d.client.Delete(url, nil)
+	r, err := d.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete database: %s", err)
+		return fmt.Errorf("failed to delete database: %w", err)
 	}
 	defer r.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("database %s not found. List known databases using %s", internal.Emph(database), internal.Emph("turso db list"))
+		return apiErrorf(r, "database %s not found. List known databases using %s", internal.Emph(database), internal.Emph("turso db list"))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -162,6 +163,11 @@ type DBSeed struct {
 	// This is only used locally when uploading a database file and
 	// never passed to the control plane as JSON.
 	Filepath string `json:"-"`
+	// Resumable saves the progress of the upload next to the file, so that
+	// an interrupted upload can be resumed with ResumeImport.
+	Resumable bool `json:"-"`
+	// Upload configures the upload of the file.
+	Upload UploadOptions `json:"-"`
 }
 
 type RemoteEncryption struct {
@@ -183,14 +189,22 @@ type CreateDatabaseBody struct {
 	UseTursoDB       bool              `json:"use_tursodb,omitempty"`
 }
 
-func (d *DatabasesClient) Create(name, location, image, extensions, group string, schema string, isSchema bool, seed *DBSeed, sizeLimit, remoteEncryptionCipher, remoteEncryptionKey string, useTursoDB bool, spinner *prompt.SpinnerT) (*CreateDatabaseResponse, error) {
+func (d *DatabasesClient) Create(ctx context.Context, name, location, image, extensions, group string, schema string, isSchema bool, seed *DBSeed, sizeLimit, remoteEncryptionCipher, remoteEncryptionKey string, useTursoDB bool, spinner *prompt.SpinnerT) (*CreateDatabaseResponse, error) {
 	isTursoServerUpload := seed != nil && seed.Type == "database_upload" && seed.Filepath != ""
 	var uploadFilepath string
+	var resumable bool
+	var uploadOptions UploadOptions
 	var params CreateDatabaseBody
 	if isTursoServerUpload {
 		uploadFilepath = seed.Filepath
+		resumable = seed.Resumable
+		uploadOptions = seed.Upload
+		if resumable && ImportInProgress(uploadFilepath) {
+			return nil, fmt.Errorf("an interrupted import of %s exists, resume it or remove %s", uploadFilepath, ImportStatePath(uploadFilepath))
+		}
 		// Clear the unused seed parameters, only Type=database_upload is used.
 		seed.Filepath = ""
+		seed.Resumable = false
 		seed.Name = ""
 		seed.URL = ""
 		seed.Timestamp = nil
@@ -217,19 +231,19 @@ func (d *DatabasesClient) Create(name, location, image, extensions, group string
 		return nil, fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	res, err := d.client.Post(d.URL(""), body)
+	res, err := d.client.Post(ctx, d.URL(""), body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database: %s", err)
+		return nil, fmt.Errorf("failed to create database: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(res, org)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return nil, fmt.Errorf("database name '%s' is not available", name)
+		return nil, apiErrorf(res, "database name '%s' is not available", name)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -242,9 +256,25 @@ func (d *DatabasesClient) Create(name, location, image, extensions, group string
 	}
 
 	if isTursoServerUpload {
-		if _, err = d.UploadDatabaseAWS(data, group, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey, spinner); err != nil {
-			// Clean up the database if the upload fails
-			if deleteErr := d.Delete(data.Database.Name); deleteErr != nil {
+		state := &importState{
+			Database: data.Database.Name,
+			Hostname: data.Database.Hostname,
+			Group:    group,
+		}
+		if remoteEncryptionKey != "" {
+			state.EncryptionCipher = remoteEncryptionCipher
+		}
+		if resumable {
+			state.path = ImportStatePath(uploadFilepath)
+		}
+		if err = d.uploadDatabaseAWS(ctx, state, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey, uploadOptions, spinner); err != nil {
+			// Keep the draft database if the upload can be resumed
+			if ImportInProgress(uploadFilepath) {
+				return nil, err
+			}
+			// Clean up the database if the upload fails. This must happen even
+			// when the upload was interrupted, so it can't inherit ctx cancellation.
+			if deleteErr := d.Delete(context.WithoutCancel(ctx), data.Database.Name); deleteErr != nil {
 				fmt.Printf("%v", deleteErr)
 			}
 			return nil, err
@@ -263,26 +293,65 @@ func (d *DatabasesClient) Create(name, location, image, extensions, group string
 //     This call happens in DatabasesClient.Create() above, after which it calls this function.
 //  2. This function creates a DB token for the newly-created DB, and then calls turso-server to upload the database file.
 //     turso-server will perform validations on the file and 'activate' the db if everything is ok.
-func (d *DatabasesClient) UploadDatabaseAWS(resp *CreateDatabaseResponse, group, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey string, spinner *prompt.SpinnerT) (*CreateDatabaseResponse, error) {
-	dbName := resp.Database.Name
+func (d *DatabasesClient) UploadDatabaseAWS(ctx context.Context, resp *CreateDatabaseResponse, group, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey string, spinner *prompt.SpinnerT) (*CreateDatabaseResponse, error) {
+	state := &importState{Database: resp.Database.Name, Hostname: resp.Database.Hostname, Group: group}
+	if err := d.uploadDatabaseAWS(ctx, state, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey, UploadOptions{}, spinner); err != nil {
+		return nil, err
+	}
+	// Return the original database creation response
+	return resp, nil
+}
+
+// ResumeImport continues the interrupted upload of file to the draft database
+// it was started for, see FindInterruptedImport. remoteEncryptionKey must be
+// given again if the database is encrypted.
+func (d *DatabasesClient) ResumeImport(ctx context.Context, file, remoteEncryptionKey string, opts UploadOptions, spinner *prompt.SpinnerT) error {
+	state, err := readImportState(file)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("there is no interrupted import of %s", file)
+	}
+	if state.EncryptionCipher != "" && remoteEncryptionKey == "" {
+		return fmt.Errorf("database %s is encrypted, the encryption key is needed to resume its import", state.Database)
+	}
+	if err := d.uploadDatabaseAWS(ctx, state, file, state.EncryptionCipher, remoteEncryptionKey, opts, spinner); err != nil {
+		// Clean up the draft database if the upload can't be resumed anymore,
+		// as Create does
+		if !ImportInProgress(file) {
+			if deleteErr := d.Delete(context.WithoutCancel(ctx), state.Database); deleteErr != nil {
+				fmt.Printf("%v", deleteErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// uploadDatabaseAWS uploads uploadFilepath to the draft database of state.
+func (d *DatabasesClient) uploadDatabaseAWS(ctx context.Context, state *importState, uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey string, opts UploadOptions, spinner *prompt.SpinnerT) error {
+	dbName := state.Database
+	group := state.Group
 	tokenTTL := 5 * time.Minute
 	tokenProvider := func() (string, error) {
-		return d.Token(dbName, "5m", false, nil, nil)
+		return d.Token(ctx, dbName, "5m", false, nil, nil)
 	}
 
-	baseURL, err := url.Parse(fmt.Sprintf("https://%s", resp.Database.Hostname))
+	baseURL, err := url.Parse(fmt.Sprintf("https://%s", state.Hostname))
 	if err != nil {
-		return nil, fmt.Errorf("unable to create TursoServerClient: %v", err)
+		return fmt.Errorf("unable to create TursoServerClient: %v", err)
 	}
-	tursoServerClient, err := NewTursoServerClient(baseURL, tokenProvider, tokenTTL, d.client.cliVersion, d.client.Org)
+	tursoServerClient, err := NewTursoServerClient(baseURL, tokenProvider, tokenTTL, d.client.cliVersion, d.client.Org, d.client.httpClient)
 	if err != nil {
-		return nil, fmt.Errorf("could not create Turso server client: %w", err)
+		return fmt.Errorf("could not create Turso server client: %w", err)
 	}
+	tursoServerClient.SetUploadOptions(opts)
 
 	// Upload the database file
-	spinner.Text(fmt.Sprintf("Uploading database %s in group %s, this may take a while...", internal.Emph(resp.Database.Name), internal.Emph(group)))
+	spinner.Text(fmt.Sprintf("Uploading database %s in group %s, this may take a while...", internal.Emph(dbName), internal.Emph(group)))
 
-	err = tursoServerClient.UploadFileMultipart(uploadFilepath, remoteEncryptionCipher, remoteEncryptionKey, func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool) {
+	err = tursoServerClient.uploadFileMultipart(ctx, uploadFilepath, state, remoteEncryptionCipher, remoteEncryptionKey, func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool) {
 		totalSeconds := int(elapsedTime.Seconds())
 		minutes := totalSeconds / 60
 		seconds := totalSeconds % 60
@@ -301,42 +370,68 @@ func (d *DatabasesClient) UploadDatabaseAWS(resp *CreateDatabaseResponse, group,
 			elapsedTimeStr = fmt.Sprintf("%d %s", seconds, secondsStr)
 		}
 		if done {
-			spinner.Text(fmt.Sprintf("Uploaded database %s in group %s (%d bytes) - we are now verifying your database on the server... (took %s)", internal.Emph(resp.Database.Name), internal.Emph(group), totalBytes, elapsedTimeStr))
+			spinner.Text(fmt.Sprintf("Uploaded database %s in group %s (%d bytes) - we are now verifying your database on the server... (took %s)", internal.Emph(dbName), internal.Emph(group), totalBytes, elapsedTimeStr))
 		} else {
-			spinner.Text(fmt.Sprintf("Uploading database %s in group %s, %d%% complete (%d/%d bytes uploaded) (elapsed %s)", internal.Emph(resp.Database.Name), internal.Emph(group), progressPct, uploadedBytes, totalBytes, elapsedTimeStr))
+			spinner.Text(fmt.Sprintf("Uploading database %s in group %s, %d%% complete (%d/%d bytes uploaded) (elapsed %s)", internal.Emph(dbName), internal.Emph(group), progressPct, uploadedBytes, totalBytes, elapsedTimeStr))
 		}
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not upload database file: %w", err)
+		return fmt.Errorf("could not upload database file: %w", err)
 	}
-
-	// Return the original database creation response
-	return resp, nil
+	return nil
 }
 
-func (d *DatabasesClient) Export(dbName, dbUrl, outputFile string, withMetadata bool, overwrite bool, remoteEncryptionKey string) error {
-	if !overwrite {
+func (d *DatabasesClient) Export(ctx context.Context, dbName, dbUrl, outputFile string, overwrite bool, opts ExportOptions) (ExportResult, error) {
+	if !overwrite && !(opts.Resume && ExportInProgress(outputFile)) {
 		if _, err := os.Stat(outputFile); err == nil {
-			return fmt.Errorf("file %s already exists, use `--overwrite` flag to overwrite it", outputFile)
+			return ExportResult{}, fmt.Errorf("file %s already exists, use `--overwrite` flag to overwrite it", outputFile)
 		}
 	}
+	tursoServerClient, err := d.serverClient(ctx, dbName, dbUrl)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	return tursoServerClient.Export(ctx, outputFile, opts)
+}
+
+// ExportStream exports the database to the objects returned by create, see
+// TursoServerClient.ExportStream.
+func (d *DatabasesClient) ExportStream(ctx context.Context, dbName, dbUrl string, opts ExportOptions, create func(suffix string) (ExportObject, error)) (ExportResult, error) {
+	tursoServerClient, err := d.serverClient(ctx, dbName, dbUrl)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	return tursoServerClient.ExportStream(ctx, opts, create)
+}
+
+// Pull updates an export of the database written with metadata, see
+// TursoServerClient.Pull.
+func (d *DatabasesClient) Pull(ctx context.Context, dbName, dbUrl, outputFile string, remoteEncryptionKey string) (PullResult, error) {
+	tursoServerClient, err := d.serverClient(ctx, dbName, dbUrl)
+	if err != nil {
+		return PullResult{}, err
+	}
+	return tursoServerClient.Pull(ctx, outputFile, remoteEncryptionKey)
+}
+
+func (d *DatabasesClient) serverClient(ctx context.Context, dbName, dbUrl string) (TursoServerClient, error) {
 	tokenProvider := func() (string, error) {
-		return d.Token(dbName, "1h", false, nil, nil)
+		return d.Token(ctx, dbName, "1h", false, nil, nil)
 	}
 	baseURL, err := url.Parse(dbUrl)
 	if err != nil {
-		return fmt.Errorf("could not parse database URL: %w", err)
+		return TursoServerClient{}, fmt.Errorf("could not parse database URL: %w", err)
 	}
-	tursoServerClient, err := NewTursoServerClient(baseURL, tokenProvider, time.Hour, d.client.cliVersion, d.client.Org)
+	tursoServerClient, err := NewTursoServerClient(baseURL, tokenProvider, time.Hour, d.client.cliVersion, d.client.Org, d.client.httpClient)
 	if err != nil {
-		return fmt.Errorf("could not create Turso server client: %w", err)
+		return TursoServerClient{}, fmt.Errorf("could not create Turso server client: %w", err)
 	}
-	return tursoServerClient.Export(outputFile, withMetadata, remoteEncryptionKey)
+	return tursoServerClient, nil
 }
 
-func (d *DatabasesClient) Seed(name string, dbFile *os.File) error {
+func (d *DatabasesClient) Seed(ctx context.Context, name string, dbFile *os.File) error {
 	url := d.URL(fmt.Sprintf("/%s/seed", name))
-	res, err := d.client.Upload(url, dbFile)
+	res, err := d.client.Upload(ctx, url, dbFile)
 	if err != nil {
 		return fmt.Errorf("failed to create database: %w", err)
 	}
@@ -344,11 +439,11 @@ func (d *DatabasesClient) Seed(name string, dbFile *os.File) error {
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return fmt.Errorf("database name '%s' is not available", name)
+		return apiErrorf(res, "database name '%s' is not available", name)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -357,9 +452,9 @@ func (d *DatabasesClient) Seed(name string, dbFile *os.File) error {
 	return nil
 }
 
-func (d *DatabasesClient) UploadDump(dbFile *os.File) (string, error) {
+func (d *DatabasesClient) UploadDump(ctx context.Context, dbFile *os.File) (string, error) {
 	url := d.URL("/dumps")
-	res, err := d.client.Upload(url, dbFile)
+	res, err := d.client.Upload(ctx, url, dbFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload the dump file: %w", err)
 	}
@@ -367,7 +462,7 @@ func (d *DatabasesClient) UploadDump(dbFile *os.File) (string, error) {
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return "", notMemberErr(org)
+		return "", notMemberErr(res, org)
 	}
 	if res.StatusCode != http.StatusOK {
 		return "", parseResponseError(res)
@@ -388,6 +483,7 @@ type DatabaseTokenRequest struct {
 }
 
 func (d *DatabasesClient) Token(
+	ctx context.Context,
 	database string,
 	expiration string,
 	readOnly bool,
@@ -406,7 +502,7 @@ func (d *DatabasesClient) Token(
 		return "", fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	r, err := d.client.Post(url, body)
+	r, err := d.client.Post(ctx, url, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to get database token: %w", err)
 	}
@@ -414,7 +510,7 @@ func (d *DatabasesClient) Token(
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return "", notMemberErr(org)
+		return "", notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -429,9 +525,9 @@ func (d *DatabasesClient) Token(
 	return data.Jwt, nil
 }
 
-func (d *DatabasesClient) Rotate(database string) error {
+func (d *DatabasesClient) Rotate(ctx context.Context, database string) error {
 	url := d.URL(fmt.Sprintf("/%s/auth/rotate", database))
-	r, err := d.client.Post(url, nil)
+	r, err := d.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to rotate database keys: %w", err)
 	}
@@ -439,7 +535,7 @@ func (d *DatabasesClient) Rotate(database string) error {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -455,10 +551,10 @@ type Stats struct {
 	RowsWritten int    `json:"rows_written"`
 }
 
-func (d *DatabasesClient) Stats(database string) ([]Stats, error) {
+func (d *DatabasesClient) Stats(ctx context.Context, database string) ([]Stats, error) {
 	from := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
 	url := d.URL(fmt.Sprintf("/%s/usage/queries?from=%v", database, from))
-	r, err := d.client.Get(url, nil)
+	r, err := d.client.Get(ctx, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update database: %w", err)
 	}
@@ -466,7 +562,7 @@ func (d *DatabasesClient) Stats(database string) ([]Stats, error) {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -480,14 +576,14 @@ type Body struct {
 	Org string `json:"org"`
 }
 
-func (d *DatabasesClient) Transfer(database, org string) error {
+func (d *DatabasesClient) Transfer(ctx context.Context, database, org string) error {
 	url := d.URL(fmt.Sprintf("/%s/transfer", database))
 	body, err := json.Marshal(Body{Org: org})
 	bodyReader := bytes.NewReader(body)
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
-	r, err := d.client.Post(url, bodyReader)
+	r, err := d.client.Post(ctx, url, bodyReader)
 	if err != nil {
 		return errors.New("failed to transfer database")
 	}
@@ -500,9 +596,9 @@ func (d *DatabasesClient) Transfer(database, org string) error {
 	return nil
 }
 
-func (d *DatabasesClient) Wakeup(database string) error {
+func (d *DatabasesClient) Wakeup(ctx context.Context, database string) error {
 	url := d.URL(fmt.Sprintf("/%s/wakeup", database))
-	r, err := d.client.Post(url, nil)
+	r, err := d.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to unarchive database: %w", err)
 	}
@@ -510,7 +606,7 @@ func (d *DatabasesClient) Wakeup(database string) error {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -542,10 +638,10 @@ type DbUsageResponse struct {
 	DbUsage DbUsage `json:"database"`
 }
 
-func (d *DatabasesClient) Usage(database string) (DbUsage, error) {
+func (d *DatabasesClient) Usage(ctx context.Context, database string) (DbUsage, error) {
 	url := d.URL(fmt.Sprintf("/%s/usage", database))
 
-	r, err := d.client.Get(url, nil)
+	r, err := d.client.Get(ctx, url, nil)
 	if err != nil {
 		return DbUsage{}, fmt.Errorf("failed to get database usage: %w", err)
 	}
@@ -612,9 +708,9 @@ func (d *DatabaseConfig) AttachAllowed() bool {
 	return *d.AllowAttach
 }
 
-func (d *DatabasesClient) GetConfig(database string) (DatabaseConfig, error) {
+func (d *DatabasesClient) GetConfig(ctx context.Context, database string) (DatabaseConfig, error) {
 	url := d.URL(fmt.Sprintf("/%s/configuration", database))
-	r, err := d.client.Get(url, nil)
+	r, err := d.client.Get(ctx, url, nil)
 	if err != nil {
 		return DatabaseConfig{}, fmt.Errorf("failed to get database: %w", err)
 	}
@@ -622,24 +718,24 @@ func (d *DatabasesClient) GetConfig(database string) (DatabaseConfig, error) {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return DatabaseConfig{}, notMemberErr(org)
+		return DatabaseConfig{}, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
 		err = parseResponseError(r)
-		return DatabaseConfig{}, fmt.Errorf("failed to get config for database: %d %s", r.StatusCode, err)
+		return DatabaseConfig{}, fmt.Errorf("failed to get config for database: %w", err)
 	}
 
 	return unmarshal[DatabaseConfig](r)
 }
 
-func (d *DatabasesClient) UpdateConfig(database string, config DatabaseConfig) error {
+func (d *DatabasesClient) UpdateConfig(ctx context.Context, database string, config DatabaseConfig) error {
 	url := d.URL(fmt.Sprintf("/%s/configuration", database))
 	body, err := marshal(config)
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
-	r, err := d.client.Patch(url, body)
+	r, err := d.client.Patch(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to update database: %w", err)
 	}
@@ -647,12 +743,12 @@ func (d *DatabasesClient) UpdateConfig(database string, config DatabaseConfig) e
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
 		err = parseResponseError(r)
-		return fmt.Errorf("failed to update config for database: %d %s", r.StatusCode, err)
+		return fmt.Errorf("failed to update config for database: %w", err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -33,7 +34,7 @@ type DatabaseV3ListOptions struct {
 	Cursor     string
 }
 
-func (d *DatabasesV3Client) List(orgID string, options DatabaseV3ListOptions) ([]Database, string, error) {
+func (d *DatabasesV3Client) List(ctx context.Context, orgID string, options DatabaseV3ListOptions) ([]Database, string, error) {
 	path := d.url(orgID, "")
 	q := url.Values{}
 	if options.GroupId != "" {
@@ -52,7 +53,7 @@ func (d *DatabasesV3Client) List(orgID string, options DatabaseV3ListOptions) ([
 		path += "?" + enc
 	}
 
-	r, err := d.client.Get(path, nil)
+	r, err := d.client.Get(ctx, path, nil)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to list databases: %w", err)
 	}
@@ -77,8 +78,8 @@ func (d *DatabasesV3Client) List(orgID string, options DatabaseV3ListOptions) ([
 	return resp.Databases, next, nil
 }
 
-func (d *DatabasesV3Client) Get(orgID, dbID string) (Database, error) {
-	r, err := d.client.Get(d.url(orgID, "/"+dbID), nil)
+func (d *DatabasesV3Client) Get(ctx context.Context, orgID, dbID string) (Database, error) {
+	r, err := d.client.Get(ctx, d.url(orgID, "/"+dbID), nil)
 	if err != nil {
 		return Database{}, fmt.Errorf("failed to get database: %w", err)
 	}
@@ -98,8 +99,8 @@ func (d *DatabasesV3Client) Get(orgID, dbID string) (Database, error) {
 	return resp.Database, nil
 }
 
-func (d *DatabasesV3Client) GetConfig(orgID, dbID string) (DatabaseConfig, error) {
-	r, err := d.client.Get(d.url(orgID, "/"+dbID), nil)
+func (d *DatabasesV3Client) GetConfig(ctx context.Context, orgID, dbID string) (DatabaseConfig, error) {
+	r, err := d.client.Get(ctx, d.url(orgID, "/"+dbID), nil)
 	if err != nil {
 		return DatabaseConfig{}, fmt.Errorf("failed to get database: %w", err)
 	}
@@ -136,8 +137,8 @@ func (d *DatabasesV3Client) GetConfig(orgID, dbID string) (DatabaseConfig, error
 	return config, nil
 }
 
-func (d *DatabasesV3Client) Delete(orgID, dbID string) error {
-	r, err := d.client.Delete(d.url(orgID, "/"+dbID), nil)
+func (d *DatabasesV3Client) Delete(ctx context.Context, orgID, dbID string) error {
+	r, err := d.client.Delete(ctx, d.url(orgID, "/"+dbID), nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete database: %w", err)
 	}
@@ -149,13 +150,13 @@ func (d *DatabasesV3Client) Delete(orgID, dbID string) error {
 	return nil
 }
 
-func (d *DatabasesV3Client) Create(orgID string, body CreateDatabaseV3Body) (Database, error) {
+func (d *DatabasesV3Client) Create(ctx context.Context, orgID string, body CreateDatabaseV3Body) (Database, error) {
 	payload, err := marshal(body)
 	if err != nil {
 		return Database{}, fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	r, err := d.client.Post(d.url(orgID, ""), payload)
+	r, err := d.client.Post(ctx, d.url(orgID, ""), payload)
 	if err != nil {
 		return Database{}, fmt.Errorf("failed to create database: %w", err)
 	}
@@ -176,6 +177,7 @@ func (d *DatabasesV3Client) Create(orgID string, body CreateDatabaseV3Body) (Dat
 }
 
 func (d *DatabasesV3Client) Token(
+	ctx context.Context,
 	orgID string, dbID string,
 	expiration string,
 	readOnly bool,
@@ -198,7 +200,7 @@ func (d *DatabasesV3Client) Token(
 	if err != nil {
 		return "", fmt.Errorf("could not serialize request body: %w", err)
 	}
-	r, err := d.client.Post(path, body)
+	r, err := d.client.Post(ctx, path, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to get database token: %w", err)
 	}
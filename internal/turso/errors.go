@@ -0,0 +1,105 @@
+package turso
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned by client methods when the platform answers with an
+// unexpected status. Callers can inspect it with errors.As to branch on the
+// kind of failure instead of matching on the message.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the machine readable error code sent by the platform, if any.
+	Code string
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// Message is the human readable description of the error.
+	Message string
+	// Retryable reports whether the same request may succeed if retried.
+	Retryable bool
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// NotFound reports whether the requested resource does not exist.
+func (e *APIError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// Unauthorized reports whether the credentials were missing, invalid or not
+// allowed to access the resource.
+func (e *APIError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// QuotaExceeded reports whether the request was rejected because of the
+// organization plan limits.
+func (e *APIError) QuotaExceeded() bool {
+	if e.StatusCode == http.StatusPaymentRequired {
+		return true
+	}
+	switch e.Code {
+	case "feature_not_available_for_starter_plan", "quota_exceeded", "limit_exceeded":
+		return true
+	}
+	return false
+}
+
+// Conflict reports whether the request conflicts with an existing resource.
+func (e *APIError) Conflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+// newAPIError builds an APIError for res with the given message.
+func newAPIError(res *http.Response, message string) *APIError {
+	err := &APIError{
+		StatusCode: res.StatusCode,
+		Message:    message,
+		Retryable:  isRetryableStatus(res.StatusCode),
+	}
+	if res.Request != nil {
+		err.Method = res.Request.Method
+		if res.Request.URL != nil {
+			err.Path = res.Request.URL.Path
+		}
+	}
+	return err
+}
+
+func apiErrorf(res *http.Response, format string, a ...any) *APIError {
+	return newAPIError(res, fmt.Sprintf(format, a...))
+}
+
+func parseResponseError(res *http.Response) error {
+	d, err := io.ReadAll(res.Body)
+	if err != nil {
+		return apiErrorf(res, "response failed with status %s (%s)", res.Status, d)
+	}
+
+	var errResp ErrorResponseDetails
+	if err := json.Unmarshal(d, &errResp); err == nil {
+		if errResp.Error != nil {
+			apiErr := apiErrorf(res, "%v", errResp.Error)
+			apiErr.Code = errResp.Code
+			return apiErr
+		}
+	}
+	return apiErrorf(res, "response failed with status %s (%s)", res.Status, d)
+}
+
+// AsAPIError returns the APIError wrapped by err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
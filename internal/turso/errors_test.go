@@ -0,0 +1,79 @@
+package turso
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		fmt.Fprint(w, `{"error":"upgrade your plan","code":"quota_exceeded"}`)
+	}))
+	defer server.Close()
+
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := New(base, "token", "dev", "")
+
+	res, err := client.Get(t.Context(), "/v1/organizations/acme/databases", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	err = fmt.Errorf("failed to list databases: %w", parseResponseError(res))
+	require.EqualError(t, err, "failed to list databases: upgrade your plan")
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusPaymentRequired, apiErr.StatusCode)
+	require.Equal(t, "quota_exceeded", apiErr.Code)
+	require.Equal(t, http.MethodGet, apiErr.Method)
+	require.Equal(t, "/v1/organizations/acme/databases", apiErr.Path)
+	require.False(t, apiErr.Retryable)
+	require.True(t, apiErr.QuotaExceeded())
+}
+
+func TestParseResponseErrorWithoutBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := New(base, "token", "dev", "")
+
+	res, err := client.Get(t.Context(), "/v1/locations", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	apiErr, ok := AsAPIError(parseResponseError(res))
+	require.True(t, ok)
+	require.Equal(t, "response failed with status 503 Service Unavailable ()", apiErr.Error())
+	require.Empty(t, apiErr.Code)
+	require.True(t, apiErr.Retryable)
+}
+
+func TestDeleteOrganizationForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := New(base, "token", "dev", "")
+
+	err = client.Organizations.Delete(t.Context(), "acme")
+	require.EqualError(t, err, "you do not have permission to delete organization acme")
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, http.MethodDelete, apiErr.Method)
+	require.Equal(t, "/v1/organizations/acme", apiErr.Path)
+}
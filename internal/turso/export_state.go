@@ -0,0 +1,97 @@
+package turso
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// exportState is the progress of an export, saved next to the output file so
+// that an interrupted export can be resumed. It is only advanced after the
+// data it covers has been synced to disk.
+type exportState struct {
+	Generation int `json:"generation"`
+	// SnapshotBytes is the length of the snapshot written so far, and
+	// SnapshotDone whether it is complete. The WAL header is written with
+	// the snapshot, so the WAL file exists once SnapshotDone is set.
+	SnapshotBytes int64 `json:"snapshot_bytes"`
+	SnapshotDone  bool  `json:"snapshot_done"`
+	// Salt and Checksum are the WAL salts and the running checksum after
+	// LastFrameNo, which are needed to append more frames.
+	Salt        [2]uint32 `json:"salt"`
+	Checksum    [2]uint32 `json:"checksum"`
+	LastFrameNo int       `json:"last_frame_no"`
+	// WALBase is the number of the frame preceding the first frame of the
+	// WAL file. It is only set when pulling frames into a new WAL file, the
+	// earlier frames being in the database file.
+	WALBase int `json:"wal_base,omitempty"`
+}
+
+func exportStatePath(outputFile string) string {
+	return outputFile + "-export-state"
+}
+
+// ExportInProgress reports whether an interrupted export to outputFile can be
+// resumed.
+func ExportInProgress(outputFile string) bool {
+	_, err := os.Stat(exportStatePath(outputFile))
+	return err == nil
+}
+
+// readExportState returns the saved progress of an export to outputFile, or
+// nil if there is none.
+func readExportState(outputFile string) (*exportState, error) {
+	data, err := os.ReadFile(exportStatePath(outputFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export state: %w", err)
+	}
+	state := &exportState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid export state %s: %w", exportStatePath(outputFile), err)
+	}
+	return state, nil
+}
+
+// save replaces the saved state atomically, so that a crash leaves either the
+// previous or the new state behind.
+func (s *exportState) save(outputFile string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(exportStatePath(outputFile), data); err != nil {
+		return fmt.Errorf("failed to save export state: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file renamed to path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func removeExportState(outputFile string) {
+	_ = os.Remove(exportStatePath(outputFile))
+}
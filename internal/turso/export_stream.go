@@ -0,0 +1,99 @@
+package turso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ExportObject is a file of an export written by ExportStream. Close is called
+// once the file is complete, and Abort instead when the export fails.
+type ExportObject interface {
+	io.Writer
+	Close() error
+	Abort() error
+}
+
+// ExportStream exports the database like Export, but streams the files to the
+// objects returned by create instead of writing them to disk. create is
+// called with the suffix of each file: "" for the database file, then "-wal"
+// and, with ExportOptions.WithMetadata, "-info".
+//
+// Nothing is saved while exporting, so ExportOptions.Resume and
+// ExportOptions.Checkpoint are not supported.
+func (i *TursoServerClient) ExportStream(ctx context.Context, opts ExportOptions, create func(suffix string) (ExportObject, error)) (ExportResult, error) {
+	if opts.Resume || opts.Checkpoint {
+		return ExportResult{}, errors.New("resuming and checkpointing are not supported when streaming an export")
+	}
+	headers := map[string]string{}
+	if opts.RemoteEncryptionKey != "" {
+		headers[EncryptionKeyHeader] = opts.RemoteEncryptionKey
+	}
+	info, err := i.info(ctx, headers)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	result := ExportResult{Generation: info.CurrentGeneration}
+	state := &exportState{Generation: info.CurrentGeneration}
+	progress := newExportProgress(opts.OnProgress)
+
+	err = writeExportObject(create, "", func(w io.Writer) error {
+		res, body, err := i.fetchSnapshot(ctx, state, headers, progress)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if _, err := io.Copy(w, body); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = writeExportObject(create, "-wal", func(w io.Writer) error {
+		header, err := newWALHeader(state)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(header); err != nil {
+			return fmt.Errorf("failed to write WAL header: %w", err)
+		}
+		return i.streamWAL(ctx, w, state, headers, progress, func() error { return nil })
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to export WAL: %w", err)
+	}
+	result.LastFrameNo = state.LastFrameNo
+
+	if opts.WithMetadata {
+		err = writeExportObject(create, "-info", func(w io.Writer) error {
+			data, err := encodeExportMetadata(state.Generation, state.LastFrameNo, state.WALBase)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to export metadata: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// writeExportObject creates the object for suffix and writes it with write,
+// aborting it if write fails.
+func writeExportObject(create func(suffix string) (ExportObject, error), suffix string, write func(w io.Writer) error) error {
+	object, err := create(suffix)
+	if err != nil {
+		return err
+	}
+	if err := write(object); err != nil {
+		_ = object.Abort()
+		return err
+	}
+	return object.Close()
+}
@@ -0,0 +1,592 @@
+package turso
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// MockExportServer serves the /info, /export and /sync endpoints of a
+// database with a snapshot and WAL frames.
+type MockExportServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	generation int
+	snapshot   []byte
+	frames     [][]byte
+	ranges     []string
+
+	// Error simulation
+	supportRange      bool
+	failSnapshotAt    int // Snapshot bytes sent before the connection breaks, -1 means no failure
+	failSyncFromFrame int // First frame of the /sync request that fails once, 0 means no failure
+	endStatus         int // Status of the /sync requests past the last frame
+	pastEndRequests   map[int]int
+
+	// Latency simulation, the /sync requests being served concurrently
+	syncDelay       time.Duration
+	syncMu          sync.Mutex
+	syncInFlight    int
+	maxSyncInFlight int
+}
+
+func NewMockExportServer(snapshotSize, frameCount int) *MockExportServer {
+	mock := &MockExportServer{
+		generation:      1,
+		snapshot:        bytes.Repeat([]byte("SNAPSHOT"), snapshotSize/8),
+		supportRange:    true,
+		failSnapshotAt:  -1,
+		endStatus:       http.StatusBadRequest,
+		pastEndRequests: map[int]int{},
+	}
+	mock.addFrames(frameCount)
+
+	mock.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sync/") && mock.syncDelay > 0 {
+			mock.delaySync()
+		}
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/info":
+			fmt.Fprintf(w, `{"current_generation": %d}`, mock.generation)
+		case parts[0] == "export" && len(parts) == 2:
+			mock.handleExport(w, r)
+		case parts[0] == "sync" && len(parts) == 4:
+			from, _ := strconv.Atoi(parts[2])
+			to, _ := strconv.Atoi(parts[3])
+			mock.handleSync(w, from, to)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return mock
+}
+
+// delaySync waits for syncDelay, recording how many requests wait at once.
+func (m *MockExportServer) delaySync() {
+	m.syncMu.Lock()
+	m.syncInFlight++
+	m.maxSyncInFlight = max(m.maxSyncInFlight, m.syncInFlight)
+	m.syncMu.Unlock()
+
+	time.Sleep(m.syncDelay)
+
+	m.syncMu.Lock()
+	m.syncInFlight--
+	m.syncMu.Unlock()
+}
+
+// addFrames appends a transaction of count frames to the WAL.
+func (m *MockExportServer) addFrames(count int) {
+	for i := 0; i < count; i++ {
+		n := len(m.frames)
+		frame := make([]byte, walFrameSize)
+		binary.BigEndian.PutUint32(frame[0:4], uint32(n%10+1))
+		if i == count-1 {
+			binary.BigEndian.PutUint32(frame[4:8], 10) // Commit frame
+		}
+		for j := 24; j < walFrameSize; j++ {
+			frame[j] = byte(n + j)
+		}
+		m.frames = append(m.frames, frame)
+	}
+}
+
+func (m *MockExportServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	data := m.snapshot
+	status := http.StatusOK
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		m.ranges = append(m.ranges, rangeHeader)
+		if m.supportRange {
+			offset, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			data = data[offset:]
+			status = http.StatusPartialContent
+		}
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	if m.failSnapshotAt >= 0 {
+		// Announce the full length but stop early, as a broken connection would
+		w.Write(data[:m.failSnapshotAt])
+		m.failSnapshotAt = -1
+		return
+	}
+	w.Write(data)
+}
+
+func (m *MockExportServer) handleSync(w http.ResponseWriter, from, to int) {
+	if m.failSyncFromFrame != 0 && from == m.failSyncFromFrame {
+		m.failSyncFromFrame = 0
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if from > len(m.frames) {
+		m.pastEndRequests[from]++
+		w.WriteHeader(m.endStatus)
+		return
+	}
+	for frameNo := from; frameNo < to && frameNo <= len(m.frames); frameNo++ {
+		w.Write(m.frames[frameNo-1])
+	}
+}
+
+// requireValidExport checks that the export matches the mock database and
+// that the WAL checksums are valid.
+func requireValidExport(t *testing.T, mock *MockExportServer, outputFile string) {
+	t.Helper()
+
+	snapshot, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, mock.snapshot, snapshot)
+
+	requireValidWAL(t, outputFile+"-wal", mock.frames)
+}
+
+// requireValidWAL checks that the WAL file holds frames with valid salts and
+// checksums.
+func requireValidWAL(t *testing.T, walFile string, frames [][]byte) {
+	t.Helper()
+
+	wal, err := os.ReadFile(walFile)
+	require.NoError(t, err)
+	require.Len(t, wal, walHeaderSize+len(frames)*walFrameSize)
+
+	s0, s1 := uint32(0), uint32(0)
+	checksum := func(data []byte) {
+		for i := 0; i < len(data); i += 8 {
+			s0 += binary.LittleEndian.Uint32(data[i:i+4]) + s1
+			s1 += binary.LittleEndian.Uint32(data[i+4:i+8]) + s0
+		}
+	}
+	checksum(wal[:24])
+	require.Equal(t, s0, binary.BigEndian.Uint32(wal[24:28]))
+	require.Equal(t, s1, binary.BigEndian.Uint32(wal[28:32]))
+	for i, expected := range frames {
+		frame := wal[walHeaderSize+i*walFrameSize : walHeaderSize+(i+1)*walFrameSize]
+		require.Equal(t, wal[16:24], frame[8:16], "salts of frame %d", i+1)
+		require.Equal(t, expected[:8], frame[:8])
+		require.Equal(t, expected[24:], frame[24:])
+		checksum(frame[:8])
+		checksum(frame[24:])
+		require.Equal(t, s0, binary.BigEndian.Uint32(frame[16:20]), "checksum of frame %d", i+1)
+		require.Equal(t, s1, binary.BigEndian.Uint32(frame[20:24]), "checksum of frame %d", i+1)
+	}
+}
+
+func TestExport(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 200)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{WithMetadata: true})
+	require.NoError(t, err)
+	require.Equal(t, ExportResult{Generation: 1, LastFrameNo: 200}, result)
+	requireValidExport(t, mock, outputFile)
+	require.FileExists(t, outputFile+"-info")
+	require.False(t, ExportInProgress(outputFile))
+}
+
+func TestDatabasesExport_ExistingFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+	require.NoError(t, os.WriteFile(outputFile, []byte("data"), 0o644))
+
+	_, err := (&DatabasesClient{}).Export(context.Background(), "db", "", outputFile, false, ExportOptions{Resume: true})
+	require.ErrorContains(t, err, "already exists, use `--overwrite` flag to overwrite it")
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+}
+
+func TestExport_EndOfWALInternalServerError(t *testing.T) {
+	// The last batch is full, so the end of the WAL is only known from the
+	// status of the next one
+	mock := NewMockExportServer(64*1024, 2*walBatchSize)
+	defer mock.Close()
+	mock.endStatus = http.StatusInternalServerError
+	client := createTestClient(t, mock.URL)
+	client.client.maxRetries = 3
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	start := time.Now()
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, ExportResult{Generation: 1, LastFrameNo: 2 * walBatchSize}, result)
+	requireValidExport(t, mock, outputFile)
+
+	// The end of the WAL is not retried
+	require.Less(t, time.Since(start), apiBaseRetryDelay)
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	require.NotEmpty(t, mock.pastEndRequests)
+	for from, count := range mock.pastEndRequests {
+		require.Equal(t, 1, count, "requests of the batch from frame %d", from)
+	}
+}
+
+func TestExport_ConcurrentWAL(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 1000)
+	defer mock.Close()
+	mock.syncDelay = 20 * time.Millisecond
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	var progress []ExportProgress
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{
+		OnProgress: func(p ExportProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1000, result.LastFrameNo)
+	requireValidExport(t, mock, outputFile)
+	mock.syncMu.Lock()
+	require.Greater(t, mock.maxSyncInFlight, 1)
+	require.LessOrEqual(t, mock.maxSyncInFlight, walFetchConcurrency)
+	mock.syncMu.Unlock()
+
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	require.Equal(t, int64(64*1024), last.SnapshotBytes)
+	require.Equal(t, int64(64*1024), last.SnapshotSize)
+	require.Equal(t, 1000, last.Frames)
+	require.Equal(t, int64(1000*walFrameSize), last.WALBytes)
+	for i := 1; i < len(progress); i++ {
+		require.GreaterOrEqual(t, progress[i].Frames, progress[i-1].Frames)
+	}
+}
+
+func TestExport_ResumeSnapshot(t *testing.T) {
+	for _, supportRange := range []bool{true, false} {
+		t.Run(fmt.Sprintf("range=%v", supportRange), func(t *testing.T) {
+			mock := NewMockExportServer(64*1024, 10)
+			defer mock.Close()
+			mock.supportRange = supportRange
+			mock.failSnapshotAt = 10000
+			client := createTestClient(t, mock.URL)
+			outputFile := filepath.Join(t.TempDir(), "db.db")
+
+			_, err := client.Export(context.Background(), outputFile, ExportOptions{})
+			require.Error(t, err)
+			require.True(t, ExportInProgress(outputFile))
+			state, err := readExportState(outputFile)
+			require.NoError(t, err)
+			require.Equal(t, int64(10000), state.SnapshotBytes)
+
+			result, err := client.Export(context.Background(), outputFile, ExportOptions{Resume: true})
+			require.NoError(t, err)
+			require.True(t, result.Resumed)
+			require.Equal(t, []string{"bytes=10000-"}, mock.ranges)
+			requireValidExport(t, mock, outputFile)
+			require.False(t, ExportInProgress(outputFile))
+		})
+	}
+}
+
+func TestExport_ResumeWAL(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 300)
+	defer mock.Close()
+	mock.failSyncFromFrame = 129
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{})
+	require.Error(t, err)
+	state, err := readExportState(outputFile)
+	require.NoError(t, err)
+	require.True(t, state.SnapshotDone)
+	require.Equal(t, 128, state.LastFrameNo)
+
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{Resume: true})
+	require.NoError(t, err)
+	require.True(t, result.Resumed)
+	require.Equal(t, 300, result.LastFrameNo)
+	require.Empty(t, mock.ranges)
+	requireValidExport(t, mock, outputFile)
+}
+
+func TestExport_RestartsWhenGenerationChanged(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	mock.failSnapshotAt = 10000
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{})
+	require.Error(t, err)
+
+	mock.mu.Lock()
+	mock.generation = 2
+	mock.mu.Unlock()
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{Resume: true})
+	require.NoError(t, err)
+	require.True(t, result.Restarted)
+	require.False(t, result.Resumed)
+	require.Equal(t, 2, result.Generation)
+	require.Empty(t, mock.ranges)
+	requireValidExport(t, mock, outputFile)
+}
+
+func TestPull(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{WithMetadata: true})
+	require.NoError(t, err)
+
+	mock.mu.Lock()
+	mock.addFrames(150)
+	mock.mu.Unlock()
+	result, err := client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.Equal(t, PullResult{Generation: 1, LastFrameNo: 160, NewFrames: 150}, result)
+	requireValidExport(t, mock, outputFile)
+	metadata, err := readExportMetadata(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, 160, metadata.DurableFrameNum)
+
+	result, err = client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.Equal(t, 0, result.NewFrames)
+	requireValidExport(t, mock, outputFile)
+}
+
+func TestPull_AfterCheckpoint(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{WithMetadata: true})
+	require.NoError(t, err)
+	// SQLite removes the WAL once it is checkpointed into the database
+	require.NoError(t, os.Remove(outputFile+"-wal"))
+
+	mock.mu.Lock()
+	mock.addFrames(5)
+	mock.mu.Unlock()
+	result, err := client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.Equal(t, 5, result.NewFrames)
+	requireValidWAL(t, outputFile+"-wal", mock.frames[10:])
+
+	// The next pull appends to the new WAL file
+	mock.mu.Lock()
+	mock.addFrames(3)
+	mock.mu.Unlock()
+	result, err = client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.Equal(t, 3, result.NewFrames)
+	require.Equal(t, 18, result.LastFrameNo)
+	requireValidWAL(t, outputFile+"-wal", mock.frames[10:])
+
+	metadata, err := readExportMetadata(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, 18, metadata.DurableFrameNum)
+	require.Equal(t, 10, metadata.WALBase)
+
+	// And so does a pull with nothing new
+	result, err = client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.Zero(t, result.NewFrames)
+}
+
+func TestPull_ExportsAgainWhenGenerationChanged(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{WithMetadata: true})
+	require.NoError(t, err)
+
+	mock.mu.Lock()
+	mock.generation = 2
+	mock.snapshot = bytes.Repeat([]byte("NEWSNAPS"), 1024)
+	mock.frames = nil
+	mock.addFrames(3)
+	mock.mu.Unlock()
+	result, err := client.Pull(context.Background(), outputFile, "")
+	require.NoError(t, err)
+	require.True(t, result.Exported)
+	require.Equal(t, 2, result.Generation)
+	requireValidExport(t, mock, outputFile)
+}
+
+func TestPull_RequiresMetadata(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{})
+	require.NoError(t, err)
+	_, err = client.Pull(context.Background(), outputFile, "")
+	require.Error(t, err)
+}
+
+// sqliteSnapshot returns a database of the given number of pages in WAL mode,
+// filled with a byte identifying each page.
+func sqliteSnapshot(pages int) []byte {
+	snapshot := make([]byte, pages*walPageSize)
+	for pgno := 1; pgno <= pages; pgno++ {
+		for i := (pgno - 1) * walPageSize; i < pgno*walPageSize; i++ {
+			snapshot[i] = byte(pgno)
+		}
+	}
+	copy(snapshot, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(snapshot[16:18], walPageSize)
+	snapshot[18], snapshot[19] = 2, 2
+	snapshot[21], snapshot[22], snapshot[23] = 64, 32, 32
+	binary.BigEndian.PutUint32(snapshot[24:28], 7)
+	binary.BigEndian.PutUint32(snapshot[28:32], uint32(pages))
+	binary.BigEndian.PutUint32(snapshot[92:96], 7)
+	return snapshot
+}
+
+func TestExport_Checkpoint(t *testing.T) {
+	mock := NewMockExportServer(0, 25)
+	defer mock.Close()
+	mock.snapshot = sqliteSnapshot(12)
+	// An uncommitted transaction must not be applied
+	uncommitted := make([]byte, walFrameSize)
+	binary.BigEndian.PutUint32(uncommitted[0:4], 3)
+	mock.frames = append(mock.frames, uncommitted)
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	result, err := client.Export(context.Background(), outputFile, ExportOptions{Checkpoint: true})
+	require.NoError(t, err)
+	require.Equal(t, 25, result.CheckpointedFrames)
+	require.NoFileExists(t, outputFile+"-wal")
+	require.False(t, ExportInProgress(outputFile))
+
+	db, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	// The frames commit a database of 10 pages, the last 5 frames updating
+	// pages 1 to 5 again
+	require.Len(t, db, 10*walPageSize)
+	require.Equal(t, []byte{1, 1}, db[18:20])
+	require.Equal(t, uint32(10), binary.BigEndian.Uint32(db[28:32]))
+	require.Equal(t, db[24:28], db[92:96])
+	for pgno := 1; pgno <= 10; pgno++ {
+		page := db[(pgno-1)*walPageSize : pgno*walPageSize]
+		frame := mock.frames[10+pgno-1]
+		if pgno <= 5 {
+			frame = mock.frames[20+pgno-1]
+		}
+		require.Equal(t, uint32(pgno), binary.BigEndian.Uint32(frame[0:4]))
+		data := frame[24:]
+		if pgno == 1 {
+			// Skip the database header, which is updated
+			page, data = page[100:], data[100:]
+		}
+		require.Equal(t, data, page, "page %d", pgno)
+	}
+}
+
+func TestCheckpointWAL_InvalidChecksum(t *testing.T) {
+	mock := NewMockExportServer(0, 10)
+	defer mock.Close()
+	mock.snapshot = sqliteSnapshot(12)
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{})
+	require.NoError(t, err)
+	wal, err := os.OpenFile(outputFile+"-wal", os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = wal.WriteAt([]byte{0xff}, walHeaderSize+4*walFrameSize+100)
+	require.NoError(t, err)
+	require.NoError(t, wal.Close())
+
+	_, err = checkpointWAL(outputFile)
+	require.ErrorContains(t, err, "WAL frame 5: checksum mismatch")
+	db, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, mock.snapshot, db)
+	require.FileExists(t, outputFile+"-wal")
+}
+
+// testObject is an ExportObject writing to a file.
+type testObject struct {
+	*os.File
+	closed, aborted bool
+}
+
+func (o *testObject) Close() error {
+	o.closed = true
+	return o.File.Close()
+}
+
+func (o *testObject) Abort() error {
+	o.aborted = true
+	return o.File.Close()
+}
+
+func TestExportStream(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 300)
+	defer mock.Close()
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	objects := map[string]*testObject{}
+	result, err := client.ExportStream(context.Background(), ExportOptions{WithMetadata: true}, func(suffix string) (ExportObject, error) {
+		file, err := os.Create(outputFile + suffix)
+		if err != nil {
+			return nil, err
+		}
+		objects[suffix] = &testObject{File: file}
+		return objects[suffix], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, ExportResult{Generation: 1, LastFrameNo: 300}, result)
+	require.Len(t, objects, 3)
+	for suffix, object := range objects {
+		require.True(t, object.closed, suffix)
+		require.False(t, object.aborted, suffix)
+	}
+	requireValidExport(t, mock, outputFile)
+	metadata, err := readExportMetadata(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, 300, metadata.DurableFrameNum)
+	require.False(t, ExportInProgress(outputFile))
+}
+
+func TestExportStream_AbortsOnFailure(t *testing.T) {
+	mock := NewMockExportServer(64*1024, 10)
+	defer mock.Close()
+	mock.failSnapshotAt = 10000
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+
+	objects := map[string]*testObject{}
+	_, err := client.ExportStream(context.Background(), ExportOptions{}, func(suffix string) (ExportObject, error) {
+		file, err := os.Create(outputFile + suffix)
+		if err != nil {
+			return nil, err
+		}
+		objects[suffix] = &testObject{File: file}
+		return objects[suffix], nil
+	})
+	require.Error(t, err)
+	require.Len(t, objects, 1)
+	require.True(t, objects[""].aborted)
+	require.False(t, objects[""].closed)
+}
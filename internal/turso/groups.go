@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -28,20 +29,20 @@ type Group struct {
 	Status    GroupStatus `json:"status"`
 }
 
-func (d *GroupsClient) List() ([]Group, error) {
-	r, err := d.client.Get(d.URL(""), nil)
+func (d *GroupsClient) List(ctx context.Context) ([]Group, error) {
+	r, err := d.client.Get(ctx, d.URL(""), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get groups: %s", err)
+		return nil, fmt.Errorf("failed to get groups: %w", err)
 	}
 	defer r.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get database groups: received status code %w", parseResponseError(r))
+		return nil, fmt.Errorf("failed to get database groups: %w", parseResponseError(r))
 	}
 
 	type ListResponse struct {
@@ -51,8 +52,8 @@ func (d *GroupsClient) List() ([]Group, error) {
 	return resp.Groups, err
 }
 
-func (d *GroupsClient) Get(name string) (Group, error) {
-	r, err := d.client.Get(d.URL("/"+name), nil)
+func (d *GroupsClient) Get(ctx context.Context, name string) (Group, error) {
+	r, err := d.client.Get(ctx, d.URL("/"+name), nil)
 	if err != nil {
 		return Group{}, fmt.Errorf("failed to get group %s: %w", name, err)
 	}
@@ -60,15 +61,15 @@ func (d *GroupsClient) Get(name string) (Group, error) {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return Group{}, notMemberErr(org)
+		return Group{}, notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return Group{}, fmt.Errorf("group %s was not found", name)
+		return Group{}, apiErrorf(r, "group %s was not found", name)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return Group{}, fmt.Errorf("failed to get database group: received status code %w", parseResponseError(r))
+		return Group{}, fmt.Errorf("failed to get database group: %w", parseResponseError(r))
 	}
 
 	type Response struct {
@@ -89,9 +90,9 @@ func (g *GroupConfig) IsDeleteProtected() bool {
 	return *g.DeleteProtection
 }
 
-func (g *GroupsClient) GetConfig(group string) (GroupConfig, error) {
+func (g *GroupsClient) GetConfig(ctx context.Context, group string) (GroupConfig, error) {
 	url := g.URL(fmt.Sprintf("/%s/configuration", group))
-	r, err := g.client.Get(url, nil)
+	r, err := g.client.Get(ctx, url, nil)
 	if err != nil {
 		return GroupConfig{}, fmt.Errorf("failed to get group: %w", err)
 	}
@@ -99,25 +100,25 @@ func (g *GroupsClient) GetConfig(group string) (GroupConfig, error) {
 
 	org := g.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return GroupConfig{}, notMemberErr(org)
+		return GroupConfig{}, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
 		err = parseResponseError(r)
-		return GroupConfig{}, fmt.Errorf("failed to get config for group: %d %s", r.StatusCode, err)
+		return GroupConfig{}, fmt.Errorf("failed to get config for group: %w", err)
 	}
 
 	return unmarshal[GroupConfig](r)
 }
 
-func (g *GroupsClient) UpdateConfig(group string, config GroupConfig) error {
+func (g *GroupsClient) UpdateConfig(ctx context.Context, group string, config GroupConfig) error {
 	url := g.URL(fmt.Sprintf("/%s/configuration", group))
 	body, err := marshal(config)
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	r, err := g.client.Patch(url, body)
+	r, err := g.client.Patch(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to patch group: %w", err)
 	}
@@ -125,61 +126,61 @@ func (g *GroupsClient) UpdateConfig(group string, config GroupConfig) error {
 
 	org := g.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
 		err = parseResponseError(r)
-		return fmt.Errorf("failed to patch config for group: %d %s", r.StatusCode, err)
+		return fmt.Errorf("failed to patch config for group: %w", err)
 	}
 
 	return nil
 }
 
-func (d *GroupsClient) Delete(group string) error {
+func (d *GroupsClient) Delete(ctx context.Context, group string) error {
 	url := d.URL("/" + group)
-	r, err := d.client.Delete(url, nil)
+	r, err := d.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete group: %s", err)
+		return fmt.Errorf("failed to delete group: %w", err)
 	}
 	defer r.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("group %s not found. List known databases using %s", internal.Emph(group), internal.Emph("turso group list"))
+		return apiErrorf(r, "group %s not found. List known databases using %s", internal.Emph(group), internal.Emph("turso group list"))
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete group: received status code %w", parseResponseError(r))
+		return fmt.Errorf("failed to delete group: %w", parseResponseError(r))
 	}
 
 	return nil
 }
 
-func (d *GroupsClient) Create(name, location, version string) error {
+func (d *GroupsClient) Create(ctx context.Context, name, location, version string) error {
 	type Body struct{ Name, Location, Version string }
 	body, err := marshal(Body{name, location, version})
 	if err != nil {
 		return fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	res, err := d.client.Post(d.URL(""), body)
+	res, err := d.client.Post(ctx, d.URL(""), body)
 	if err != nil {
-		return fmt.Errorf("failed to create group: %s", err)
+		return fmt.Errorf("failed to create group: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode == http.StatusUnprocessableEntity {
-		return fmt.Errorf("group name '%s' is not available", name)
+		return apiErrorf(res, "group name '%s' is not available", name)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -188,16 +189,16 @@ func (d *GroupsClient) Create(name, location, version string) error {
 	return nil
 }
 
-func (d *GroupsClient) Unarchive(name string) error {
-	res, err := d.client.Post(d.URL("/"+name+"/unarchive"), nil)
+func (d *GroupsClient) Unarchive(ctx context.Context, name string) error {
+	res, err := d.client.Post(ctx, d.URL("/"+name+"/unarchive"), nil)
 	if err != nil {
-		return fmt.Errorf("failed to unarchive group: %s", err)
+		return fmt.Errorf("failed to unarchive group: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -206,16 +207,16 @@ func (d *GroupsClient) Unarchive(name string) error {
 	return nil
 }
 
-func (d *GroupsClient) AddLocation(name, location string) error {
-	res, err := d.client.Post(d.URL("/"+name+"/locations/"+location), nil)
+func (d *GroupsClient) AddLocation(ctx context.Context, name, location string) error {
+	res, err := d.client.Post(ctx, d.URL("/"+name+"/locations/"+location), nil)
 	if err != nil {
-		return fmt.Errorf("failed to post group location request: %s", err)
+		return fmt.Errorf("failed to post group location request: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -224,16 +225,16 @@ func (d *GroupsClient) AddLocation(name, location string) error {
 	return nil
 }
 
-func (d *GroupsClient) RemoveLocation(name, location string) error {
-	res, err := d.client.Delete(d.URL("/"+name+"/locations/"+location), nil)
+func (d *GroupsClient) RemoveLocation(ctx context.Context, name, location string) error {
+	res, err := d.client.Delete(ctx, d.URL("/"+name+"/locations/"+location), nil)
 	if err != nil {
-		return fmt.Errorf("failed to post group location request: %s", err)
+		return fmt.Errorf("failed to post group location request: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -242,16 +243,16 @@ func (d *GroupsClient) RemoveLocation(name, location string) error {
 	return nil
 }
 
-func (d *GroupsClient) WaitLocation(name, location string) error {
-	res, err := d.client.Get(d.URL("/"+name+"/locations/"+location+"/wait"), nil)
+func (d *GroupsClient) WaitLocation(ctx context.Context, name, location string) error {
+	res, err := d.client.Get(ctx, d.URL("/"+name+"/locations/"+location+"/wait"), nil)
 	if err != nil {
-		return fmt.Errorf("failed to send wait location request: %s", err)
+		return fmt.Errorf("failed to send wait location request: %w", err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(res, org)
 	}
 
 	if res.StatusCode != http.StatusOK {
@@ -274,6 +275,7 @@ type GroupTokenRequest struct {
 }
 
 func (d *GroupsClient) Token(
+	ctx context.Context,
 	group string,
 	expiration string,
 	readOnly bool,
@@ -292,7 +294,7 @@ func (d *GroupsClient) Token(
 		return "", fmt.Errorf("could not serialize request body: %w", err)
 	}
 
-	r, err := d.client.Post(url, body)
+	r, err := d.client.Post(ctx, url, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to get database token: %w", err)
 	}
@@ -300,7 +302,7 @@ func (d *GroupsClient) Token(
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return "", notMemberErr(org)
+		return "", notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -315,9 +317,9 @@ func (d *GroupsClient) Token(
 	return data.Jwt, nil
 }
 
-func (d *GroupsClient) Rotate(group string) error {
+func (d *GroupsClient) Rotate(ctx context.Context, group string) error {
 	url := d.URL(fmt.Sprintf("/%s/auth/rotate", group))
-	r, err := d.client.Post(url, nil)
+	r, err := d.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to rotate database keys: %w", err)
 	}
@@ -325,7 +327,7 @@ func (d *GroupsClient) Rotate(group string) error {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -335,7 +337,7 @@ func (d *GroupsClient) Rotate(group string) error {
 	return nil
 }
 
-func (d *GroupsClient) Rename(oldName, newName string) error {
+func (d *GroupsClient) Rename(ctx context.Context, oldName, newName string) error {
 	type Body struct{ Name string }
 	body, err := marshal(Body{Name: newName})
 	if err != nil {
@@ -343,7 +345,7 @@ func (d *GroupsClient) Rename(oldName, newName string) error {
 	}
 
 	url := d.URL(fmt.Sprintf("/%s/rename", oldName))
-	r, err := d.client.Post(url, body)
+	r, err := d.client.Post(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to rename group: %w", err)
 	}
@@ -351,7 +353,7 @@ func (d *GroupsClient) Rename(oldName, newName string) error {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -361,7 +363,7 @@ func (d *GroupsClient) Rename(oldName, newName string) error {
 	return nil
 }
 
-func (d *GroupsClient) Transfer(group string, to string) error {
+func (d *GroupsClient) Transfer(ctx context.Context, group string, to string) error {
 	type Body struct {
 		Organization string `json:"organization"`
 	}
@@ -371,7 +373,7 @@ func (d *GroupsClient) Transfer(group string, to string) error {
 	}
 
 	url := d.URL(fmt.Sprintf("/%s/transfer", group))
-	r, err := d.client.Post(url, body)
+	r, err := d.client.Post(ctx, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to transfer group: %w", err)
 	}
@@ -379,7 +381,7 @@ func (d *GroupsClient) Transfer(group string, to string) error {
 
 	org := d.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -395,9 +397,9 @@ type AwsMigrationInfo struct {
 	Comment string `json:"comment"`
 }
 
-func (d *GroupsClient) GetAwsMigrationInfo(group string) (AwsMigrationInfo, error) {
+func (d *GroupsClient) GetAwsMigrationInfo(ctx context.Context, group string) (AwsMigrationInfo, error) {
 	url := d.URL(fmt.Sprintf("/%s/aws/migration/info", group))
-	r, err := d.client.Get(url, nil)
+	r, err := d.client.Get(ctx, url, nil)
 	if err != nil {
 		return AwsMigrationInfo{}, fmt.Errorf("failed to get group migration info: %w", err)
 	}
@@ -414,9 +416,9 @@ func (d *GroupsClient) GetAwsMigrationInfo(group string) (AwsMigrationInfo, erro
 	return result, nil
 }
 
-func (d *GroupsClient) StartAwsMigration(group string) error {
+func (d *GroupsClient) StartAwsMigration(ctx context.Context, group string) error {
 	url := d.URL(fmt.Sprintf("/%s/aws/migration/start", group))
-	r, err := d.client.Post(url, nil)
+	r, err := d.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start group migration: %w", err)
 	}
@@ -429,9 +431,9 @@ func (d *GroupsClient) StartAwsMigration(group string) error {
 	return nil
 }
 
-func (d *GroupsClient) AbortAwsMigration(group string) error {
+func (d *GroupsClient) AbortAwsMigration(ctx context.Context, group string) error {
 	url := d.URL(fmt.Sprintf("/%s/aws/migration/abort", group))
-	r, err := d.client.Post(url, nil)
+	r, err := d.client.Post(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to abort group migration: %w", err)
 	}
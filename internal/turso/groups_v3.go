@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,8 +15,8 @@ func (g *GroupsV3Client) url(orgID, suffix string) string {
 	return "/v3/organizations/" + orgID + "/groups" + suffix
 }
 
-func (g *GroupsV3Client) List(orgID string) ([]Group, error) {
-	r, err := g.client.Get(g.url(orgID, ""), nil)
+func (g *GroupsV3Client) List(ctx context.Context, orgID string) ([]Group, error) {
+	r, err := g.client.Get(ctx, g.url(orgID, ""), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list groups: %w", err)
 	}
@@ -35,8 +36,8 @@ func (g *GroupsV3Client) List(orgID string) ([]Group, error) {
 	return resp.Groups, nil
 }
 
-func (g *GroupsV3Client) Get(orgID, groupID string) (Group, error) {
-	r, err := g.client.Get(g.url(orgID, "/"+groupID), nil)
+func (g *GroupsV3Client) Get(ctx context.Context, orgID, groupID string) (Group, error) {
+	r, err := g.client.Get(ctx, g.url(orgID, "/"+groupID), nil)
 	if err != nil {
 		return Group{}, fmt.Errorf("failed to get group: %w", err)
 	}
@@ -57,6 +58,7 @@ func (g *GroupsV3Client) Get(orgID, groupID string) (Group, error) {
 }
 
 func (g *GroupsV3Client) Token(
+	ctx context.Context,
 	orgID, groupID string,
 	expiration string,
 	readOnly bool,
@@ -79,7 +81,7 @@ func (g *GroupsV3Client) Token(
 	if err != nil {
 		return "", fmt.Errorf("could not serialize request body: %w", err)
 	}
-	r, err := g.client.Post(path, body)
+	r, err := g.client.Post(ctx, path, body)
 	if err != nil {
 		return "", fmt.Errorf("failed to get group token: %w", err)
 	}
@@ -0,0 +1,167 @@
+package turso
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// importState is the progress of the multipart upload of a database file,
+// saved next to the file so that an interrupted import can be resumed against
+// the same draft database.
+type importState struct {
+	Database string `json:"database"`
+	Hostname string `json:"hostname"`
+	Group    string `json:"group"`
+	// EncryptionCipher is the cipher of the remote encryption, if any. The
+	// key is not saved, it must be given again to resume.
+	EncryptionCipher string `json:"encryption_cipher,omitempty"`
+
+	UploadID    string          `json:"upload_id"`
+	ChunkSize   int64           `json:"chunk_size"`
+	Fingerprint fileFingerprint `json:"fingerprint"`
+	// CompletedChunks are the IDs of the chunks the server acknowledged.
+	CompletedChunks []int `json:"completed_chunks"`
+
+	// path is where the state is saved, or empty when it is not.
+	path string
+}
+
+// fileFingerprint identifies the content of a file, to detect that it
+// changed since its upload started.
+type fileFingerprint struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	// SHA256 is the hash of the first and the last MiB of the file. Hashing
+	// a whole database would take as long as uploading it from a fast link.
+	SHA256 string `json:"sha256"`
+}
+
+const fingerprintSampleSize = 1024 * 1024
+
+func fingerprintFile(file *os.File) (fileFingerprint, error) {
+	stat, err := file.Stat()
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+	hash := sha256.New()
+	sample := make([]byte, min(stat.Size(), fingerprintSampleSize))
+	for _, offset := range []int64{0, stat.Size() - int64(len(sample))} {
+		if _, err := file.ReadAt(sample, offset); err != nil && !errors.Is(err, io.EOF) {
+			return fileFingerprint{}, fmt.Errorf("failed to read %s: %w", file.Name(), err)
+		}
+		hash.Write(sample)
+	}
+	return fileFingerprint{
+		Size:    stat.Size(),
+		ModTime: stat.ModTime().UTC(),
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func (f fileFingerprint) equal(other fileFingerprint) bool {
+	return f.Size == other.Size && f.ModTime.Equal(other.ModTime) && f.SHA256 == other.SHA256
+}
+
+// ImportStatePath returns the path of the file where the progress of an
+// import of file is saved.
+func ImportStatePath(file string) string {
+	return file + "-import-state"
+}
+
+// ImportInProgress reports whether an interrupted import of file can be
+// resumed.
+func ImportInProgress(file string) bool {
+	_, err := os.Stat(ImportStatePath(file))
+	return err == nil
+}
+
+// InterruptedImport describes an import that can be resumed.
+type InterruptedImport struct {
+	Database string
+	Group    string
+	// Encrypted is set when the database uses remote encryption, whose key
+	// must be given again.
+	Encrypted      bool
+	UploadedChunks int
+	TotalChunks    int
+}
+
+// FindInterruptedImport returns the interrupted import of file, or nil if
+// there is none.
+func FindInterruptedImport(file string) (*InterruptedImport, error) {
+	state, err := readImportState(file)
+	if err != nil || state == nil {
+		return nil, err
+	}
+	return &InterruptedImport{
+		Database:       state.Database,
+		Group:          state.Group,
+		Encrypted:      state.EncryptionCipher != "",
+		UploadedChunks: len(state.CompletedChunks),
+		TotalChunks:    state.totalChunks(),
+	}, nil
+}
+
+// readImportState returns the saved progress of an import of file, or nil if
+// there is none.
+func readImportState(file string) (*importState, error) {
+	path := ImportStatePath(file)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import state: %w", err)
+	}
+	state := &importState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid import state %s: %w", path, err)
+	}
+	if state.UploadID == "" || state.ChunkSize <= 0 || state.Database == "" {
+		return nil, fmt.Errorf("invalid import state %s", path)
+	}
+	state.path = path
+	return state, nil
+}
+
+func (s *importState) totalChunks() int {
+	return int((s.Fingerprint.Size + s.ChunkSize - 1) / s.ChunkSize)
+}
+
+// uploadedBytes returns the size of the completed chunks.
+func (s *importState) uploadedBytes() int64 {
+	uploaded := int64(0)
+	for _, chunkID := range s.CompletedChunks {
+		uploaded += min(s.ChunkSize, s.Fingerprint.Size-int64(chunkID)*s.ChunkSize)
+	}
+	return uploaded
+}
+
+// save replaces the saved state atomically. It does nothing when the state
+// is not saved.
+func (s *importState) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to save import state: %w", err)
+	}
+	return nil
+}
+
+func (s *importState) remove() {
+	if s.path != "" {
+		_ = os.Remove(s.path)
+	}
+}
@@ -1,7 +1,7 @@
 package turso
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -24,20 +24,20 @@ func (e *CreateInstanceLocationError) Error() string {
 	return e.err
 }
 
-func (i *InstancesClient) List(db string) ([]Instance, error) {
-	r, err := i.client.Get(i.URL(db, ""), nil)
+func (i *InstancesClient) List(ctx context.Context, db string) ([]Instance, error) {
+	r, err := i.client.Get(ctx, i.URL(db, ""), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list instances of %s: %s", db, err)
+		return nil, fmt.Errorf("failed to list instances of %s: %w", db, err)
 	}
 	defer r.Body.Close()
 
 	org := i.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response with status code %d", r.StatusCode)
+		return nil, parseResponseError(r)
 	}
 
 	type ListResponse struct{ Instances []Instance }
@@ -49,37 +49,37 @@ func (i *InstancesClient) List(db string) ([]Instance, error) {
 	return resp.Instances, nil
 }
 
-func (i *InstancesClient) Delete(db, instance string) error {
+func (i *InstancesClient) Delete(ctx context.Context, db, instance string) error {
 	url := i.URL(db, "/"+instance)
-	r, err := i.client.Delete(url, nil)
+	r, err := i.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to destroy instances %s of %s: %s", instance, db, err)
+		return fmt.Errorf("failed to destroy instances %s of %s: %w", instance, db, err)
 	}
 	defer r.Body.Close()
 
 	org := i.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusBadRequest {
 		body, _ := unmarshal[struct{ Error string }](r)
-		return errors.New(body.Error)
+		return newAPIError(r, body.Error)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
 		body, _ := unmarshal[struct{ Error string }](r)
-		return errors.New(body.Error)
+		return newAPIError(r, body.Error)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return fmt.Errorf("response with status code %d", r.StatusCode)
+		return parseResponseError(r)
 	}
 
 	return nil
 }
 
-func (d *InstancesClient) Create(dbName, location string) (*Instance, error) {
+func (d *InstancesClient) Create(ctx context.Context, dbName, location string) (*Instance, error) {
 	type Body struct {
 		Location string
 	}
@@ -89,15 +89,15 @@ func (d *InstancesClient) Create(dbName, location string) (*Instance, error) {
 	}
 
 	url := d.URL(dbName, "")
-	res, err := d.client.Post(url, body)
+	res, err := d.client.Post(ctx, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new instances for %s: %s", dbName, err)
+		return nil, fmt.Errorf("failed to create new instances for %s: %w", dbName, err)
 	}
 	defer res.Body.Close()
 
 	org := d.client.Org
 	if isNotMemberErr(res.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(res, org)
 	}
 
 	if res.StatusCode >= http.StatusInternalServerError {
@@ -116,31 +116,31 @@ func (d *InstancesClient) Create(dbName, location string) (*Instance, error) {
 	return &data.Instance, nil
 }
 
-func (i *InstancesClient) Wait(db, instance string) error {
+func (i *InstancesClient) Wait(ctx context.Context, db, instance string) error {
 	url := i.URL(db, "/"+instance+"/wait")
-	r, err := i.client.Get(url, nil)
+	r, err := i.client.Get(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to wait for instance %s to of %s be ready: %s", instance, db, err)
+		return fmt.Errorf("failed to wait for instance %s to of %s be ready: %w", instance, db, err)
 	}
 	defer r.Body.Close()
 
 	org := i.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return notMemberErr(org)
+		return notMemberErr(r, org)
 	}
 
 	if r.StatusCode == http.StatusBadRequest {
 		body, _ := unmarshal[struct{ Error string }](r)
-		return errors.New(body.Error)
+		return newAPIError(r, body.Error)
 	}
 
 	if r.StatusCode == http.StatusNotFound {
 		body, _ := unmarshal[struct{ Error string }](r)
-		return errors.New(body.Error)
+		return newAPIError(r, body.Error)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return fmt.Errorf("response with status code %d", r.StatusCode)
+		return parseResponseError(r)
 	}
 
 	return nil
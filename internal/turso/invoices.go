@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -18,8 +19,8 @@ type Invoice struct {
 	HostedInvoiceUrl string `json:"hosted_invoice_url"`
 }
 
-func (i *InvoicesClient) List(invoiceType string) ([]Invoice, error) {
-	r, err := i.client.Get(i.URL(fmt.Sprintf("?type=%s", invoiceType)), nil)
+func (i *InvoicesClient) List(ctx context.Context, invoiceType string) ([]Invoice, error) {
+	r, err := i.client.Get(ctx, i.URL(fmt.Sprintf("?type=%s", invoiceType)), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invoices: %w", err)
 	}
@@ -27,11 +28,11 @@ func (i *InvoicesClient) List(invoiceType string) ([]Invoice, error) {
 
 	org := i.client.Org
 	if isNotMemberErr(r.StatusCode, org) {
-		return nil, notMemberErr(org)
+		return nil, notMemberErr(r, org)
 	}
 
 	if r.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get invoices: received status code %w", parseResponseError(r))
+		return nil, fmt.Errorf("failed to get invoices: %w", parseResponseError(r))
 	}
 
 	type ListResponse struct {
@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -27,15 +28,15 @@ type LocationResponse struct {
 	Closest     []Location
 }
 
-func (c *LocationsClient) Get(location string) (LocationResponse, error) {
-	r, err := c.client.Get("/v1/locations/"+location, nil)
+func (c *LocationsClient) Get(ctx context.Context, location string) (LocationResponse, error) {
+	r, err := c.client.Get(ctx, "/v1/locations/"+location, nil)
 	if err != nil {
 		return LocationResponse{}, fmt.Errorf("failed to request location %s: %w", location, err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		return LocationResponse{}, fmt.Errorf("failed to get location %s: %s", location, r.Status)
+		return LocationResponse{}, fmt.Errorf("failed to get location %s: %w", location, parseResponseError(r))
 	}
 
 	data, err := unmarshal[struct {
@@ -53,10 +54,10 @@ type ClosestLocationResponse struct {
 	Server string
 }
 
-func (c *LocationsClient) Closest() (string, error) {
-	r, err := c.client.Get("https://region.turso.io", nil)
+func (c *LocationsClient) Closest(ctx context.Context) (string, error) {
+	r, err := c.client.Get(ctx, "https://region.turso.io", nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to request closest: %s", err)
+		return "", fmt.Errorf("failed to request closest: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -73,9 +74,9 @@ func (c *LocationsClient) Closest() (string, error) {
 	return data.Server, nil
 }
 
-func ProbeLocation(location string) *time.Duration {
+func ProbeLocation(ctx context.Context, location string) *time.Duration {
 	client := &http.Client{Timeout: 2 * time.Second}
-	req, err := http.NewRequest("GET", "http://region.turso.io:8080/", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://region.turso.io:8080/", nil)
 	if err != nil {
 		return nil
 	}
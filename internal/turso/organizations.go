@@ -2,8 +2,8 @@ package turso
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,10 +24,10 @@ type Organization struct {
 	Overages bool   `json:"overages,omitempty"`
 }
 
-func (c *OrganizationsClient) List() ([]Organization, error) {
-	r, err := c.client.Get("/v2/organizations", nil)
+func (c *OrganizationsClient) List(ctx context.Context) ([]Organization, error) {
+	r, err := c.client.Get(ctx, "/v2/organizations", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request organizations: %s", err)
+		return nil, fmt.Errorf("failed to request organizations: %w", err)
 	}
 	defer r.Body.Close()
 
@@ -47,24 +47,24 @@ func (c *OrganizationsClient) List() ([]Organization, error) {
 	return data.Orgs, nil
 }
 
-func (c *OrganizationsClient) Create(name string, stripeId string, dryRun bool) (Organization, error) {
+func (c *OrganizationsClient) Create(ctx context.Context, name string, stripeId string, dryRun bool) (Organization, error) {
 	body, err := marshal(Organization{Name: name, StripeID: stripeId})
 	if err != nil {
-		return Organization{}, fmt.Errorf("failed to marshall create org request body: %s", err)
+		return Organization{}, fmt.Errorf("failed to marshall create org request body: %w", err)
 	}
 
-	r, err := c.client.Post(fmt.Sprintf("/v1/organizations?dry_run=%v", dryRun), body)
+	r, err := c.client.Post(ctx, fmt.Sprintf("/v1/organizations?dry_run=%v", dryRun), body)
 	if err != nil {
-		return Organization{}, fmt.Errorf("failed to post organization: %s", err)
+		return Organization{}, fmt.Errorf("failed to post organization: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusConflict {
-		return Organization{}, fmt.Errorf("failed to create organization %s: name already exists", internal.Emph(name))
+		return Organization{}, apiErrorf(r, "failed to create organization %s: name already exists", internal.Emph(name))
 	}
 
 	if r.StatusCode == http.StatusPaymentRequired {
-		return Organization{}, fmt.Errorf("failed to create organization %s: you need to upgrade your plan", internal.Emph(name))
+		return Organization{}, apiErrorf(r, "failed to create organization %s: you need to upgrade your plan", internal.Emph(name))
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -79,15 +79,15 @@ func (c *OrganizationsClient) Create(name string, stripeId string, dryRun bool)
 	return data.Org, nil
 }
 
-func (c *OrganizationsClient) Delete(slug string) error {
-	r, err := c.client.Delete("/v1/organizations/"+slug, nil)
+func (c *OrganizationsClient) Delete(ctx context.Context, slug string) error {
+	r, err := c.client.Delete(ctx, "/v1/organizations/"+slug, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete organization: %s", err)
+		return fmt.Errorf("failed to delete organization: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("could not find organization %s", slug)
+		return apiErrorf(r, "could not find organization %s", slug)
 	}
 
 	switch r.StatusCode {
@@ -96,7 +96,7 @@ func (c *OrganizationsClient) Delete(slug string) error {
 	case http.StatusBadRequest:
 		return parseResponseError(r)
 	case http.StatusForbidden:
-		return fmt.Errorf("you do not have permission to delete organization %s", slug)
+		return apiErrorf(r, "you do not have permission to delete organization %s", slug)
 	default:
 		return fmt.Errorf("failed to delete organization: %w", parseResponseError(r))
 	}
@@ -122,21 +122,20 @@ type OrgUsageResponse struct {
 	OrgUsage OrgUsage `json:"organization"`
 }
 
-func (c *OrganizationsClient) Usage() (OrgUsage, error) {
+func (c *OrganizationsClient) Usage(ctx context.Context) (OrgUsage, error) {
 	prefix := "/v1"
 	if c.client.Org != "" {
 		prefix = "/v1/organizations/" + c.client.Org
 	}
 
-	r, err := c.client.Get(prefix+"/usage", nil)
+	r, err := c.client.Get(ctx, prefix+"/usage", nil)
 	if err != nil {
 		return OrgUsage{}, fmt.Errorf("failed to get database usage: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[string](r)
-		return OrgUsage{}, fmt.Errorf("failed to get database usage: %d %s", r.StatusCode, err)
+		return OrgUsage{}, fmt.Errorf("failed to get database usage: %w", parseResponseError(r))
 	}
 
 	body, err := unmarshal[OrgUsageResponse](r)
@@ -152,21 +151,20 @@ type OrgLocationsResponse struct {
 	Locations OrgLocations
 }
 
-func (c *OrganizationsClient) Locations() (OrgLocations, error) {
+func (c *OrganizationsClient) Locations(ctx context.Context) (OrgLocations, error) {
 	prefix := "/v2"
 	if c.client.Org != "" {
 		prefix = "/v2/organizations/" + c.client.Org
 	}
 
-	r, err := c.client.Get(prefix+"/locations", nil)
+	r, err := c.client.Get(ctx, prefix+"/locations", nil)
 	if err != nil {
 		return OrgLocations{}, fmt.Errorf("failed to get org locations: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[string](r)
-		return OrgLocations{}, fmt.Errorf("failed to get locations: %d %s", r.StatusCode, err)
+		return OrgLocations{}, fmt.Errorf("failed to get locations: %w", parseResponseError(r))
 	}
 
 	body, err := unmarshal[OrgLocationsResponse](r)
@@ -196,20 +194,19 @@ type OrgJwksTemplateParams struct {
 	Permissions []flags.FineGrainedPermissions `json:"permissions"`
 }
 
-func (c *OrganizationsClient) JwksTemplate(org string, param OrgJwksTemplateParams) (string, error) {
+func (c *OrganizationsClient) JwksTemplate(ctx context.Context, org string, param OrgJwksTemplateParams) (string, error) {
 	request, err := marshal(param)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal jwks template request body: %s", err)
+		return "", fmt.Errorf("failed to marshal jwks template request body: %w", err)
 	}
-	r, err := c.client.Get(fmt.Sprintf("/v2/organizations/%v/jwks-template", org), request)
+	r, err := c.client.Get(ctx, fmt.Sprintf("/v2/organizations/%v/jwks-template", org), request)
 	if err != nil {
 		return "", fmt.Errorf("failed to get org jwks template: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[struct{ Error string }](r)
-		return "", fmt.Errorf("failed to get org jwks template: %s", err.Error)
+		return "", fmt.Errorf("failed to get org jwks template: %w", parseResponseError(r))
 	}
 
 	body, err := unmarshal[OrgJwksTemplate](r)
@@ -223,16 +220,15 @@ func (c *OrganizationsClient) JwksTemplate(org string, param OrgJwksTemplatePara
 	return string(json), nil
 }
 
-func (c *OrganizationsClient) ListJwks(org string) ([]OrgJwks, error) {
-	r, err := c.client.Get(fmt.Sprintf("/v2/organizations/%v/jwks", org), nil)
+func (c *OrganizationsClient) ListJwks(ctx context.Context, org string) ([]OrgJwks, error) {
+	r, err := c.client.Get(ctx, fmt.Sprintf("/v2/organizations/%v/jwks", org), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get org jwks: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[struct{ Error string }](r)
-		return nil, fmt.Errorf("failed to get org jwks: %s", err.Error)
+		return nil, fmt.Errorf("failed to get org jwks: %w", parseResponseError(r))
 	}
 
 	body, err := unmarshal[OrgJwksList](r)
@@ -242,51 +238,49 @@ func (c *OrganizationsClient) ListJwks(org string) ([]OrgJwks, error) {
 	return body.Jwks, nil
 }
 
-func (c *OrganizationsClient) SaveJwks(org string, name, url, region string) error {
+func (c *OrganizationsClient) SaveJwks(ctx context.Context, org string, name, url, region string) error {
 	body, err := marshal(map[string]any{"jwks_url": url, "region": region})
 	if err != nil {
-		return fmt.Errorf("failed to marshal save jwks request body: %s", err)
+		return fmt.Errorf("failed to marshal save jwks request body: %w", err)
 	}
-	r, err := c.client.Put(fmt.Sprintf("/v2/organizations/%v/jwks/%v", org, name), body)
+	r, err := c.client.Put(ctx, fmt.Sprintf("/v2/organizations/%v/jwks/%v", org, name), body)
 	if err != nil {
 		return fmt.Errorf("failed to save org jwks: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[struct{ Error string }](r)
-		return fmt.Errorf("failed to save org jwks: %v", err.Error)
+		return fmt.Errorf("failed to save org jwks: %w", parseResponseError(r))
 	}
 
 	return nil
 }
 
-func (c *OrganizationsClient) RemoveJwks(org string, name, region string) error {
+func (c *OrganizationsClient) RemoveJwks(ctx context.Context, org string, name, region string) error {
 	body, err := marshal(map[string]any{"region": region})
 	if err != nil {
-		return fmt.Errorf("failed to marshal remove jwks request body: %s", err)
+		return fmt.Errorf("failed to marshal remove jwks request body: %w", err)
 	}
-	r, err := c.client.Delete(fmt.Sprintf("/v2/organizations/%v/jwks/%v", org, name), body)
+	r, err := c.client.Delete(ctx, fmt.Sprintf("/v2/organizations/%v/jwks/%v", org, name), body)
 	if err != nil {
 		return fmt.Errorf("failed to remove org jwks: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		err, _ := unmarshal[struct{ Error string }](r)
-		return fmt.Errorf("failed to remove org jwks: %v", err.Error)
+		return fmt.Errorf("failed to remove org jwks: %w", parseResponseError(r))
 	}
 
 	return nil
 }
 
-func (c *OrganizationsClient) SetOverages(slug string, toggle bool) error {
+func (c *OrganizationsClient) SetOverages(ctx context.Context, slug string, toggle bool) error {
 	path := "/v1/organizations/" + slug
 	body, err := marshal(map[string]bool{"overages": toggle})
 	if err != nil {
-		return fmt.Errorf("failed to marshall set overages request body: %s", err)
+		return fmt.Errorf("failed to marshall set overages request body: %w", err)
 	}
-	r, err := c.client.Patch(path, body)
+	r, err := c.client.Patch(ctx, path, body)
 	if err != nil {
 		return err
 	}
@@ -310,20 +304,20 @@ type Invite struct {
 	Accepted bool   `json:"accepted,omitempty"`
 }
 
-func (c *OrganizationsClient) ListMembers() ([]Member, error) {
+func (c *OrganizationsClient) ListMembers(ctx context.Context) ([]Member, error) {
 	url, err := c.MembersURL("")
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := c.client.Get(url, nil)
+	r, err := c.client.Get(ctx, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request organization members: %s", err)
+		return nil, fmt.Errorf("failed to request organization members: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return nil, errors.New("only organization admins or owners can list members")
+		return nil, newAPIError(r, "only organization admins or owners can list members")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -338,7 +332,7 @@ func (c *OrganizationsClient) ListMembers() ([]Member, error) {
 	return data.Members, nil
 }
 
-func (c *OrganizationsClient) AddMember(username, role string) error {
+func (c *OrganizationsClient) AddMember(ctx context.Context, username, role string) error {
 	url, err := c.MembersURL("")
 	if err != nil {
 		return err
@@ -346,17 +340,17 @@ func (c *OrganizationsClient) AddMember(username, role string) error {
 
 	body, err := marshal(Member{Name: username, Role: role})
 	if err != nil {
-		return fmt.Errorf("failed to marshall add member request body: %s", err)
+		return fmt.Errorf("failed to marshall add member request body: %w", err)
 	}
 
-	r, err := c.client.Post(url, body)
+	r, err := c.client.Post(ctx, url, body)
 	if err != nil {
-		return fmt.Errorf("failed to post organization member: %s", err)
+		return fmt.Errorf("failed to post organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return errors.New("only organization admins or owners can add members")
+		return newAPIError(r, "only organization admins or owners can add members")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -366,22 +360,22 @@ func (c *OrganizationsClient) AddMember(username, role string) error {
 	return nil
 }
 
-func (c *OrganizationsClient) InviteMember(email, role string) error {
+func (c *OrganizationsClient) InviteMember(ctx context.Context, email, role string) error {
 	prefix := "/v1/organizations/" + c.client.Org
 
 	body, err := marshal(Invite{Email: email, Role: role})
 	if err != nil {
-		return fmt.Errorf("failed to marshall invite email request body: %s", err)
+		return fmt.Errorf("failed to marshall invite email request body: %w", err)
 	}
 
-	r, err := c.client.Post(prefix+"/invite", body)
+	r, err := c.client.Post(ctx, prefix+"/invite", body)
 	if err != nil {
-		return fmt.Errorf("failed to invite organization member: %s", err)
+		return fmt.Errorf("failed to invite organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return errors.New("only organization admins or owners can invite members")
+		return newAPIError(r, "only organization admins or owners can invite members")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -391,21 +385,21 @@ func (c *OrganizationsClient) InviteMember(email, role string) error {
 	return nil
 }
 
-func (c *OrganizationsClient) DeleteInvite(email string) error {
+func (c *OrganizationsClient) DeleteInvite(ctx context.Context, email string) error {
 	prefix := "/v1/organizations/" + c.client.Org
 
-	r, err := c.client.Delete(prefix+"/invites/"+email, nil)
+	r, err := c.client.Delete(ctx, prefix+"/invites/"+email, nil)
 	if err != nil {
-		return fmt.Errorf("failed to remove pending invite: %s", err)
+		return fmt.Errorf("failed to remove pending invite: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return errors.New("only organization admins or owners can invite members")
+		return newAPIError(r, "only organization admins or owners can invite members")
 	}
 
 	if r.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("invite for %s not found", email)
+		return apiErrorf(r, "invite for %s not found", email)
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -415,17 +409,17 @@ func (c *OrganizationsClient) DeleteInvite(email string) error {
 	return nil
 }
 
-func (c *OrganizationsClient) ListInvites() ([]Invite, error) {
+func (c *OrganizationsClient) ListInvites(ctx context.Context) ([]Invite, error) {
 	prefix := "/v1/organizations/" + c.client.Org
 
-	r, err := c.client.Get(prefix+"/invites", nil)
+	r, err := c.client.Get(ctx, prefix+"/invites", nil)
 	if err != nil {
-		return []Invite{}, fmt.Errorf("failed to list invites: %s", err)
+		return []Invite{}, fmt.Errorf("failed to list invites: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return []Invite{}, errors.New("only organization admins or owners can list invites")
+		return []Invite{}, newAPIError(r, "only organization admins or owners can list invites")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -442,20 +436,20 @@ func (c *OrganizationsClient) ListInvites() ([]Invite, error) {
 	return data.Invites, nil
 }
 
-func (c *OrganizationsClient) RemoveMember(username string) error {
+func (c *OrganizationsClient) RemoveMember(ctx context.Context, username string) error {
 	url, err := c.MembersURL("/" + username)
 	if err != nil {
 		return err
 	}
 
-	r, err := c.client.Delete(url, nil)
+	r, err := c.client.Delete(ctx, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete organization member: %s", err)
+		return fmt.Errorf("failed to delete organization member: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusForbidden {
-		return errors.New("only organization admins or owners can remove members")
+		return newAPIError(r, "only organization admins or owners can remove members")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -498,16 +492,16 @@ type AuditLogsResponse struct {
 	Pagination AuditLogsPagination `json:"pagination"`
 }
 
-func (c *OrganizationsClient) AuditLogs(org string, page int, limit int) (AuditLogsResponse, error) {
+func (c *OrganizationsClient) AuditLogs(ctx context.Context, org string, page int, limit int) (AuditLogsResponse, error) {
 	path := fmt.Sprintf("/v1/organizations/%s/audit-logs?page=%d&page_size=%d", org, page, limit)
-	r, err := c.client.Get(path, nil)
+	r, err := c.client.Get(ctx, path, nil)
 	if err != nil {
 		return AuditLogsResponse{}, fmt.Errorf("failed to get audit logs: %w", err)
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode == http.StatusNotFound {
-		return AuditLogsResponse{}, errors.New("audit logs endpoint not found. This feature may not be available yet")
+		return AuditLogsResponse{}, newAPIError(r, "audit logs endpoint not found. This feature may not be available yet")
 	}
 
 	if r.StatusCode != http.StatusOK {
@@ -550,8 +544,8 @@ func isNotMemberErr(status int, org string) bool {
 	return false
 }
 
-func notMemberErr(org string) error {
+func notMemberErr(res *http.Response, org string) error {
 	msg := fmt.Sprintf("you are not a member of organization %s. ", internal.Emph(org))
 	msg += fmt.Sprintf("%s is now configured to use your personal organization.", internal.Emph("turso"))
-	return errors.New(msg)
+	return newAPIError(res, msg)
 }
@@ -0,0 +1,167 @@
+package turso
+
+import (
+	"context"
+	"errors"
+	mathrand "math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries    = 15
+	baseRetryDelay       = 2 * time.Second
+	maxRetryDelay        = 60 * time.Second
+	retryJitterMaxMillis = 1000
+
+	// Control-plane calls are interactive, so they back off faster than
+	// chunk uploads do.
+	apiBaseRetryDelay = 250 * time.Millisecond
+	apiMaxRetryDelay  = 10 * time.Second
+)
+
+// isRetriableError determines if an error should be retried.
+// Returns true for network errors, server errors (5xx), and specific client errors (408, 429).
+func isRetriableError(err error, statusCode int) bool {
+	// A request that stalled may go through on another connection
+	var timeoutErr *RequestTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	// The caller gave up, retrying would only delay reporting it
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// Network errors are always retriable
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Connection errors (no response received)
+	if err != nil && statusCode == 0 {
+		return true
+	}
+
+	return isRetryableStatus(statusCode)
+}
+
+// isRetryableStatus reports whether a response with the given status may
+// succeed if the request is sent again: server errors (5xx), 408 and 429.
+func isRetryableStatus(statusCode int) bool {
+	if statusCode >= 500 && statusCode < 600 {
+		return true
+	}
+	return statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
+
+// calculateBackoff returns the backoff duration for a given retry attempt.
+// Uses exponential backoff with jitter.
+func calculateBackoff(attempt int) time.Duration {
+	delay := baseRetryDelay * time.Duration(1<<uint(attempt)) // 2^attempt
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	// Add jitter
+	jitter := time.Duration(mathrand.IntN(retryJitterMaxMillis)) * time.Millisecond
+	return delay + jitter
+}
+
+// calculateAPIBackoff is the control-plane counterpart of calculateBackoff.
+// The jitter is bounded by the base delay.
+func calculateAPIBackoff(attempt int) time.Duration {
+	delay := apiBaseRetryDelay * time.Duration(1<<uint(attempt)) // 2^attempt
+	if delay > apiMaxRetryDelay {
+		delay = apiMaxRetryDelay
+	}
+	jitter := time.Duration(mathrand.Int64N(int64(apiBaseRetryDelay)))
+	return delay + jitter
+}
+
+// retryAfter parses the Retry-After header of res, which is either a number
+// of seconds or an HTTP date. The returned delay is capped at maxRetryDelay.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = date.Sub(now)
+	} else {
+		return 0, false
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay, true
+}
+
+// isIdempotentMethod reports whether a request with the given method can be
+// sent more than once without changing the outcome.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+type finalStatusesKey struct{}
+
+// withFinalStatuses records in ctx the statuses that are an answer of the
+// endpoint rather than a failure, such as the end of the WAL for /sync, so
+// that the request is not retried when it gets one of them.
+func withFinalStatuses(ctx context.Context, statuses ...int) context.Context {
+	return context.WithValue(ctx, finalStatusesKey{}, statuses)
+}
+
+func isFinalStatus(ctx context.Context, statusCode int) bool {
+	statuses, _ := ctx.Value(finalStatusesKey{}).([]int)
+	return slices.Contains(statuses, statusCode)
+}
+
+// shouldRetryRequest decides whether a control-plane request may be retried
+// after an attempt that ended with err or with the status of res.
+// Non-idempotent requests are only retried when the server explicitly
+// rejected them with 429, since they were not processed.
+func shouldRetryRequest(req *http.Request, res *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body was consumed and can't be replayed.
+		return false
+	}
+	statusCode := 0
+	if res != nil {
+		statusCode = res.StatusCode
+	}
+	if err == nil && isFinalStatus(req.Context(), statusCode) {
+		return false
+	}
+	if !isIdempotentMethod(req.Method) {
+		return err == nil && statusCode == http.StatusTooManyRequests
+	}
+	return isRetriableError(err, statusCode)
+}
+
+// sleepContext waits for d to elapse or for ctx to be done, whichever
+// happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package turso
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, maxRetries int) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := New(base, "token", "dev", "")
+	client.maxRetries = maxRetries
+	return client
+}
+
+func TestDoRetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// every attempt must send the full body again
+		if body, _ := io.ReadAll(r.Body); string(body) != `{"name":"db"}`+"\n" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, 3)
+
+	body, err := marshal(map[string]string{"name": "db"})
+	require.NoError(t, err)
+	res, err := client.Put(t.Context(), "/v1/databases", body)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestDoStopsAfterRetryBudget(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusBadGateway)
+	}, 2)
+
+	res, err := client.Get(t.Context(), "/v1/databases", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadGateway, res.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestDoDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, 3)
+
+	res, err := client.Post(t.Context(), "/v1/databases", strings.NewReader("{}"))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestDoRetriesNonIdempotentRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, 3)
+
+	res, err := client.Post(t.Context(), "/v1/databases", strings.NewReader("{}"))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestDoDoesNotRetryCanceledRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	var calls atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, 3)
+
+	res, err := client.Get(ctx, "/v1/databases", nil)
+	if res != nil {
+		res.Body.Close()
+	}
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		delay  time.Duration
+		ok     bool
+	}{
+		{name: "missing", header: "", ok: false},
+		{name: "seconds", header: "5", delay: 5 * time.Second, ok: true},
+		{name: "date", header: now.Add(10 * time.Second).Format(http.TimeFormat), delay: 10 * time.Second, ok: true},
+		{name: "past date", header: now.Add(-time.Minute).Format(http.TimeFormat), delay: 0, ok: true},
+		{name: "capped", header: "3600", delay: maxRetryDelay, ok: true},
+		{name: "invalid", header: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				res.Header.Set("Retry-After", tt.header)
+			}
+			delay, ok := retryAfter(res, now)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.delay, delay)
+		})
+	}
+}
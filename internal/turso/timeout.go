@@ -0,0 +1,109 @@
+package turso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestTimeoutError is returned when a request made no progress for the
+// timeout of its transport, see TransportConfig.RequestTimeout. It wraps
+// context.DeadlineExceeded.
+type RequestTimeoutError struct {
+	Method  string
+	Host    string
+	Timeout time.Duration
+}
+
+func (e *RequestTimeoutError) Error() string {
+	return fmt.Sprintf("%s request to %s made no progress for %s", e.Method, e.Host, e.Timeout)
+}
+
+func (e *RequestTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// timeoutTransport cancels a request once it made no progress for timeout:
+// no byte of its body was sent, no response was received, or no byte of the
+// response body was read in that time. Long transfers are not bounded as
+// long as data flows.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timeoutErr := &RequestTimeoutError{Method: req.Method, Host: req.URL.Host, Timeout: t.timeout}
+	timer := time.AfterFunc(t.timeout, func() { cancel(timeoutErr) })
+	progress := func() { timer.Reset(t.timeout) }
+
+	req = req.WithContext(ctx)
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = &progressBody{ReadCloser: req.Body, progress: progress}
+	}
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		timer.Stop()
+		cancel(nil)
+		return nil, timeoutCause(ctx, err)
+	}
+	progress()
+	res.Body = &timeoutBody{
+		progressBody: progressBody{ReadCloser: res.Body, progress: progress},
+		ctx:          ctx,
+		stop: func() {
+			timer.Stop()
+			cancel(nil)
+		},
+	}
+	return res, nil
+}
+
+// timeoutCause returns the RequestTimeoutError that cancelled ctx, if any,
+// instead of err.
+func timeoutCause(ctx context.Context, err error) error {
+	var timeoutErr *RequestTimeoutError
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		return timeoutErr
+	}
+	return err
+}
+
+// progressBody calls progress every time bytes are read from the body.
+type progressBody struct {
+	io.ReadCloser
+	progress func()
+}
+
+func (b *progressBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.progress()
+	}
+	return n, err
+}
+
+// timeoutBody is the body of a response, whose request is cancelled when the
+// body makes no progress, and released when the body is closed.
+type timeoutBody struct {
+	progressBody
+	ctx  context.Context
+	stop func()
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.progressBody.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = timeoutCause(b.ctx, err)
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	b.stop()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,285 @@
+package turso
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redacted = "[REDACTED]"
+
+	// maxTracedBodySize bounds the bodies written to a trace, so binary
+	// uploads and exports don't end up in it.
+	maxTracedBodySize = 64 << 10
+)
+
+// Headers whose values are always replaced in traces and debug dumps.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	http.CanonicalHeaderKey(EncryptionKeyHeader): true,
+	// Sent to object storage with temporary credentials
+	"X-Amz-Security-Token": true,
+}
+
+// JSON fields and query parameters whose values are always replaced.
+var sensitiveFields = map[string]bool{
+	"jwt":                   true,
+	"token":                 true,
+	"auth_token":            true,
+	"authtoken":             true,
+	"access_token":          true,
+	"password":              true,
+	"secret":                true,
+	"encryption_key":        true,
+	"encryptionkey":         true,
+	"remote_encryption_key": true,
+}
+
+var (
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"]+`)
+	// fieldPattern catches sensitive fields in JSON that couldn't be parsed,
+	// e.g. a truncated or chunked body.
+	fieldPattern = regexp.MustCompile(`(?i)("(?:jwt|token|auth_?token|access_token|password|secret|(?:remote_)?encryption_?key)"\s*:\s*)"[^"]*"`)
+)
+
+// TraceEvent is a single line of a trace. Bodies and headers are only set
+// when the Tracer was created with bodies enabled.
+type TraceEvent struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	Host            string            `json:"host"`
+	Path            string            `json:"path"`
+	Status          int               `json:"status,omitempty"`
+	LatencyMs       int64             `json:"latency_ms"`
+	RequestID       string            `json:"request_id,omitempty"`
+	Retry           int               `json:"retry"`
+	Error           string            `json:"error,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+}
+
+// Tracer writes one JSON line per HTTP request to w. Credentials and
+// encryption keys are redacted, so traces are safe to share.
+type Tracer struct {
+	mu            sync.Mutex
+	w             io.Writer
+	includeBodies bool
+}
+
+func NewTracer(w io.Writer, includeBodies bool) *Tracer {
+	return &Tracer{w: w, includeBodies: includeBodies}
+}
+
+func (t *Tracer) record(event TraceEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.w.Write(append(line, '\n'))
+}
+
+// tracingTransport records every round trip of the wrapped transport.
+type tracingTransport struct {
+	next   http.RoundTripper
+	tracer *Tracer
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	event := TraceEvent{
+		Method: req.Method,
+		Host:   req.URL.Host,
+		Path:   redactURLPath(req.URL),
+		Retry:  retryAttempt(req.Context()),
+	}
+	if t.tracer.includeBodies {
+		event.RequestHeaders = redactHeaders(req.Header)
+		event.RequestBody = tracedRequestBody(req)
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	event.Time = start.UTC()
+	event.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		event.Error = redactString(err.Error())
+		t.tracer.record(event)
+		return res, err
+	}
+
+	event.Status = res.StatusCode
+	event.RequestID = requestID(res.Header)
+	if t.tracer.includeBodies {
+		event.ResponseHeaders = redactHeaders(res.Header)
+		event.ResponseBody = tracedResponseBody(res)
+	}
+	t.tracer.record(event)
+	return res, nil
+}
+
+func requestID(header http.Header) string {
+	for _, name := range []string{"X-Request-Id", "Fly-Request-Id"} {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func tracedRequestBody(req *http.Request) string {
+	if req.GetBody == nil || req.ContentLength > maxTracedBodySize || !isTextContent(req.Header) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxTracedBodySize))
+	if err != nil {
+		return ""
+	}
+	return string(redactBody(data))
+}
+
+// tracedResponseBody reads the body of res and replaces it with a copy, so
+// the caller can still consume it.
+func tracedResponseBody(res *http.Response) string {
+	if res.ContentLength > maxTracedBodySize || !isTextContent(res.Header) {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxTracedBodySize+1))
+	res.Body = readCloser{io.MultiReader(bytes.NewReader(data), res.Body), res.Body}
+	if err != nil || len(data) > maxTracedBodySize {
+		return ""
+	}
+	return string(redactBody(data))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func isTextContent(header http.Header) bool {
+	contentType := header.Get("Content-Type")
+	return strings.Contains(contentType, "json") || strings.HasPrefix(contentType, "text/")
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = redactString(strings.Join(values, ", "))
+	}
+	return headers
+}
+
+func redactURLPath(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Path
+	}
+	query := u.Query()
+	for key := range query {
+		if sensitiveFields[strings.ToLower(key)] {
+			query.Set(key, redacted)
+		}
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+// redactBody replaces credentials in a request or response body. JSON
+// fields known to hold secrets are replaced whatever their value, and
+// anything that looks like a JWT or a bearer token is replaced everywhere.
+func redactBody(body []byte) []byte {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []byte(redactString(string(body)))
+	}
+	redactedBody, err := json.Marshal(redactJSON(value))
+	if err != nil {
+		return []byte(redactString(string(body)))
+	}
+	return redactedBody
+}
+
+func redactJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSON(field)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+		return v
+	case string:
+		return redactString(v)
+	}
+	return value
+}
+
+// redactString replaces JWTs, bearer tokens and sensitive JSON fields in s.
+func redactString(s string) string {
+	s = fieldPattern.ReplaceAllString(s, `${1}"`+redacted+`"`)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	return jwtPattern.ReplaceAllString(s, redacted)
+}
+
+// redactDump redacts the output of httputil.DumpRequestOut and
+// httputil.DumpResponse: sensitive headers, then credentials in the body.
+func redactDump(dump string) string {
+	head, body, found := strings.Cut(dump, "\r\n\r\n")
+	lines := strings.Split(head, "\r\n")
+	for i, line := range lines {
+		name, _, ok := strings.Cut(line, ":")
+		if ok && sensitiveHeaders[http.CanonicalHeaderKey(strings.TrimSpace(name))] {
+			lines[i] = name + ": " + redacted
+		}
+	}
+	head = redactString(strings.Join(lines, "\r\n"))
+	if !found {
+		return head
+	}
+	if body != "" {
+		body = string(redactBody([]byte(body)))
+	}
+	return head + "\r\n\r\n" + body
+}
+
+type retryAttemptKey struct{}
+
+// withRetryAttempt records in ctx how many times a request was retried, so
+// the trace can report it.
+func withRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+func retryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
@@ -0,0 +1,103 @@
+package turso
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const defaultMaxIdleConnsPerHost = 16
+
+// TransportConfig describes how the CLI connects to the platform API and to
+// databases. The zero value behaves like http.DefaultTransport, except for a
+// larger connection pool.
+type TransportConfig struct {
+	// CABundle is the path to a PEM file with root certificates trusted in
+	// addition to the system ones, e.g. for TLS intercepting proxies.
+	CABundle string
+	// ClientCert and ClientKey are the paths to a PEM certificate and key
+	// presented to servers that require mutual TLS.
+	ClientCert string
+	ClientKey  string
+	// Proxy is the URL of the proxy used for every request. When empty, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+	Proxy string
+	// MaxIdleConnsPerHost bounds the idle connections kept for reuse.
+	MaxIdleConnsPerHost int
+	// Tracer, when set, records every request sent by the client.
+	Tracer *Tracer
+	// RequestTimeout, when set, cancels every request that makes no
+	// progress for that long, with a RequestTimeoutError.
+	RequestTimeout time.Duration
+}
+
+// NewHTTPClient returns an HTTP client configured according to config. The
+// client should be shared by all requests so connections are pooled.
+func NewHTTPClient(config TransportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	if transport.MaxIdleConnsPerHost <= 0 {
+		transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+
+	if config.Proxy != "" {
+		proxyURL, err := url.Parse(config.Proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", config.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	var roundTripper http.RoundTripper = transport
+	if config.RequestTimeout > 0 {
+		roundTripper = &timeoutTransport{next: roundTripper, timeout: config.RequestTimeout}
+	}
+	if config.Tracer != nil {
+		roundTripper = &tracingTransport{next: roundTripper, tracer: config.Tracer}
+	}
+	return &http.Client{Transport: roundTripper}, nil
+}
+
+func newTLSConfig(config TransportConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CABundle != "" {
+		pem, err := os.ReadFile(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", config.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (config.ClientCert == "") != (config.ClientKey == "") {
+		return nil, errors.New("both a client certificate and a client key are required for mutual TLS")
+	}
+	if config.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,126 @@
+package turso
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClientTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := New(base, "token", "dev", "")
+	client.maxRetries = 0
+	httpClient, err := NewHTTPClient(TransportConfig{})
+	require.NoError(t, err)
+	client.SetHTTPClient(httpClient)
+	_, err = client.Get(context.Background(), "/", nil)
+	require.Error(t, err, "the test server certificate must not be trusted by default")
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(block), 0o600))
+
+	httpClient, err = NewHTTPClient(TransportConfig{CABundle: bundle})
+	require.NoError(t, err)
+	client.SetHTTPClient(httpClient)
+	res, err := client.Get(context.Background(), "/", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestNewHTTPClientRejectsInvalidConfig(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+
+	for name, config := range map[string]TransportConfig{
+		"missing bundle":     {CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		"bundle without PEM": {CABundle: empty},
+		"cert without key":   {ClientCert: "cert.pem"},
+		"key without cert":   {ClientKey: "key.pem"},
+		"proxy without host": {Proxy: "proxy.internal"},
+		"proxy not a URL":    {Proxy: "http://[::1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewHTTPClient(config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewHTTPClientUsesProxy(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{Proxy: "http://proxy.internal:3128"})
+	require.NoError(t, err)
+
+	transport := httpClient.Transport.(*http.Transport)
+	req, err := http.NewRequest(http.MethodGet, "https://api.turso.tech/v1/organizations", nil)
+	require.NoError(t, err)
+	proxy, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "proxy.internal:3128", proxy.Host)
+	require.Equal(t, defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+}
+
+func TestNewHTTPClientRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		switch r.URL.Path {
+		case "/stalled":
+			<-release
+		case "/steady":
+			// Takes longer than the timeout overall, but never stalls
+			for i := 0; i < 10; i++ {
+				fmt.Fprint(w, "data")
+				flusher.Flush()
+				time.Sleep(20 * time.Millisecond)
+			}
+		case "/stalled-body":
+			fmt.Fprint(w, "data")
+			flusher.Flush()
+			<-release
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	httpClient, err := NewHTTPClient(TransportConfig{RequestTimeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = httpClient.Get(server.URL + "/stalled")
+	var timeoutErr *RequestTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, isRetriableError(err, 0))
+
+	res, err := httpClient.Get(server.URL + "/steady")
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, strings.Repeat("data", 10), string(body))
+
+	res, err = httpClient.Get(server.URL + "/stalled-body")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	_, err = io.ReadAll(res.Body)
+	require.ErrorAs(t, err, &timeoutErr)
+}
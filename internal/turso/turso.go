@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +12,8 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/tursodatabase/turso-cli/internal/flags"
 )
@@ -18,9 +21,12 @@ import (
 // Collection of all turso clients
 type Client struct {
 	baseUrl    *url.URL
+	tokenMu    sync.RWMutex
 	token      string
 	cliVersion string
 	Org        string
+	maxRetries int
+	httpClient *http.Client
 
 	// Single instance to be reused by all clients
 	base *client
@@ -47,7 +53,7 @@ type client struct {
 }
 
 func New(base *url.URL, token string, cliVersion string, org string) *Client {
-	c := &Client{baseUrl: base, token: token, cliVersion: cliVersion, Org: org}
+	c := &Client{baseUrl: base, token: token, cliVersion: cliVersion, Org: org, maxRetries: flags.MaxRetries(), httpClient: http.DefaultClient}
 
 	c.base = &client{c}
 	c.Instances = (*InstancesClient)(c.base)
@@ -68,10 +74,23 @@ func New(base *url.URL, token string, cliVersion string, org string) *Client {
 }
 
 func (t *Client) SetToken(token string) {
+	t.tokenMu.Lock()
+	defer t.tokenMu.Unlock()
 	t.token = token
 }
 
-func (t *Client) newRequest(method, urlPath string, body io.Reader, extraHeaders map[string]string) (*http.Request, error) {
+func (t *Client) currentToken() string {
+	t.tokenMu.RLock()
+	defer t.tokenMu.RUnlock()
+	return t.token
+}
+
+// SetHTTPClient sets the client used to send requests, see NewHTTPClient.
+func (t *Client) SetHTTPClient(httpClient *http.Client) {
+	t.httpClient = httpClient
+}
+
+func (t *Client) newRequest(ctx context.Context, method, urlPath string, body io.Reader, extraHeaders map[string]string) (*http.Request, error) {
 	if _, exists := extraHeaders["Content-Type"]; !exists {
 		return nil, errors.New("content type is required")
 	}
@@ -83,12 +102,12 @@ func (t *Client) newRequest(method, urlPath string, body io.Reader, extraHeaders
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(method, url.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	if t.token != "" {
-		req.Header.Add("Authorization", fmt.Sprint("Bearer ", t.token))
+	if token := t.currentToken(); token != "" {
+		req.Header.Add("Authorization", fmt.Sprint("Bearer ", token))
 	}
 	req.Header.Add("TursoCliVersion", t.cliVersion)
 	parsedCliVersion := t.cliVersion
@@ -102,8 +121,8 @@ func (t *Client) newRequest(method, urlPath string, body io.Reader, extraHeaders
 	return req, nil
 }
 
-func (t *Client) do(method, path string, body io.Reader, extraHeaders map[string]string) (*http.Response, error) {
-	req, err := t.newRequest(method, path, body, extraHeaders)
+func (t *Client) do(ctx context.Context, method, path string, body io.Reader, extraHeaders map[string]string) (*http.Response, error) {
+	req, err := t.newRequest(ctx, method, path, body, extraHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -115,26 +134,57 @@ func (t *Client) do(method, path string, body io.Reader, extraHeaders map[string
 		req.ContentLength = int64(length)
 		req.TransferEncoding = nil
 	}
-	var reqDump string
-	if flags.Debug() {
-		reqDump = dumpRequest(req)
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if flags.Debug() {
-		printDumps(reqDump, dumpResponse(resp))
+	maxRetries := t.maxRetries
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		if attempt > 0 {
+			req = req.WithContext(withRetryAttempt(ctx, attempt))
+		}
+		var reqDump string
+		if flags.Debug() {
+			reqDump = dumpRequest(req)
+		}
+		resp, err := t.httpClient.Do(req)
+		if flags.Debug() && err == nil {
+			printDumps(reqDump, dumpResponse(resp))
+		}
+		if attempt >= maxRetries || !shouldRetryRequest(req, resp, err) {
+			return resp, err
+		}
+
+		backoff := calculateAPIBackoff(attempt)
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if delay, ok := retryAfter(resp, time.Now()); ok {
+				backoff = delay
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if flags.Debug() {
+			fmt.Fprintf(os.Stderr, "retrying %s %s (attempt %d of %d) in %v: %s\n", req.Method, req.URL.Path, attempt+2, maxRetries+1, backoff, reason)
+		}
+		if err := sleepContext(ctx, backoff); err != nil {
+			return nil, err
+		}
 	}
-	return resp, nil
 }
 
+// printDumps writes the debug dumps to stderr, so they don't mix with the
+// command output.
 func printDumps(req, resp string) {
 	if req != "" {
-		fmt.Println(req)
+		fmt.Fprintln(os.Stderr, req)
 	}
 	if resp != "" {
-		fmt.Println(resp)
+		fmt.Fprintln(os.Stderr, resp)
 	}
 }
 
@@ -143,7 +193,7 @@ func dumpRequest(req *http.Request) string {
 	if err != nil {
 		return ""
 	}
-	return string(dump)
+	return redactDump(string(dump))
 }
 
 func dumpResponse(req *http.Response) string {
@@ -151,7 +201,7 @@ func dumpResponse(req *http.Response) string {
 	if err != nil {
 		return ""
 	}
-	return string(dump)
+	return redactDump(string(dump))
 }
 
 func Header(key, value string) map[string]string {
@@ -160,47 +210,47 @@ func Header(key, value string) map[string]string {
 	}
 }
 
-func (t *Client) Get(path string, body io.Reader) (*http.Response, error) {
-	return t.do("GET", path, body, Header("Content-Type", "application/json"))
+func (t *Client) Get(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return t.do(ctx, "GET", path, body, Header("Content-Type", "application/json"))
 }
 
-func (t *Client) GetWithHeaders(path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+func (t *Client) GetWithHeaders(ctx context.Context, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
 	if headers == nil {
 		headers = make(map[string]string)
 	}
 	headers["Content-Type"] = "application/json"
-	return t.do("GET", path, body, headers)
+	return t.do(ctx, "GET", path, body, headers)
 }
 
-func (t *Client) Post(path string, body io.Reader) (*http.Response, error) {
-	return t.do("POST", path, body, Header("Content-Type", "application/json"))
+func (t *Client) Post(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return t.do(ctx, "POST", path, body, Header("Content-Type", "application/json"))
 }
 
-func (t *Client) PostBinary(path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+func (t *Client) PostBinary(ctx context.Context, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
 	if headers == nil {
 		headers = make(map[string]string)
 	}
 	headers["Content-Type"] = "application/octet-stream"
-	return t.do("POST", path, body, headers)
+	return t.do(ctx, "POST", path, body, headers)
 }
 
-func (t *Client) PutBinary(path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+func (t *Client) PutBinary(ctx context.Context, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
 	if headers == nil {
 		headers = make(map[string]string)
 	}
 	headers["Content-Type"] = "application/octet-stream"
-	return t.do("PUT", path, body, headers)
+	return t.do(ctx, "PUT", path, body, headers)
 }
 
-func (t *Client) Patch(path string, body io.Reader) (*http.Response, error) {
-	return t.do("PATCH", path, body, Header("Content-Type", "application/json"))
+func (t *Client) Patch(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return t.do(ctx, "PATCH", path, body, Header("Content-Type", "application/json"))
 }
 
-func (t *Client) Put(path string, body io.Reader) (*http.Response, error) {
-	return t.do("PUT", path, body, Header("Content-Type", "application/json"))
+func (t *Client) Put(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return t.do(ctx, "PUT", path, body, Header("Content-Type", "application/json"))
 }
 
-func (t *Client) Upload(path string, fileData *os.File) (*http.Response, error) {
+func (t *Client) Upload(ctx context.Context, path string, fileData *os.File) (*http.Response, error) {
 	body, bodyWriter := io.Pipe()
 	writer := multipart.NewWriter(bodyWriter)
 	go func() {
@@ -215,17 +265,17 @@ func (t *Client) Upload(path string, fileData *os.File) (*http.Response, error)
 		}
 		bodyWriter.CloseWithError(writer.Close())
 	}()
-	req, err := t.newRequest("POST", path, body, Header("Content-Type", writer.FormDataContentType()))
+	req, err := t.newRequest(ctx, "POST", path, body, Header("Content-Type", writer.FormDataContentType()))
 	if err != nil {
 		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := t.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (t *Client) Delete(path string, body io.Reader) (*http.Response, error) {
-	return t.do("DELETE", path, body, Header("Content-Type", "application/json"))
+func (t *Client) Delete(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return t.do(ctx, "DELETE", path, body, Header("Content-Type", "application/json"))
 }
@@ -1,28 +1,27 @@
 package turso
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
 	"log"
-	mathrand "math/rand/v2"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
-)
 
-const (
-	defaultMaxRetries    = 15
-	baseRetryDelay       = 2 * time.Second
-	maxRetryDelay        = 60 * time.Second
-	retryJitterMaxMillis = 1000
+	"golang.org/x/sync/errgroup"
 )
 
 // debugUpload returns true if TURSO_DEBUG_UPLOAD=1 is set.
@@ -51,26 +50,88 @@ type progressReader struct {
 func (pr *progressReader) Read(p []byte) (int, error) {
 	n, err := pr.reader.Read(p)
 	if n > 0 {
-		pr.bytesRead += int64(n)
-		totalUploaded := pr.baseBytes + pr.bytesRead
-		progressPct := int(float64(totalUploaded) / float64(pr.totalSize) * 100)
+		pr.advance(int64(n), errors.Is(err, io.EOF))
+	}
+	return n, err
+}
+
+// advance records that n more bytes were uploaded, and reports the progress
+// if it changed enough since the last report.
+func (pr *progressReader) advance(n int64, done bool) {
+	pr.bytesRead += n
+	totalUploaded := pr.baseBytes + pr.bytesRead
+	progressPct := int(float64(totalUploaded) / float64(pr.totalSize) * 100)
+
+	timeSinceLastUpdate := time.Since(pr.lastUpdateTime)
+	bytesSinceLastUpdate := totalUploaded - pr.lastUpdateBytes
+
+	// Update if: percentage changed OR 2s elapsed OR 50MB uploaded OR done
+	shouldUpdate := progressPct > pr.lastUpdate ||
+		timeSinceLastUpdate >= 2*time.Second ||
+		bytesSinceLastUpdate >= 50*1024*1024 ||
+		done
+
+	if shouldUpdate {
+		elapsedTime := time.Since(pr.startTime)
+		pr.lastUpdate = progressPct
+		pr.lastUpdateTime = time.Now()
+		pr.lastUpdateBytes = totalUploaded
+		pr.onProgress(progressPct, totalUploaded, pr.totalSize, elapsedTime, done)
+	}
+}
 
-		timeSinceLastUpdate := time.Since(pr.lastUpdateTime)
-		bytesSinceLastUpdate := totalUploaded - pr.lastUpdateBytes
+// uploadProgress aggregates the progress of the chunks uploaded concurrently,
+// so that it is reported as the progress of a single upload.
+type uploadProgress struct {
+	mu      sync.Mutex
+	tracker progressReader
+}
+
+func newUploadProgress(totalSize, uploadedBytes int64, startTime time.Time, onProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) *uploadProgress {
+	return &uploadProgress{tracker: progressReader{
+		totalSize:       totalSize,
+		baseBytes:       uploadedBytes,
+		startTime:       startTime,
+		onProgress:      onProgress,
+		lastUpdate:      -1,
+		lastUpdateTime:  time.Now(),
+		lastUpdateBytes: uploadedBytes,
+	}}
+}
+
+// add records that n more bytes were uploaded. n is negative when the bytes
+// of a failed attempt are discarded.
+func (p *uploadProgress) add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n < 0 {
+		p.tracker.bytesRead += n
+		return
+	}
+	p.tracker.advance(n, p.tracker.baseBytes+p.tracker.bytesRead+n == p.tracker.totalSize)
+}
 
-		// Update if: percentage changed OR 2s elapsed OR 50MB uploaded OR done
-		shouldUpdate := progressPct > pr.lastUpdate ||
-			timeSinceLastUpdate >= 2*time.Second ||
-			bytesSinceLastUpdate >= 50*1024*1024 ||
-			errors.Is(err, io.EOF)
+// chunkReader reads a chunk for an upload attempt, at most at the rate
+// allowed by limiter, and adds what it reads to progress.
+type chunkReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	progress *uploadProgress
+	limiter  *bandwidthLimiter
+	read     int64
+}
 
-		if shouldUpdate {
-			elapsedTime := time.Since(pr.startTime)
-			pr.lastUpdate = progressPct
-			pr.lastUpdateTime = time.Now()
-			pr.lastUpdateBytes = totalUploaded
-			pr.onProgress(progressPct, totalUploaded, pr.totalSize, elapsedTime, errors.Is(err, io.EOF))
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if r.limiter != nil {
+		p = p[:min(len(p), bandwidthBurst)]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.wait(r.ctx, n); waitErr != nil {
+			return 0, waitErr
 		}
+		r.read += int64(n)
+		r.progress.add(int64(n))
 	}
 	return n, err
 }
@@ -80,30 +141,55 @@ type TursoServerClient struct {
 	client           *Client
 	tokenProvider    TokenProvider
 	tokenTTL         time.Duration
+	tokenMu          *sync.Mutex
 	lastTokenRefresh time.Time
+	upload           UploadOptions
+}
+
+// UploadOptions configures how database files are uploaded.
+type UploadOptions struct {
+	// Concurrency is the number of chunks uploaded at once,
+	// DefaultUploadConcurrency when zero.
+	Concurrency int
+	// MaxBandwidth is the maximum number of bytes uploaded per second across
+	// all chunks, unlimited when zero.
+	MaxBandwidth int64
 }
 
-func NewTursoServerClient(baseURL *url.URL, tokenProvider TokenProvider, tokenTTL time.Duration, cliVersion string, org string) (TursoServerClient, error) {
+// DefaultUploadConcurrency is the number of chunks uploaded at once when
+// UploadOptions.Concurrency is not set.
+const DefaultUploadConcurrency = 4
+
+func NewTursoServerClient(baseURL *url.URL, tokenProvider TokenProvider, tokenTTL time.Duration, cliVersion string, org string, httpClient *http.Client) (TursoServerClient, error) {
 	initialToken, err := tokenProvider()
 	if err != nil {
 		return TursoServerClient{}, fmt.Errorf("failed to get initial token: %w", err)
 	}
 
 	newClient := New(baseURL, initialToken, cliVersion, org)
+	newClient.SetHTTPClient(httpClient)
 
 	return TursoServerClient{
 		tenant:           org,
 		client:           newClient,
 		tokenProvider:    tokenProvider,
 		tokenTTL:         tokenTTL,
+		tokenMu:          &sync.Mutex{},
 		lastTokenRefresh: time.Now(),
 	}, nil
 }
 
+// SetUploadOptions configures the uploads of UploadFileMultipart.
+func (i *TursoServerClient) SetUploadOptions(opts UploadOptions) {
+	i.upload = opts
+}
+
 func (i *TursoServerClient) refreshTokenIfNeeded() error {
 	if i.tokenProvider == nil {
 		return nil
 	}
+	i.tokenMu.Lock()
+	defer i.tokenMu.Unlock()
 	token, err := i.tokenProvider()
 	if err != nil {
 		return fmt.Errorf("failed to refresh token: %w", err)
@@ -113,45 +199,6 @@ func (i *TursoServerClient) refreshTokenIfNeeded() error {
 	return nil
 }
 
-// isRetriableError determines if an error should be retried.
-// Returns true for network errors, server errors (5xx), and specific client errors (408, 429).
-func isRetriableError(err error, statusCode int) bool {
-	// Network errors are always retriable
-	var netErr net.Error
-	if errors.As(err, &netErr) {
-		return true
-	}
-
-	// Connection errors (no response received)
-	if err != nil && statusCode == 0 {
-		return true
-	}
-
-	// Server errors (5xx) are retriable
-	if statusCode >= 500 && statusCode < 600 {
-		return true
-	}
-
-	// Specific client errors that are retriable
-	if statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests {
-		return true
-	}
-
-	return false
-}
-
-// calculateBackoff returns the backoff duration for a given retry attempt.
-// Uses exponential backoff with jitter.
-func calculateBackoff(attempt int) time.Duration {
-	delay := baseRetryDelay * time.Duration(1<<uint(attempt)) // 2^attempt
-	if delay > maxRetryDelay {
-		delay = maxRetryDelay
-	}
-	// Add jitter
-	jitter := time.Duration(mathrand.IntN(retryJitterMaxMillis)) * time.Millisecond
-	return delay + jitter
-}
-
 // chunkUploadContext holds the context needed for uploading a chunk with retry support.
 type chunkUploadContext struct {
 	chunkID          int
@@ -160,62 +207,42 @@ type chunkUploadContext struct {
 	chunkStartOffset int64 // File offset where this chunk starts
 	file             *os.File
 	headers          map[string]string
-	totalSize        int64
-	startTime        time.Time
-	onProgress       func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)
-	lastProgressPct  int
-	lastUpdateTime   time.Time
-	lastUpdateBytes  int64
-}
-
-// chunkUploadResult holds the progress state after a successful chunk upload.
-type chunkUploadResult struct {
-	lastProgressPct int
-	lastUpdateTime  time.Time
-	lastUpdateBytes int64
+	sha256           string // Hex digest of the chunk, also in headers
+	progress         *uploadProgress
+	limiter          *bandwidthLimiter
 }
 
 // uploadChunkWithRetry uploads a single chunk with retry logic.
 // It handles token refresh, exponential backoff, and progress tracking reset on retry.
-func (i *TursoServerClient) uploadChunkWithRetry(ctx *chunkUploadContext, maxRetries int) (chunkUploadResult, error) {
+func (i *TursoServerClient) uploadChunkWithRetry(ctx context.Context, chunk *chunkUploadContext, maxRetries int) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if debugUpload() && attempt > 0 {
-			log.Printf("[upload] Chunk %d: retry attempt %d/%d after error: %v", ctx.chunkID, attempt, maxRetries, lastErr)
+			log.Printf("[upload] Chunk %d: retry attempt %d/%d after error: %v", chunk.chunkID, attempt, maxRetries, lastErr)
 		}
 
 		// Refresh token on retries (ensures token doesn't expire during retry sequence)
 		if attempt > 0 {
 			if debugUpload() {
-				log.Printf("[upload] Chunk %d: refreshing token before retry attempt %d", ctx.chunkID, attempt)
+				log.Printf("[upload] Chunk %d: refreshing token before retry attempt %d", chunk.chunkID, attempt)
 			}
 			if err := i.refreshTokenIfNeeded(); err != nil {
-				return chunkUploadResult{}, err
+				return err
 			}
 		}
 
-		// Seek to chunk start position for this attempt
-		if _, err := ctx.file.Seek(ctx.chunkStartOffset, io.SeekStart); err != nil {
-			return chunkUploadResult{}, fmt.Errorf("failed to seek to chunk %d start: %w", ctx.chunkID, err)
-		}
-
-		// Create a fresh reader for this attempt
-		chunkReader := io.LimitReader(ctx.file, ctx.chunkSize)
-
-		progressTracker := &progressReader{
-			reader:          chunkReader,
-			totalSize:       ctx.totalSize,
-			baseBytes:       ctx.chunkStartOffset,
-			startTime:       ctx.startTime,
-			onProgress:      ctx.onProgress,
-			lastUpdate:      ctx.lastProgressPct,
-			lastUpdateTime:  ctx.lastUpdateTime,
-			lastUpdateBytes: ctx.lastUpdateBytes,
+		// Read the chunk from its start for this attempt. Chunks are read
+		// with ReadAt, so that they can be uploaded concurrently.
+		reader := &chunkReader{
+			ctx:      ctx,
+			reader:   io.NewSectionReader(chunk.file, chunk.chunkStartOffset, chunk.chunkSize),
+			progress: chunk.progress,
+			limiter:  chunk.limiter,
 		}
 
 		// Attempt the upload
-		r, err := i.client.PutBinary(ctx.chunkPath, progressTracker, ctx.headers)
+		r, err := i.client.PutBinary(withRetryAttempt(ctx, attempt), chunk.chunkPath, reader, chunk.headers)
 
 		// Determine status code (0 if no response)
 		statusCode := 0
@@ -227,50 +254,65 @@ func (i *TursoServerClient) uploadChunkWithRetry(ctx *chunkUploadContext, maxRet
 		if err == nil && (statusCode == http.StatusOK || statusCode == http.StatusCreated) {
 			_ = r.Body.Close()
 			if debugUpload() && attempt > 0 {
-				log.Printf("[upload] Chunk %d: succeeded on attempt %d", ctx.chunkID, attempt+1)
+				log.Printf("[upload] Chunk %d: succeeded on attempt %d", chunk.chunkID, attempt+1)
 			}
-			return chunkUploadResult{
-				lastProgressPct: progressTracker.lastUpdate,
-				lastUpdateTime:  progressTracker.lastUpdateTime,
-				lastUpdateBytes: progressTracker.lastUpdateBytes,
-			}, nil
+			// The chunk is read from the file again for every attempt, a
+			// different digest means the file or the transfer is broken, so
+			// sending it again would not help
+			if err := checkDigest(r, ChunkSHA256Header, chunk.sha256); err != nil {
+				return fmt.Errorf("chunk %d is corrupted: %w", chunk.chunkID, err)
+			}
+			return nil
 		}
 
+		// The chunk is sent again from its start
+		chunk.progress.add(-reader.read)
+
 		// Build error for this attempt
 		if err != nil {
-			lastErr = fmt.Errorf("failed to upload chunk %d: %w", ctx.chunkID, err)
+			lastErr = fmt.Errorf("failed to upload chunk %d: %w", chunk.chunkID, err)
 		} else {
 			body, _ := io.ReadAll(r.Body)
 			_ = r.Body.Close()
-			lastErr = fmt.Errorf("upload chunk %d failed with status code %d: %s", ctx.chunkID, statusCode, string(body))
+			lastErr = apiErrorf(r, "upload chunk %d failed with status code %d: %s", chunk.chunkID, statusCode, string(body))
 		}
 
 		// Check if error is retriable
 		if !isRetriableError(err, statusCode) {
 			if debugUpload() {
-				log.Printf("[upload] Chunk %d: non-retriable error (status=%d): %v", ctx.chunkID, statusCode, lastErr)
+				log.Printf("[upload] Chunk %d: non-retriable error (status=%d): %v", chunk.chunkID, statusCode, lastErr)
 			}
-			return chunkUploadResult{}, lastErr
+			return lastErr
 		}
 
 		// Don't sleep after the last attempt
 		if attempt < maxRetries {
 			backoff := calculateBackoff(attempt)
 			if debugUpload() {
-				log.Printf("[upload] Chunk %d: retriable error (status=%d), waiting %v before retry", ctx.chunkID, statusCode, backoff)
+				log.Printf("[upload] Chunk %d: retriable error (status=%d), waiting %v before retry", chunk.chunkID, statusCode, backoff)
+			}
+			if err := sleepContext(ctx, backoff); err != nil {
+				return err
 			}
-			time.Sleep(backoff)
 		}
 	}
 
 	if debugUpload() {
-		log.Printf("[upload] Chunk %d: exhausted all %d retries, giving up", ctx.chunkID, maxRetries+1)
+		log.Printf("[upload] Chunk %d: exhausted all %d retries, giving up", chunk.chunkID, maxRetries+1)
 	}
-	return chunkUploadResult{}, fmt.Errorf("failed after %d retries: %w", maxRetries+1, lastErr)
+	return fmt.Errorf("failed after %d retries: %w", maxRetries+1, lastErr)
 }
 
 // UploadFileMultipart uploads a database file using the multipart upload flow.
-func (i *TursoServerClient) UploadFileMultipart(filepath string, remoteEncryptionCipher, remoteEncryptionKey string, onUploadProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) error {
+func (i *TursoServerClient) UploadFileMultipart(ctx context.Context, filepath string, remoteEncryptionCipher, remoteEncryptionKey string, onUploadProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) error {
+	return i.uploadFileMultipart(ctx, filepath, &importState{}, remoteEncryptionCipher, remoteEncryptionKey, onUploadProgress)
+}
+
+// uploadFileMultipart uploads a database file, continuing the upload of state
+// if it has an upload ID. The progress is saved to state after every chunk,
+// and state is removed once the upload is finalized or the server rejects it.
+// It is kept when chunks fail to upload, so that the upload can be resumed.
+func (i *TursoServerClient) uploadFileMultipart(ctx context.Context, filepath string, state *importState, remoteEncryptionCipher, remoteEncryptionKey string, onUploadProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) error {
 	file, err := os.Open(filepath)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s: %w", filepath, err)
@@ -290,12 +332,31 @@ func (i *TursoServerClient) UploadFileMultipart(filepath string, remoteEncryptio
 	totalSize := stat.Size()
 	startTime := time.Now()
 
-	uploadStart, err := i.startMultipartUpload(totalSize)
+	fingerprint, err := fingerprintFile(file)
 	if err != nil {
 		return err
 	}
+	if state.UploadID != "" {
+		if !fingerprint.equal(state.Fingerprint) {
+			state.remove()
+			return fmt.Errorf("%s changed since its upload started, it can't be resumed", filepath)
+		}
+	} else {
+		uploadStart, err := i.startMultipartUpload(ctx, totalSize)
+		if err != nil {
+			return err
+		}
+		state.UploadID = uploadStart.UploadID
+		state.ChunkSize = uploadStart.ChunkSize
+		state.Fingerprint = fingerprint
+		if err := state.save(); err != nil {
+			// The upload can still complete, it just can't be resumed
+			fmt.Fprintf(os.Stderr, "Warning: %v, the import can't be resumed if it is interrupted.\n", err)
+			state.path = ""
+		}
+	}
 
-	uploadedBytes, err := i.uploadChunks(uploadStart.UploadID, uploadStart.ChunkSize, file, totalSize, startTime, remoteEncryptionCipher, remoteEncryptionKey, onUploadProgress)
+	uploadedBytes, digest, err := i.uploadChunks(ctx, state, file, totalSize, startTime, remoteEncryptionCipher, remoteEncryptionKey, onUploadProgress)
 	if err != nil {
 		return err
 	}
@@ -304,9 +365,15 @@ func (i *TursoServerClient) UploadFileMultipart(filepath string, remoteEncryptio
 		return err
 	}
 
-	if err = i.finalizeUpload(uploadStart.UploadID); err != nil {
+	if err = i.finalizeUpload(ctx, state.UploadID, digest); err != nil {
+		// The server validates the database when the upload is finalized,
+		// the upload can only be resumed if the request didn't reach it
+		if _, ok := AsAPIError(err); ok || errors.Is(err, errUploadCorrupted) {
+			state.remove()
+		}
 		return err
 	}
+	state.remove()
 
 	elapsedTime := time.Since(startTime)
 	onUploadProgress(100, uploadedBytes, totalSize, elapsedTime, true)
@@ -319,7 +386,7 @@ type multipartUploadStart struct {
 	UploadID  string
 }
 
-func (i *TursoServerClient) startMultipartUpload(dbSize int64) (multipartUploadStart, error) {
+func (i *TursoServerClient) startMultipartUpload(ctx context.Context, dbSize int64) (multipartUploadStart, error) {
 	requestBody := map[string]int64{
 		"db_size_bytes": dbSize,
 	}
@@ -329,7 +396,7 @@ func (i *TursoServerClient) startMultipartUpload(dbSize int64) (multipartUploadS
 		return multipartUploadStart{}, fmt.Errorf("failed to marshal multipart upload request: %w", err)
 	}
 
-	r, err := i.client.Put("/v2/upload/start", body)
+	r, err := i.client.Put(ctx, "/v2/upload/start", body)
 	if err != nil {
 		return multipartUploadStart{}, fmt.Errorf("failed to initiate multipart upload: %w", err)
 	}
@@ -338,9 +405,9 @@ func (i *TursoServerClient) startMultipartUpload(dbSize int64) (multipartUploadS
 	if r.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			return multipartUploadStart{}, fmt.Errorf("initiate multipart upload failed with status code %d and error reading response: %v", r.StatusCode, err)
+			return multipartUploadStart{}, apiErrorf(r, "initiate multipart upload failed with status code %d and error reading response: %v", r.StatusCode, err)
 		}
-		return multipartUploadStart{}, fmt.Errorf("initiate multipart upload failed with status code %d: %s", r.StatusCode, string(body))
+		return multipartUploadStart{}, apiErrorf(r, "initiate multipart upload failed with status code %d: %s", r.StatusCode, string(body))
 	}
 
 	type multipartUploadResponse struct {
@@ -355,77 +422,110 @@ func (i *TursoServerClient) startMultipartUpload(dbSize int64) (multipartUploadS
 	return multipartUploadStart(uploadResp), nil
 }
 
-func (i *TursoServerClient) uploadChunks(uploadID string, chunkSize int64, file *os.File, totalSize int64, startTime time.Time, remoteEncryptionCipher, remoteEncryptionKey string, onUploadProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) (int64, error) {
-	var uploadedBytes int64 = 0
-	chunkID := 0
-	lastProgressPct := -1
-	lastUpdateTime := time.Now()
-	var lastUpdateBytes int64 = 0
+// uploadChunks uploads the chunks of file that state does not record as
+// completed, UploadOptions.Concurrency at once, and returns the number of
+// bytes of the file uploaded and its hex SHA-256 digest. The first chunk to
+// fail cancels the others.
+func (i *TursoServerClient) uploadChunks(ctx context.Context, state *importState, file *os.File, totalSize int64, startTime time.Time, remoteEncryptionCipher, remoteEncryptionKey string, onUploadProgress func(progressPct int, uploadedBytes int64, totalBytes int64, elapsedTime time.Duration, done bool)) (int64, string, error) {
+	uploadID, chunkSize := state.UploadID, state.ChunkSize
+	concurrency := i.upload.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultUploadConcurrency
+	}
 
 	totalChunks := (totalSize + chunkSize - 1) / chunkSize
 	if debugUpload() {
-		log.Printf("[upload] Starting chunked upload: uploadID=%s, totalSize=%d bytes, chunkSize=%d bytes, totalChunks=%d", uploadID, totalSize, chunkSize, totalChunks)
+		log.Printf("[upload] Starting chunked upload: uploadID=%s, totalSize=%d bytes, chunkSize=%d bytes, totalChunks=%d, completedChunks=%d, concurrency=%d", uploadID, totalSize, chunkSize, totalChunks, len(state.CompletedChunks), concurrency)
 	}
 
-	for uploadedBytes < totalSize {
-		// Refresh token between chunks (not before first chunk)
-		if chunkID > 0 {
-			if err := i.refreshTokenIfNeeded(); err != nil {
-				return 0, err
-			}
-		}
-
-		remaining := totalSize - uploadedBytes
-		currentChunkSize := chunkSize
-		if remaining < chunkSize {
-			currentChunkSize = remaining
-		}
-
-		chunkPath := fmt.Sprintf("/v2/upload/%s/chunk/%d", uploadID, chunkID)
-
-		if debugUpload() {
-			log.Printf("[upload] Uploading chunk %d/%d: size=%d bytes, offset=%d", chunkID+1, totalChunks, currentChunkSize, uploadedBytes)
+	progress := newUploadProgress(totalSize, state.uploadedBytes(), startTime, onUploadProgress)
+	limiter := newBandwidthLimiter(i.upload.MaxBandwidth)
+	// stateMu guards state, which workers update as their chunks complete
+	var stateMu sync.Mutex
+	completed := slices.Clone(state.CompletedChunks)
+	fileHash := sha256.New()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	started := 0
+	for chunkID := 0; int64(chunkID) < totalChunks; chunkID++ {
+		if gctx.Err() != nil {
+			break
 		}
-
-		headers := map[string]string{}
-		if remoteEncryptionCipher != "" && remoteEncryptionKey != "" {
-			headers[EncryptionCipherHeader] = remoteEncryptionCipher
-			headers[EncryptionKeyHeader] = remoteEncryptionKey
+		offset := int64(chunkID) * chunkSize
+		currentChunkSize := min(chunkSize, totalSize-offset)
+
+		// Chunks are hashed in order, while the previous ones upload, and
+		// the completed ones too for the digest of the file
+		chunkHash := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(chunkHash, fileHash), io.NewSectionReader(file, offset, currentChunkSize)); err != nil {
+			_ = g.Wait()
+			return 0, "", fmt.Errorf("failed to read chunk %d: %w", chunkID, err)
 		}
-		headers["Content-Length"] = strconv.FormatInt(currentChunkSize, 10)
-
-		ctx := &chunkUploadContext{
-			chunkID:          chunkID,
-			chunkPath:        chunkPath,
-			chunkSize:        currentChunkSize,
-			chunkStartOffset: uploadedBytes,
-			file:             file,
-			headers:          headers,
-			totalSize:        totalSize,
-			startTime:        startTime,
-			onProgress:       onUploadProgress,
-			lastProgressPct:  lastProgressPct,
-			lastUpdateTime:   lastUpdateTime,
-			lastUpdateBytes:  lastUpdateBytes,
+		if slices.Contains(completed, chunkID) {
+			continue
 		}
+		chunkDigest := hex.EncodeToString(chunkHash.Sum(nil))
+
+		// The first chunks are uploaded with the initial token, the others
+		// refresh it
+		refreshToken := started >= concurrency
+		started++
+		g.Go(func() error {
+			if refreshToken {
+				if err := i.refreshTokenIfNeeded(); err != nil {
+					return err
+				}
+			}
 
-		result, err := i.uploadChunkWithRetry(ctx, defaultMaxRetries)
-		if err != nil {
-			return 0, err
-		}
+			if debugUpload() {
+				log.Printf("[upload] Uploading chunk %d/%d: size=%d bytes, offset=%d", chunkID+1, totalChunks, currentChunkSize, offset)
+			}
 
-		uploadedBytes += currentChunkSize
-		lastProgressPct = result.lastProgressPct
-		lastUpdateTime = result.lastUpdateTime
-		lastUpdateBytes = result.lastUpdateBytes
+			headers := map[string]string{}
+			if remoteEncryptionCipher != "" && remoteEncryptionKey != "" {
+				headers[EncryptionCipherHeader] = remoteEncryptionCipher
+				headers[EncryptionKeyHeader] = remoteEncryptionKey
+			}
+			headers["Content-Length"] = strconv.FormatInt(currentChunkSize, 10)
+			headers[ChunkSHA256Header] = chunkDigest
+
+			chunk := &chunkUploadContext{
+				chunkID:          chunkID,
+				chunkPath:        fmt.Sprintf("/v2/upload/%s/chunk/%d", uploadID, chunkID),
+				chunkSize:        currentChunkSize,
+				chunkStartOffset: offset,
+				file:             file,
+				headers:          headers,
+				sha256:           chunkDigest,
+				progress:         progress,
+				limiter:          limiter,
+			}
+			if err := i.uploadChunkWithRetry(gctx, chunk, defaultMaxRetries); err != nil {
+				return err
+			}
 
-		chunkID++
+			stateMu.Lock()
+			defer stateMu.Unlock()
+			state.CompletedChunks = append(state.CompletedChunks, chunkID)
+			return state.save()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, "", err
+	}
+	// Chunks are not scheduled anymore once ctx is done
+	if err := ctx.Err(); err != nil {
+		return 0, "", err
 	}
-	return uploadedBytes, nil
+	return totalSize, hex.EncodeToString(fileHash.Sum(nil)), nil
 }
 
-func (i *TursoServerClient) finalizeUpload(uploadID string) error {
-	r, err := i.client.Put(fmt.Sprintf("/v2/upload/%s/finalize", uploadID), nil)
+// finalizeUpload completes the upload of a file whose hex SHA-256 digest is
+// digest.
+func (i *TursoServerClient) finalizeUpload(ctx context.Context, uploadID, digest string) error {
+	headers := map[string]string{"Content-Type": "application/json", UploadSHA256Header: digest}
+	r, err := i.client.do(ctx, http.MethodPut, fmt.Sprintf("/v2/upload/%s/finalize", uploadID), nil, headers)
 	if err != nil {
 		return fmt.Errorf("failed to finalize multipart upload: %w", err)
 	}
@@ -434,13 +534,30 @@ func (i *TursoServerClient) finalizeUpload(uploadID string) error {
 	if r.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			return fmt.Errorf("finalize multipart upload failed with status code %d and error reading response: %v", r.StatusCode, err)
+			return apiErrorf(r, "finalize multipart upload failed with status code %d and error reading response: %v", r.StatusCode, err)
 		}
-		return fmt.Errorf("finalize multipart upload failed with status code %d: %s", r.StatusCode, string(body))
+		return apiErrorf(r, "finalize multipart upload failed with status code %d: %s", r.StatusCode, string(body))
+	}
+	if err := checkDigest(r, UploadSHA256Header, digest); err != nil {
+		return fmt.Errorf("%w: %v", errUploadCorrupted, err)
 	}
 	return nil
 }
 
+// errUploadCorrupted is returned when the server finalized an upload whose
+// digest differs from the one of the file.
+var errUploadCorrupted = errors.New("the uploaded database is corrupted")
+
+// checkDigest compares the digest the server sent back in header, if any,
+// with the expected one.
+func checkDigest(r *http.Response, header, expected string) error {
+	received := r.Header.Get(header)
+	if received == "" || strings.EqualFold(received, expected) {
+		return nil
+	}
+	return fmt.Errorf("the server received data with SHA-256 %s, expected %s", received, expected)
+}
+
 type ExportInfo struct {
 	CurrentGeneration int `json:"current_generation"`
 }
@@ -448,216 +565,638 @@ type ExportInfo struct {
 const EncryptionKeyHeader = "x-turso-encryption-key"
 const EncryptionCipherHeader = "x-turso-encryption-cipher"
 
-func (i *TursoServerClient) Export(outputFile string, withMetadata bool, remoteEncryptionKey string) error {
+// ChunkSHA256Header holds the hex SHA-256 digest of an uploaded chunk, and
+// UploadSHA256Header the one of the whole database file when the upload is
+// finalized. The server may send back the digest of the data it received in
+// the same header of its response.
+const ChunkSHA256Header = "x-turso-chunk-sha256"
+const UploadSHA256Header = "x-turso-upload-sha256"
+
+// ExportOptions configures TursoServerClient.Export.
+type ExportOptions struct {
+	WithMetadata        bool
+	RemoteEncryptionKey string
+	// Resume continues the interrupted export to the output file, if any.
+	// The export starts over when the database generation changed since.
+	Resume bool
+	// Checkpoint applies the WAL frames to the database file, so that the
+	// export is a single file.
+	Checkpoint bool
+	// OnProgress, if set, is called as the snapshot and the WAL frames are
+	// downloaded.
+	OnProgress func(ExportProgress)
+}
+
+// ExportProgress is the progress of an export reported to
+// ExportOptions.OnProgress.
+type ExportProgress struct {
+	// SnapshotBytes of the SnapshotSize bytes of the snapshot are downloaded.
+	SnapshotBytes int64
+	SnapshotSize  int64
+	// Frames WAL frames are exported, WALBytes of them downloaded. How many
+	// frames there are is only known once they are all downloaded.
+	Frames   int
+	WALBytes int64
+	Elapsed  time.Duration
+	// ETA is the estimated time left to download the snapshot, or 0 when
+	// unknown.
+	ETA time.Duration
+}
+
+// exportProgress tracks the progress of an export for ExportOptions.OnProgress.
+// A nil *exportProgress reports nothing.
+type exportProgress struct {
+	onProgress func(ExportProgress)
+	startTime  time.Time
+	progress   ExportProgress
+}
+
+func newExportProgress(onProgress func(ExportProgress)) *exportProgress {
+	if onProgress == nil {
+		return nil
+	}
+	return &exportProgress{onProgress: onProgress, startTime: time.Now()}
+}
+
+// snapshotReader returns a reader of the snapshot in body, starting at
+// offset, that reports the bytes read. size is the length of the body, or -1
+// if unknown.
+func (p *exportProgress) snapshotReader(body io.Reader, offset, size int64) io.Reader {
+	if p == nil || size < 0 {
+		return body
+	}
+	p.progress.SnapshotBytes = offset
+	p.progress.SnapshotSize = offset + size
+	p.report()
+	return &progressReader{
+		reader:     body,
+		totalSize:  offset + size,
+		baseBytes:  offset,
+		startTime:  time.Now(),
+		lastUpdate: -1,
+		onProgress: func(_ int, readBytes int64, totalBytes int64, elapsedTime time.Duration, done bool) {
+			p.progress.SnapshotBytes = readBytes
+			p.progress.ETA = 0
+			if read := readBytes - offset; read > 0 && !done {
+				p.progress.ETA = time.Duration(float64(elapsedTime) * float64(totalBytes-readBytes) / float64(read))
+			}
+			p.report()
+		},
+	}
+}
+
+// frames records that the WAL holds frames frames, walBytes of them downloaded.
+func (p *exportProgress) frames(frames int, walBytes int64) {
+	if p == nil {
+		return
+	}
+	p.progress.Frames = frames
+	p.progress.WALBytes = walBytes
+	p.progress.ETA = 0
+	p.report()
+}
+
+func (p *exportProgress) report() {
+	p.progress.Elapsed = time.Since(p.startTime)
+	p.onProgress(p.progress)
+}
+
+// ExportResult describes a completed export.
+type ExportResult struct {
+	Generation  int
+	LastFrameNo int
+	// Resumed is set when an interrupted export was continued, and Restarted
+	// when it could not be because the database generation changed.
+	Resumed   bool
+	Restarted bool
+	// CheckpointedFrames is the number of WAL frames applied to the database
+	// file with ExportOptions.Checkpoint.
+	CheckpointedFrames int
+}
+
+const (
+	walHeaderSize = 32
+	walPageSize   = 4096
+	walFrameSize  = 24 + walPageSize
+
+	// exportCheckpointBytes is how much of the snapshot is written between
+	// two saves of the export state.
+	exportCheckpointBytes = 64 * 1024 * 1024
+
+	// walBatchSize is the number of frames fetched by a /sync request, and
+	// walFetchConcurrency how many requests are in flight at most.
+	walBatchSize        = 128
+	walFetchConcurrency = 4
+)
+
+// Export writes the current generation of the database to outputFile and its
+// WAL frames to outputFile-wal. Progress is saved to a state file next to
+// outputFile while exporting, and kept if the export fails, so that it can be
+// resumed with ExportOptions.Resume.
+func (i *TursoServerClient) Export(ctx context.Context, outputFile string, opts ExportOptions) (ExportResult, error) {
+	headers := map[string]string{}
+	if opts.RemoteEncryptionKey != "" {
+		headers[EncryptionKeyHeader] = opts.RemoteEncryptionKey
+	}
+	info, err := i.info(ctx, headers)
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	result := ExportResult{Generation: info.CurrentGeneration}
+	state := &exportState{Generation: info.CurrentGeneration}
+	if opts.Resume {
+		saved, err := readExportState(outputFile)
+		if err != nil {
+			return result, err
+		}
+		if saved != nil && saved.Generation == info.CurrentGeneration {
+			state = saved
+			result.Resumed = true
+		} else if saved != nil {
+			result.Restarted = true
+		}
+	}
+	if !result.Resumed {
+		removeExportFiles(outputFile)
+	}
+
+	progress := newExportProgress(opts.OnProgress)
+	if !state.SnapshotDone {
+		if err := i.exportSnapshot(ctx, outputFile, state, headers, progress); err != nil {
+			return result, err
+		}
+	}
+	checkpoint := func() error { return state.save(outputFile) }
+	if err := i.exportWAL(ctx, outputFile, state, headers, progress, checkpoint); err != nil {
+		return result, fmt.Errorf("failed to export WAL: %w", err)
+	}
+	result.LastFrameNo = state.LastFrameNo
+	if opts.WithMetadata {
+		if err := i.ExportMetadata(ctx, outputFile, &info, state.LastFrameNo); err != nil {
+			return result, fmt.Errorf("failed to export metadata: %w", err)
+		}
+	}
+	if opts.Checkpoint {
+		if result.CheckpointedFrames, err = checkpointWAL(outputFile); err != nil {
+			return result, fmt.Errorf("failed to checkpoint WAL: %w", err)
+		}
+	}
+
+	removeExportState(outputFile)
+	return result, nil
+}
+
+// PullResult describes the frames added to an export by Pull.
+type PullResult struct {
+	Generation  int
+	LastFrameNo int
+	NewFrames   int
+	// Exported is set when the database generation changed since the
+	// export, so that it was exported again.
+	Exported bool
+}
+
+// Pull brings an export written with metadata up to date, by appending the
+// WAL frames written since to its WAL file. When the generation of the
+// database changed, the database is exported again.
+//
+// If SQLite checkpointed the WAL into the database file since, the new frames
+// are written to a new WAL file.
+func (i *TursoServerClient) Pull(ctx context.Context, outputFile string, remoteEncryptionKey string) (PullResult, error) {
+	if ExportInProgress(outputFile) {
+		return i.pullExport(ctx, outputFile, remoteEncryptionKey)
+	}
+	metadata, err := readExportMetadata(outputFile)
+	if err != nil {
+		return PullResult{}, err
+	}
+	if _, err := os.Stat(outputFile); err != nil {
+		return PullResult{}, fmt.Errorf("failed to open exported database: %w", err)
+	}
+
 	headers := map[string]string{}
 	if remoteEncryptionKey != "" {
 		headers[EncryptionKeyHeader] = remoteEncryptionKey
 	}
-	res, err := i.client.GetWithHeaders("/info", nil, headers)
+	info, err := i.info(ctx, headers)
+	if err != nil {
+		return PullResult{}, err
+	}
+	if info.CurrentGeneration != metadata.Generation {
+		return i.pullExport(ctx, outputFile, remoteEncryptionKey)
+	}
+
+	wal, ok, err := readWALState(outputFile + "-wal")
+	if err != nil {
+		return PullResult{}, err
+	}
+	state := &exportState{Generation: metadata.Generation, LastFrameNo: metadata.DurableFrameNum}
+	switch {
+	case ok && wal.Frames == metadata.DurableFrameNum-metadata.WALBase:
+		state.Salt = wal.Salt
+		state.Checksum = wal.Checksum
+		state.WALBase = metadata.WALBase
+	case !ok || wal.Frames == 0:
+		if err := createWAL(outputFile, state); err != nil {
+			return PullResult{}, err
+		}
+	default:
+		return PullResult{}, fmt.Errorf("%s-wal has %d frames but the export metadata has %d, export the database again", outputFile, wal.Frames, metadata.DurableFrameNum-metadata.WALBase)
+	}
+
+	checkpoint := func() error {
+		return writeExportMetadata(outputFile, state.Generation, state.LastFrameNo, state.WALBase)
+	}
+	if err := i.exportWAL(ctx, outputFile, state, headers, nil, checkpoint); err != nil {
+		return PullResult{}, fmt.Errorf("failed to pull WAL: %w", err)
+	}
+	return PullResult{
+		Generation:  state.Generation,
+		LastFrameNo: state.LastFrameNo,
+		NewFrames:   state.LastFrameNo - metadata.DurableFrameNum,
+	}, nil
+}
+
+// pullExport exports the database again for Pull, resuming the export an
+// earlier Pull started if it was interrupted.
+func (i *TursoServerClient) pullExport(ctx context.Context, outputFile string, remoteEncryptionKey string) (PullResult, error) {
+	export, err := i.Export(ctx, outputFile, ExportOptions{WithMetadata: true, RemoteEncryptionKey: remoteEncryptionKey, Resume: true})
+	if err != nil {
+		return PullResult{}, err
+	}
+	return PullResult{Generation: export.Generation, LastFrameNo: export.LastFrameNo, NewFrames: export.LastFrameNo, Exported: true}, nil
+}
+
+// info returns the current generation of the database.
+func (i *TursoServerClient) info(ctx context.Context, headers map[string]string) (ExportInfo, error) {
+	res, err := i.client.GetWithHeaders(ctx, "/info", nil, headers)
 	if err != nil {
-		return fmt.Errorf("failed to fetch database info: %w", err)
+		return ExportInfo{}, fmt.Errorf("failed to fetch database info: %w", err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return parseResponseError(res)
+		return ExportInfo{}, parseResponseError(res)
 	}
 	var info ExportInfo
 	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
-		return fmt.Errorf("failed to decode /info response: %w", err)
+		return ExportInfo{}, fmt.Errorf("failed to decode /info response: %w", err)
 	}
+	return info, nil
+}
 
-	exportRes, err := i.client.GetWithHeaders(fmt.Sprintf("/export/%d", info.CurrentGeneration), nil, headers)
-	if err != nil {
-		return fmt.Errorf("failed to fetch export: %w", err)
+// removeExportFiles removes the database, WAL, metadata and state files
+// written by Export for outputFile. Missing files are ignored.
+func removeExportFiles(outputFile string) {
+	for _, file := range []string{outputFile, outputFile + "-wal", outputFile + "-info", exportStatePath(outputFile)} {
+		_ = os.Remove(file)
 	}
-	defer exportRes.Body.Close()
-	if exportRes.StatusCode != http.StatusOK {
-		return parseResponseError(exportRes)
+}
+
+// exportSnapshot downloads the snapshot of the generation of state, from the
+// offset where a previous attempt stopped, and creates the WAL file.
+func (i *TursoServerClient) exportSnapshot(ctx context.Context, outputFile string, state *exportState, headers map[string]string, progress *exportProgress) error {
+	res, body, err := i.fetchSnapshot(ctx, state, headers, progress)
+	if err != nil {
+		return err
 	}
+	defer res.Body.Close()
 
-	out, err := os.Create(outputFile)
+	out, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
 		return fmt.Errorf("failed to create output file: %w", err)
 	}
 	defer out.Close()
-	if _, err := io.Copy(out, exportRes.Body); err != nil {
-		return fmt.Errorf("failed to write export to file: %w", err)
+	// Anything past the saved offset may not have been synced
+	if err := out.Truncate(state.SnapshotBytes); err != nil {
+		return fmt.Errorf("failed to truncate output file: %w", err)
+	}
+	if _, err := out.Seek(state.SnapshotBytes, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek output file: %w", err)
 	}
 
-	lastFrameNo, err := i.ExportWAL(outputFile, &info, remoteEncryptionKey)
+	for {
+		n, err := io.CopyN(out, body, exportCheckpointBytes)
+		if n > 0 {
+			if err := out.Sync(); err != nil {
+				return fmt.Errorf("failed to sync output file: %w", err)
+			}
+			state.SnapshotBytes += n
+			if err := state.save(outputFile); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write export to file: %w", err)
+		}
+	}
+
+	if err := createWAL(outputFile, state); err != nil {
+		return err
+	}
+	state.SnapshotDone = true
+	return state.save(outputFile)
+}
+
+// fetchSnapshot requests the snapshot of the generation of state from
+// state.SnapshotBytes, and returns the response and a reader of the snapshot
+// from that offset. The caller must close the response body.
+func (i *TursoServerClient) fetchSnapshot(ctx context.Context, state *exportState, headers map[string]string, progress *exportProgress) (*http.Response, io.Reader, error) {
+	requestHeaders := map[string]string{}
+	for k, v := range headers {
+		requestHeaders[k] = v
+	}
+	if state.SnapshotBytes > 0 {
+		requestHeaders["Range"] = fmt.Sprintf("bytes=%d-", state.SnapshotBytes)
+	}
+	res, err := i.client.GetWithHeaders(ctx, fmt.Sprintf("/export/%d", state.Generation), nil, requestHeaders)
 	if err != nil {
-		return fmt.Errorf("failed to export WAL: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch export: %w", err)
 	}
-	if withMetadata {
-		if err := i.ExportMetadata(outputFile, &info, lastFrameNo); err != nil {
-			return fmt.Errorf("failed to export metadata: %w", err)
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the range, skip what was already written
+		if _, err := io.CopyN(io.Discard, res.Body, state.SnapshotBytes); err != nil {
+			res.Body.Close()
+			return nil, nil, fmt.Errorf("failed to skip exported data: %w", err)
 		}
+	default:
+		defer res.Body.Close()
+		return nil, nil, parseResponseError(res)
 	}
-
-	return nil
+	return res, progress.snapshotReader(res.Body, state.SnapshotBytes, res.ContentLength), nil
 }
 
-func (i *TursoServerClient) ExportWAL(outputFile string, info *ExportInfo, remoteEncryptionKey string) (int, error) {
-	walFile := outputFile + "-wal"
-	walOut, err := os.Create(walFile)
+// createWAL writes the header of a new WAL file with random salts, and
+// records the salts and the header checksum in state. Frames after
+// state.LastFrameNo will be appended to it.
+func createWAL(outputFile string, state *exportState) error {
+	walHeader, err := newWALHeader(state)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create WAL file: %w", err)
+		return err
+	}
+	walOut, err := os.Create(outputFile + "-wal")
+	if err != nil {
+		return fmt.Errorf("failed to create WAL file: %w", err)
 	}
 	defer walOut.Close()
+	if _, err := walOut.Write(walHeader); err != nil {
+		return fmt.Errorf("failed to write WAL header: %w", err)
+	}
+	if err := walOut.Sync(); err != nil {
+		return fmt.Errorf("failed to sync WAL file: %w", err)
+	}
+	return nil
+}
 
+// newWALHeader returns the header of a new WAL with random salts, and records
+// the salts and the header checksum in state.
+func newWALHeader(state *exportState) ([]byte, error) {
 	var saltBytes [8]byte
 	if _, err := rand.Read(saltBytes[:]); err != nil {
-		return 0, fmt.Errorf("failed to generate random salt values: %w", err)
+		return nil, fmt.Errorf("failed to generate random salt values: %w", err)
 	}
 	salt1 := binary.BigEndian.Uint32(saltBytes[0:4]) // Random salt-1
 	salt2 := binary.BigEndian.Uint32(saltBytes[4:8]) // Random salt-2
 
-	walHeader := make([]byte, 32)
-	binary.BigEndian.PutUint32(walHeader[0:4], 0x377f0682) // Magic number
-	binary.BigEndian.PutUint32(walHeader[4:8], 3007000)    // File format version
-	binary.BigEndian.PutUint32(walHeader[8:12], 4096)      // Database page size
-	binary.BigEndian.PutUint32(walHeader[12:16], 0)        // Checkpoint sequence number
-	binary.BigEndian.PutUint32(walHeader[16:20], salt1)    // Salt-1 (must match frames)
-	binary.BigEndian.PutUint32(walHeader[20:24], salt2)    // Salt-2 (must match frames)
-
-	s0 := uint32(0)
-	s1 := uint32(0)
-
-	for i := 0; i < 24; i += 8 {
-		x0 := binary.LittleEndian.Uint32(walHeader[i : i+4])
-		x1 := binary.LittleEndian.Uint32(walHeader[i+4 : i+8])
-		s0 += x0 + s1
-		s1 += x1 + s0
-	}
+	walHeader := make([]byte, walHeaderSize)
+	binary.BigEndian.PutUint32(walHeader[0:4], walMagic)     // Magic number
+	binary.BigEndian.PutUint32(walHeader[4:8], 3007000)      // File format version
+	binary.BigEndian.PutUint32(walHeader[8:12], walPageSize) // Database page size
+	binary.BigEndian.PutUint32(walHeader[12:16], 0)          // Checkpoint sequence number
+	binary.BigEndian.PutUint32(walHeader[16:20], salt1)      // Salt-1 (must match frames)
+	binary.BigEndian.PutUint32(walHeader[20:24], salt2)      // Salt-2 (must match frames)
 
+	s0, s1 := walChecksum(0, 0, walHeader[:24])
 	binary.BigEndian.PutUint32(walHeader[24:28], s0)
 	binary.BigEndian.PutUint32(walHeader[28:32], s1)
 
-	if _, err := walOut.Write(walHeader); err != nil {
-		return 0, fmt.Errorf("failed to write WAL header: %w", err)
-	}
+	state.Salt = [2]uint32{salt1, salt2}
+	state.Checksum = [2]uint32{s0, s1}
+	state.WALBase = state.LastFrameNo
+	return walHeader, nil
+}
 
-	const batchSize = 128
-	frameNo := 1
-	lastFrameNo := 0
-	headers := map[string]string{}
-	if remoteEncryptionKey != "" {
-		headers[EncryptionKeyHeader] = remoteEncryptionKey
+// exportWAL appends the WAL frames of the generation of state after
+// state.LastFrameNo to the WAL file. Batches of frames are fetched
+// concurrently and written in order. checkpoint is called after every batch of
+// frames, once they are synced to disk.
+func (i *TursoServerClient) exportWAL(ctx context.Context, outputFile string, state *exportState, headers map[string]string, progress *exportProgress, checkpoint func() error) error {
+	walOut, err := os.OpenFile(outputFile+"-wal", os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open WAL file: %w", err)
 	}
-
-	for {
-		walRes, err := i.client.GetWithHeaders(fmt.Sprintf("/sync/%d/%d/%d", info.CurrentGeneration, frameNo, frameNo+batchSize), nil, headers)
-		if err != nil {
-			if frameNo == 1 {
-				break
-			}
-			return lastFrameNo, fmt.Errorf("failed to fetch WAL frames: %w", err)
+	defer walOut.Close()
+	walSize := int64(walHeaderSize) + int64(state.LastFrameNo-state.WALBase)*walFrameSize
+	if err := walOut.Truncate(walSize); err != nil {
+		return fmt.Errorf("failed to truncate WAL file: %w", err)
+	}
+	if _, err := walOut.Seek(walSize, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek WAL file: %w", err)
+	}
+	return i.streamWAL(ctx, walOut, state, headers, progress, func() error {
+		if err := walOut.Sync(); err != nil {
+			return fmt.Errorf("failed to sync WAL file: %w", err)
 		}
+		return checkpoint()
+	})
+}
 
-		if walRes.StatusCode == http.StatusBadRequest || walRes.StatusCode == http.StatusInternalServerError {
-			walRes.Body.Close()
-			break
-		}
-		if walRes.StatusCode != http.StatusOK {
-			walRes.Body.Close()
-			if frameNo == 1 {
-				break
+// streamWAL writes the WAL frames of the generation of state after
+// state.LastFrameNo to w, with salts and checksums following state. Batches
+// of frames are fetched concurrently and written in order, and checkpoint is
+// called after every batch once state is updated.
+func (i *TursoServerClient) streamWAL(ctx context.Context, w io.Writer, state *exportState, headers map[string]string, progress *exportProgress, checkpoint func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Batches are queued in order, the one being written and the queued ones
+	// being fetched, so that at most walFetchConcurrency are fetched at once.
+	batches := make(chan chan walBatch, walFetchConcurrency-1)
+	go func(generation, frameNo int) {
+		defer close(batches)
+		for ; ; frameNo += walBatchSize {
+			batch := make(chan walBatch, 1)
+			select {
+			case batches <- batch:
+			case <-ctx.Done():
+				return
 			}
-			return lastFrameNo, parseResponseError(walRes)
+			go func(frameNo int) {
+				batch <- i.fetchWALBatch(ctx, generation, frameNo, headers)
+			}(frameNo)
 		}
+	}(state.Generation, state.LastFrameNo+1)
 
-		frames, err := io.ReadAll(walRes.Body)
-		walRes.Body.Close()
-		if err != nil {
-			return lastFrameNo, fmt.Errorf("failed to read WAL frames: %w", err)
-		}
+	salt1, salt2 := state.Salt[0], state.Salt[1]
+	s0, s1 := state.Checksum[0], state.Checksum[1]
+	downloaded := int64(0)
 
-		if len(frames) == 0 {
-			break
+	for batch := range batches {
+		result := <-batch
+		if result.err != nil {
+			return result.err
 		}
-
-		frameSize := 4120
-		framesInBatch := len(frames) / frameSize
+		frames := result.frames
+		framesInBatch := len(frames) / walFrameSize
 
 		for i := 0; i < framesInBatch; i++ {
-			offset := i * frameSize
-			if offset+frameSize > len(frames) {
-				return lastFrameNo, fmt.Errorf("invalid frame data: expected %d bytes, got %d", frameSize, len(frames)-offset)
-			}
-			frame := frames[offset : offset+frameSize]
+			offset := i * walFrameSize
+			frame := frames[offset : offset+walFrameSize]
 
 			binary.BigEndian.PutUint32(frame[8:12], salt1)
 			binary.BigEndian.PutUint32(frame[12:16], salt2)
 
-			x0 := binary.LittleEndian.Uint32(frame[0:4])
-			x1 := binary.LittleEndian.Uint32(frame[4:8])
-			s0 += x0 + s1
-			s1 += x1 + s0
-
-			for j := 24; j < frameSize; j += 8 {
-				x0 := binary.LittleEndian.Uint32(frame[j : j+4])
-				x1 := binary.LittleEndian.Uint32(frame[j+4 : j+8])
-				s0 += x0 + s1
-				s1 += x1 + s0
-			}
+			s0, s1 = walChecksum(s0, s1, frame[:8])
+			s0, s1 = walChecksum(s0, s1, frame[24:])
 
 			binary.BigEndian.PutUint32(frame[16:20], s0)
 			binary.BigEndian.PutUint32(frame[20:24], s1)
+		}
 
-			if _, err := walOut.Write(frame); err != nil {
-				return lastFrameNo, fmt.Errorf("failed to write WAL frame: %w", err)
+		if framesInBatch > 0 {
+			if _, err := w.Write(frames); err != nil {
+				return fmt.Errorf("failed to write WAL frames: %w", err)
 			}
-
-			lastFrameNo = frameNo + i
+			state.LastFrameNo += framesInBatch
+			state.Checksum = [2]uint32{s0, s1}
+			if err := checkpoint(); err != nil {
+				return err
+			}
+			downloaded += int64(len(frames))
+			progress.frames(state.LastFrameNo, downloaded)
 		}
 
-		if framesInBatch < batchSize {
+		if result.last {
 			break
 		}
-
-		frameNo += framesInBatch
 	}
 
-	if err := walOut.Sync(); err != nil {
-		return lastFrameNo, fmt.Errorf("failed to sync WAL file: %w", err)
-	}
+	return nil
+}
 
-	return lastFrameNo, nil
+// walBatch is the result of fetching a batch of WAL frames.
+type walBatch struct {
+	frames []byte
+	// last is set when there are no frames after the batch.
+	last bool
+	err  error
 }
 
-func (i *TursoServerClient) ExportMetadata(outputFile string, info *ExportInfo, durableFrameNum int) error {
-	out, err := os.Create(outputFile + "-info")
+// fetchWALBatch fetches the batch of WAL frames starting at frameNo.
+func (i *TursoServerClient) fetchWALBatch(ctx context.Context, generation, frameNo int, headers map[string]string) walBatch {
+	requestHeaders := map[string]string{}
+	for k, v := range headers {
+		requestHeaders[k] = v
+	}
+	// The server ends the WAL with 400 or 500, which is not worth retrying
+	syncCtx := withFinalStatuses(ctx, http.StatusBadRequest, http.StatusInternalServerError)
+	walRes, err := i.client.GetWithHeaders(syncCtx, fmt.Sprintf("/sync/%d/%d/%d", generation, frameNo, frameNo+walBatchSize), nil, requestHeaders)
 	if err != nil {
-		return fmt.Errorf("failed to create info file: %w", err)
+		if frameNo == 1 && ctx.Err() == nil {
+			return walBatch{last: true}
+		}
+		return walBatch{err: fmt.Errorf("failed to fetch WAL frames: %w", err)}
 	}
-	defer out.Close()
+	defer walRes.Body.Close()
 
+	if walRes.StatusCode == http.StatusBadRequest || walRes.StatusCode == http.StatusInternalServerError {
+		return walBatch{last: true}
+	}
+	if walRes.StatusCode != http.StatusOK {
+		if frameNo == 1 {
+			return walBatch{last: true}
+		}
+		return walBatch{err: parseResponseError(walRes)}
+	}
+
+	frames, err := io.ReadAll(walRes.Body)
+	if err != nil {
+		return walBatch{err: fmt.Errorf("failed to read WAL frames: %w", err)}
+	}
+	framesInBatch := len(frames) / walFrameSize
+	return walBatch{
+		frames: frames[:framesInBatch*walFrameSize],
+		last:   framesInBatch < walBatchSize,
+	}
+}
+
+// ExportMetadata writes the -info file of an export whose WAL file holds every
+// frame up to durableFrameNum.
+func (i *TursoServerClient) ExportMetadata(ctx context.Context, outputFile string, info *ExportInfo, durableFrameNum int) error {
+	return writeExportMetadata(outputFile, info.CurrentGeneration, durableFrameNum, 0)
+}
+
+// exportMetadata is the content of the -info file of an export, in the
+// format of the metadata of libSQL embedded replicas.
+type exportMetadata struct {
+	Hash            int `json:"hash"`
+	Version         int `json:"version"`
+	DurableFrameNum int `json:"durable_frame_num"`
+	Generation      int `json:"generation"`
+	// WALBase is the number of the frame preceding the first frame of the
+	// WAL file, once Pull started a new WAL file after a checkpoint. It is
+	// not part of the libSQL format, so it is not hashed.
+	WALBase int `json:"wal_base,omitempty"`
+}
+
+func (m *exportMetadata) computeHash() int {
 	hasher := crc32.New(crc32.MakeTable(crc32.IEEE))
 	var versionBytes [4]byte
 	var durableFrameNumBytes [4]byte
 	var generationBytes [4]byte
-	binary.LittleEndian.PutUint32(versionBytes[:], 0)
-	binary.LittleEndian.PutUint32(durableFrameNumBytes[:], uint32(durableFrameNum))
-	binary.LittleEndian.PutUint32(generationBytes[:], uint32(info.CurrentGeneration))
+	binary.LittleEndian.PutUint32(versionBytes[:], uint32(m.Version))
+	binary.LittleEndian.PutUint32(durableFrameNumBytes[:], uint32(m.DurableFrameNum))
+	binary.LittleEndian.PutUint32(generationBytes[:], uint32(m.Generation))
 	hasher.Write(versionBytes[:])
 	hasher.Write(durableFrameNumBytes[:])
 	hasher.Write(generationBytes[:])
-	hash := int(hasher.Sum32())
-
-	metadata := struct {
-		Hash            int `json:"hash"`
-		Version         int `json:"version"`
-		DurableFrameNum int `json:"durable_frame_num"`
-		Generation      int `json:"generation"`
-	}{
-		Hash:            hash,
+	return int(hasher.Sum32())
+}
+
+// writeExportMetadata replaces the -info file of outputFile atomically.
+func writeExportMetadata(outputFile string, generation, durableFrameNum, walBase int) error {
+	data, err := encodeExportMetadata(generation, durableFrameNum, walBase)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(outputFile+"-info", data); err != nil {
+		return fmt.Errorf("failed to write metadata to file: %w", err)
+	}
+	return nil
+}
+
+// encodeExportMetadata returns the content of the -info file of an export.
+func encodeExportMetadata(generation, durableFrameNum, walBase int) ([]byte, error) {
+	metadata := exportMetadata{
 		Version:         0,
 		DurableFrameNum: durableFrameNum,
-		Generation:      info.CurrentGeneration,
+		Generation:      generation,
+		WALBase:         walBase,
 	}
-	if err := json.NewEncoder(out).Encode(metadata); err != nil {
-		return fmt.Errorf("failed to write metadata to file: %w", err)
+	metadata.Hash = metadata.computeHash()
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
 	}
+	return append(data, '\n'), nil
+}
 
-	return nil
+// readExportMetadata reads the -info file of outputFile and checks its hash.
+func readExportMetadata(outputFile string) (exportMetadata, error) {
+	data, err := os.ReadFile(outputFile + "-info")
+	if err != nil {
+		return exportMetadata{}, fmt.Errorf("failed to read export metadata: %w", err)
+	}
+	var metadata exportMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return exportMetadata{}, fmt.Errorf("invalid export metadata %s-info: %w", outputFile, err)
+	}
+	if metadata.Hash != metadata.computeHash() {
+		return exportMetadata{}, fmt.Errorf("invalid export metadata %s-info: hash mismatch", outputFile)
+	}
+	return metadata, nil
 }
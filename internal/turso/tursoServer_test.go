@@ -2,7 +2,10 @@ package turso
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -40,19 +44,25 @@ type MockTursoServer struct {
 	failAtChunk       int // -1 means no failure
 	failAtChunkStatus int // Status code to return when failing (default 400 - non-retriable)
 	failAtEndpoint    string
+
+	// Digest simulation: the server sends back a wrong digest for this chunk
+	// (-1 means none), or for the whole upload
+	corruptChunkDigest  int
+	corruptUploadDigest bool
 }
 
 func NewMockTursoServer() *MockTursoServer {
 	mock := &MockTursoServer{
-		chunkData:         make(map[int][]byte),
-		receivedHeaders:   make(map[string][]string),
-		startUploadStatus: http.StatusOK,
-		chunkUploadStatus: http.StatusOK,
-		finalizeStatus:    http.StatusOK,
-		chunkSize:         1024 * 1024, // 1MB default
-		failAtChunk:       -1,
-		failAtChunkStatus: http.StatusTeapot, // Non-retriable, to avoid holding up the test
-		uploadID:          "test-upload-id",
+		chunkData:          make(map[int][]byte),
+		receivedHeaders:    make(map[string][]string),
+		startUploadStatus:  http.StatusOK,
+		chunkUploadStatus:  http.StatusOK,
+		finalizeStatus:     http.StatusOK,
+		chunkSize:          1024 * 1024, // 1MB default
+		failAtChunk:        -1,
+		failAtChunkStatus:  http.StatusTeapot, // Non-retriable, to avoid holding up the test
+		uploadID:           "test-upload-id",
+		corruptChunkDigest: -1,
 	}
 
 	mock.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -105,7 +115,17 @@ func (m *MockTursoServer) handleChunkUpload(w http.ResponseWriter, r *http.Reque
 	}
 
 	data, _ := io.ReadAll(r.Body)
+	digest := sha256Hex(data)
+	if expected := r.Header.Get(ChunkSHA256Header); expected != digest {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error": "chunk digest %s does not match %s"}`, expected, digest)
+		return
+	}
 	m.chunkData[chunkID] = data
+	if m.corruptChunkDigest == chunkID {
+		digest = sha256Hex(append(data, 0))
+	}
+	w.Header().Set(ChunkSHA256Header, digest)
 	w.WriteHeader(m.chunkUploadStatus)
 }
 
@@ -116,9 +136,22 @@ func (m *MockTursoServer) handleFinalize(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	var data []byte
+	for i := 0; i < len(m.chunkData); i++ {
+		data = append(data, m.chunkData[i]...)
+	}
+	if m.corruptUploadDigest {
+		data = append(data, 0)
+	}
+	w.Header().Set(UploadSHA256Header, sha256Hex(data))
 	w.WriteHeader(m.finalizeStatus)
 }
 
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // GetAllChunkData reconstructs the full data from all chunks in order
 func (m *MockTursoServer) GetAllChunkData() []byte {
 	m.mu.Lock()
@@ -186,7 +219,7 @@ func createTestClient(t *testing.T, serverURL string) *TursoServerClient {
 		return "test-token", nil
 	}
 
-	client, err := NewTursoServerClient(baseURL, tokenProvider, 5*time.Minute, "test-version", "test-org")
+	client, err := NewTursoServerClient(baseURL, tokenProvider, 5*time.Minute, "test-version", "test-org", http.DefaultClient)
 	require.NoError(t, err)
 
 	return &client
@@ -286,7 +319,7 @@ func TestUploadFileMultipart_Success(t *testing.T) {
 	testFile := createTestFile(t, 5*1024) // 5KB = 5 chunks
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mock.mu.Lock()
@@ -310,7 +343,7 @@ func TestUploadFileMultipart_SingleChunk(t *testing.T) {
 	testFile := createTestFile(t, 1024) // 1KB file = 1 chunk
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mock.mu.Lock()
@@ -329,7 +362,7 @@ func TestUploadFileMultipart_ExactlyDivisible(t *testing.T) {
 	testFile := createTestFile(t, 3*1024) // 3KB = exactly 3 chunks
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mock.mu.Lock()
@@ -357,7 +390,7 @@ func TestUploadFileMultipart_NotDivisible(t *testing.T) {
 	testFile := createTestFile(t, 2500) // 2500 bytes = 2 full + 1 partial (452 bytes)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mock.mu.Lock()
@@ -382,7 +415,7 @@ func TestUploadFileMultipart_WithEncryption(t *testing.T) {
 	testFile := createTestFile(t, 2048)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "aes-256-cbc", "base64key==", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "aes-256-cbc", "base64key==", progress.Callback())
 	require.NoError(t, err)
 
 	require.Equal(t, "aes-256-cbc", mock.GetHeader("X-Turso-Encryption-Cipher"))
@@ -399,7 +432,7 @@ func TestUploadFileMultipart_StartFailure(t *testing.T) {
 	testFile := createTestFile(t, 2048)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "initiate multipart upload failed")
 }
@@ -414,7 +447,7 @@ func TestUploadFileMultipart_ChunkFailure_FirstChunk(t *testing.T) {
 	testFile := createTestFile(t, 3*1024)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "chunk 0")
 }
@@ -426,10 +459,12 @@ func TestUploadFileMultipart_ChunkFailure_MiddleChunk(t *testing.T) {
 	defer mock.Close()
 
 	client := createTestClient(t, mock.URL)
+	// The chunks after the failed one are not sent
+	client.SetUploadOptions(UploadOptions{Concurrency: 1})
 	testFile := createTestFile(t, 5*1024) // 5 chunks
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "chunk 2")
 
@@ -451,7 +486,7 @@ func TestUploadFileMultipart_ChunkFailure_LastChunk(t *testing.T) {
 	testFile := createTestFile(t, 5*1024) // 5 chunks
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "chunk 4")
 }
@@ -467,7 +502,7 @@ func TestUploadFileMultipart_FinalizeFailure(t *testing.T) {
 	testFile := createTestFile(t, 2*1024)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "finalize multipart upload failed")
 
@@ -479,6 +514,210 @@ func TestUploadFileMultipart_FinalizeFailure(t *testing.T) {
 	require.Equal(t, 2, numChunks, "Expected 2 chunks uploaded before finalize")
 }
 
+// resumableState returns the state of an upload of testFile saved next to it.
+func resumableState(testFile string) *importState {
+	return &importState{Database: "db", Hostname: "db.example.com", Group: "default", path: ImportStatePath(testFile)}
+}
+
+func TestUploadFileMultipart_Resume(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.failAtChunk = 2
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	// The chunk after the failed one is not sent
+	client.SetUploadOptions(UploadOptions{Concurrency: 1})
+	testFile := createTestFile(t, 3*1024+512) // 4 chunks
+	t.Cleanup(func() { os.Remove(ImportStatePath(testFile)) })
+
+	err := client.uploadFileMultipart(t.Context(), testFile, resumableState(testFile), "", "", NewProgressRecorder().Callback())
+	require.Error(t, err)
+	require.True(t, ImportInProgress(testFile))
+	interrupted, err := FindInterruptedImport(testFile)
+	require.NoError(t, err)
+	require.Equal(t, &InterruptedImport{Database: "db", Group: "default", UploadedChunks: 2, TotalChunks: 4}, interrupted)
+
+	// Only the chunks that were not acknowledged are uploaded again
+	mock.mu.Lock()
+	mock.failAtChunk = -1
+	requests := mock.requestCount
+	mock.mu.Unlock()
+	state, err := readImportState(testFile)
+	require.NoError(t, err)
+	require.Equal(t, int64(2*1024), state.uploadedBytes())
+	progress := NewProgressRecorder()
+	require.NoError(t, client.uploadFileMultipart(t.Context(), testFile, state, "", "", progress.Callback()))
+	progress.VerifyFinalCall(t, 3*1024+512)
+	require.False(t, ImportInProgress(testFile))
+
+	// The digest of the file sent on finalize covers the chunks uploaded
+	// before the interruption too, the mock checks it
+	mock.mu.Lock()
+	require.Equal(t, 3, mock.requestCount-requests, "Expected 2 chunks and finalize")
+	mock.mu.Unlock()
+	expected, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, expected, mock.GetAllChunkData())
+}
+
+func TestUploadFileMultipart_ResumeChangedFile(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.failAtChunk = 1
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	testFile := createTestFile(t, 3*1024)
+	t.Cleanup(func() { os.Remove(ImportStatePath(testFile)) })
+
+	err := client.uploadFileMultipart(t.Context(), testFile, resumableState(testFile), "", "", NewProgressRecorder().Callback())
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(testFile, bytes.Repeat([]byte("CHANGED!"), 3*1024/8), 0o644))
+	state, err := readImportState(testFile)
+	require.NoError(t, err)
+	err = client.uploadFileMultipart(t.Context(), testFile, state, "", "", NewProgressRecorder().Callback())
+	require.ErrorContains(t, err, "changed since its upload started")
+	require.False(t, ImportInProgress(testFile))
+}
+
+func TestUploadFileMultipart_RejectedUploadIsNotResumable(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.finalizeStatus = http.StatusBadRequest
+	mock.failAtEndpoint = "finalize"
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	testFile := createTestFile(t, 2*1024)
+
+	err := client.uploadFileMultipart(t.Context(), testFile, resumableState(testFile), "", "", NewProgressRecorder().Callback())
+	require.Error(t, err)
+	require.False(t, ImportInProgress(testFile))
+}
+
+func TestUploadFileMultipart_Concurrent(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	chunks := map[int][]byte{}
+	uploadID := "test-upload-id"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/upload/start":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"chunk_size": int64(1024), "upload_id": uploadID})
+		case strings.HasPrefix(r.URL.Path, "/v2/upload/"+uploadID+"/chunk/"):
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+			}
+			chunkID, _ := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			data, _ := io.ReadAll(r.Body)
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			chunks[chunkID] = data
+			mu.Unlock()
+		case r.URL.Path == "/v2/upload/"+uploadID+"/finalize":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+	client.SetUploadOptions(UploadOptions{Concurrency: 3})
+	testFile := createTestFile(t, 8*1024+100) // 9 chunks
+	progress := NewProgressRecorder()
+
+	require.NoError(t, client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback()))
+	require.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	require.Greater(t, maxInFlight.Load(), int32(1), "Expected chunks to be uploaded concurrently")
+
+	// Progress is reported for the whole file, not per chunk
+	progress.VerifyProgressIncreasing(t)
+	progress.VerifyFinalCall(t, 8*1024+100)
+	calls := progress.GetCalls()
+	for i := 1; i < len(calls); i++ {
+		require.GreaterOrEqual(t, calls[i].UploadedBytes, calls[i-1].UploadedBytes)
+	}
+
+	expected, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	var received []byte
+	for chunkID := 0; chunkID < len(chunks); chunkID++ {
+		received = append(received, chunks[chunkID]...)
+	}
+	require.Equal(t, expected, received)
+}
+
+func TestUploadFileMultipart_ConcurrentFailureStopsUpload(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.failAtChunk = 1
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	client.SetUploadOptions(UploadOptions{Concurrency: 2})
+	testFile := createTestFile(t, 20*1024) // 20 chunks
+
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", NewProgressRecorder().Callback())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "chunk 1")
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	require.Less(t, len(mock.chunkData), 19, "Expected the failure to stop the upload of the next chunks")
+}
+
+func TestUploadFileMultipart_Digests(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	testFile := createTestFile(t, 2500)
+
+	// The mock rejects chunks whose digest doesn't match their data
+	require.NoError(t, client.UploadFileMultipart(t.Context(), testFile, "", "", NewProgressRecorder().Callback()))
+	data, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	require.Equal(t, sha256Hex(data), mock.GetHeader(http.CanonicalHeaderKey(UploadSHA256Header)))
+}
+
+func TestUploadFileMultipart_ChunkDigestMismatch(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.corruptChunkDigest = 1
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	testFile := createTestFile(t, 2500)
+	t.Cleanup(func() { os.Remove(ImportStatePath(testFile)) })
+
+	err := client.uploadFileMultipart(t.Context(), testFile, resumableState(testFile), "", "", NewProgressRecorder().Callback())
+	require.ErrorContains(t, err, "chunk 1 is corrupted")
+
+	// The chunk is not recorded as completed, so resuming uploads it again
+	state, err := readImportState(testFile)
+	require.NoError(t, err)
+	require.NotContains(t, state.CompletedChunks, 1)
+}
+
+func TestUploadFileMultipart_UploadDigestMismatch(t *testing.T) {
+	mock := NewMockTursoServer()
+	mock.chunkSize = 1024
+	mock.corruptUploadDigest = true
+	defer mock.Close()
+
+	client := createTestClient(t, mock.URL)
+	testFile := createTestFile(t, 2500)
+	t.Cleanup(func() { os.Remove(ImportStatePath(testFile)) })
+
+	err := client.uploadFileMultipart(t.Context(), testFile, resumableState(testFile), "", "", NewProgressRecorder().Callback())
+	require.ErrorIs(t, err, errUploadCorrupted)
+	require.False(t, ImportInProgress(testFile))
+}
+
 func TestUploadFileMultipart_FileNotFound(t *testing.T) {
 	mock := NewMockTursoServer()
 	defer mock.Close()
@@ -486,7 +725,7 @@ func TestUploadFileMultipart_FileNotFound(t *testing.T) {
 	client := createTestClient(t, mock.URL)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart("/nonexistent/path/file.db", "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), "/nonexistent/path/file.db", "", "", progress.Callback())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to open file")
 }
@@ -500,7 +739,7 @@ func TestUploadFileMultipart_ProgressCallbackPerChunk(t *testing.T) {
 	testFile := createTestFile(t, 5*1024) // 5 chunks
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	calls := progress.GetCalls()
@@ -548,10 +787,12 @@ func TestUploadFileMultipart_ContentLengthHeader(t *testing.T) {
 	defer server.Close()
 
 	client := createTestClient(t, server.URL)
+	// Chunks are recorded in the order they arrive
+	client.SetUploadOptions(UploadOptions{Concurrency: 1})
 	testFile := createTestFile(t, 2500) // 2 full chunks + 452 bytes
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mu.Lock()
@@ -577,7 +818,7 @@ func TestUploadFileMultipart_DataIntegrity(t *testing.T) {
 	testFile := createTestFileWithContent(t, knownData)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	// Reconstruct data from chunks
@@ -598,7 +839,7 @@ func TestUploadFileMultipart_ChunkSizeFromServer(t *testing.T) {
 	testFile := createTestFile(t, 2000) // Should result in 4 chunks (512+512+512+464)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	mock.mu.Lock()
@@ -638,7 +879,7 @@ func TestUploadFileMultipart_HTTPStatusCodes(t *testing.T) {
 			testFile := createTestFile(t, 2048)
 			progress := NewProgressRecorder()
 
-			err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+			err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 
 			if tc.shouldSucceed {
 				require.NoError(t, err)
@@ -897,7 +1138,7 @@ func TestUploadFileMultipart_SmoothProgress(t *testing.T) {
 	testFile := createTestFile(t, 1024*1024)
 	progress := NewProgressRecorder()
 
-	err := client.UploadFileMultipart(testFile, "", "", progress.Callback())
+	err := client.UploadFileMultipart(t.Context(), testFile, "", "", progress.Callback())
 	require.NoError(t, err)
 
 	calls := progress.GetCalls()
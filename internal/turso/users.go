@@ -1,6 +1,7 @@
 package turso
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -16,10 +17,10 @@ type UserInfoResponse struct {
 	User UserInfo `json:"user"`
 }
 
-func (u *UsersClient) GetUser() (UserInfo, error) {
-	res, err := u.client.Get("/v1/current-user", nil)
+func (u *UsersClient) GetUser(ctx context.Context) (UserInfo, error) {
+	res, err := u.client.Get(ctx, "/v1/current-user", nil)
 	if err != nil {
-		return UserInfo{}, fmt.Errorf("failed to get user info: %s", err)
+		return UserInfo{}, fmt.Errorf("failed to get user info: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -44,18 +44,3 @@ func GetFeatureError(body []byte, feature string) error {
 	}
 	return nil
 }
-
-func parseResponseError(res *http.Response) error {
-	d, err := io.ReadAll(res.Body)
-	if err != nil {
-		return fmt.Errorf("response failed with status %s (%s)", res.Status, d)
-	}
-
-	var errResp ErrorResponseDetails
-	if err := json.Unmarshal(d, &errResp); err == nil {
-		if errResp.Error != nil {
-			return fmt.Errorf("%v", errResp.Error)
-		}
-	}
-	return fmt.Errorf("response failed with status %s (%s)", res.Status, d)
-}
@@ -0,0 +1,164 @@
+package turso
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// ExportCheck is the result of VerifyExport.
+type ExportCheck struct {
+	PageSize int
+	Pages    int
+	// WAL is set when the export has a WAL file, of Frames frames.
+	WAL    bool
+	Frames int
+	// Metadata is set when the export has a metadata file.
+	Metadata        bool
+	Generation      int
+	DurableFrameNum int
+	// Problems describes what is wrong with the export, if anything.
+	Problems []string
+}
+
+// Valid reports whether no problem was found.
+func (c *ExportCheck) Valid() bool {
+	return len(c.Problems) == 0
+}
+
+func (c *ExportCheck) problem(format string, args ...any) {
+	c.Problems = append(c.Problems, fmt.Sprintf(format, args...))
+}
+
+// VerifyExport checks an export written by Export: the header and size of the
+// database file, the header and frame checksums of its WAL file and the hash of
+// its metadata file, the last two only when they exist. What is wrong is
+// reported in ExportCheck.Problems, an error is only returned when a file
+// can't be read.
+func VerifyExport(dbFile string) (ExportCheck, error) {
+	check := ExportCheck{}
+	if ExportInProgress(dbFile) {
+		check.problem("the export is incomplete, %s exists", exportStatePath(dbFile))
+	}
+	if err := check.database(dbFile); err != nil {
+		return check, err
+	}
+	problems := len(check.Problems)
+	if err := check.wal(dbFile + "-wal"); err != nil {
+		return check, err
+	}
+	// The frames of a WAL with problems are not all counted
+	if err := check.metadata(dbFile, len(check.Problems) == problems); err != nil {
+		return check, err
+	}
+	return check, nil
+}
+
+// sqliteHeaderString starts every SQLite database file.
+const sqliteHeaderString = "SQLite format 3\x00"
+
+func (c *ExportCheck) database(dbFile string) error {
+	file, err := os.Open(dbFile)
+	if err != nil {
+		return fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat database file: %w", err)
+	}
+	header := make([]byte, 100)
+	if _, err := io.ReadFull(file, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			c.problem("database file is too small to be a SQLite database (%d bytes)", stat.Size())
+			return nil
+		}
+		return fmt.Errorf("failed to read database header: %w", err)
+	}
+
+	if !bytes.Equal(header[:16], []byte(sqliteHeaderString)) {
+		c.problem("database file is not a SQLite database: invalid header string %q", header[:16])
+		return nil
+	}
+	pageSize := sqlitePageSize(header)
+	if !validPageSize(pageSize) {
+		c.problem("database header: invalid page size %d", pageSize)
+		return nil
+	}
+	c.PageSize = pageSize
+	if (header[18] != 1 && header[18] != 2) || (header[19] != 1 && header[19] != 2) {
+		c.problem("database header: unsupported file format version %d/%d", header[18], header[19])
+	}
+	if header[21] != 64 || header[22] != 32 || header[23] != 32 {
+		c.problem("database header: invalid payload fractions %d/%d/%d", header[21], header[22], header[23])
+	}
+	c.Pages = int(stat.Size() / int64(c.PageSize))
+	if stat.Size()%int64(c.PageSize) != 0 {
+		c.problem("database file size %d is not a multiple of the page size %d", stat.Size(), c.PageSize)
+	} else if bytes.Equal(header[24:28], header[92:96]) {
+		// The in-header database size is only used when the
+		// version-valid-for number matches the change counter
+		if pages := int(binary.BigEndian.Uint32(header[28:32])); pages != c.Pages {
+			c.problem("database header records %d pages, but the file has %d", pages, c.Pages)
+		}
+	}
+	return nil
+}
+
+func (c *ExportCheck) wal(walFile string) error {
+	file, err := os.Open(walFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	defer file.Close()
+	c.WAL = true
+
+	scan, err := scanWAL(file)
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			return err
+		}
+		c.problem("%s", err)
+		return nil
+	}
+	c.Frames = scan.Frames
+	if c.PageSize != 0 && scan.PageSize != c.PageSize {
+		c.problem("WAL page size %d differs from the database page size %d", scan.PageSize, c.PageSize)
+	}
+	return nil
+}
+
+// metadata checks the metadata file, and that it records the frames of the
+// WAL when they were all counted.
+func (c *ExportCheck) metadata(dbFile string, countedFrames bool) error {
+	if _, err := os.Stat(dbFile + "-info"); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	c.Metadata = true
+	metadata, err := readExportMetadata(dbFile)
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			return err
+		}
+		c.problem("%s", err)
+		return nil
+	}
+	c.Generation = metadata.Generation
+	c.DurableFrameNum = metadata.DurableFrameNum
+	// A WAL started by a pull after a checkpoint holds the frames after
+	// the base only. A WAL cut at a frame boundary is valid on its own, so
+	// a missing frame is only noticed here.
+	if expected := c.DurableFrameNum - metadata.WALBase; c.WAL && countedFrames && c.Frames != expected {
+		c.problem("WAL has %d frames, but the metadata records %d", c.Frames, expected)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package turso
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// exportForVerify exports a database of 12 pages with 25 WAL frames and
+// metadata.
+func exportForVerify(t *testing.T) string {
+	t.Helper()
+	mock := NewMockExportServer(0, 25)
+	defer mock.Close()
+	mock.snapshot = sqliteSnapshot(12)
+	for _, frame := range mock.frames {
+		if binary.BigEndian.Uint32(frame[0:4]) == 1 {
+			copy(frame[24:], mock.snapshot[:100])
+		}
+	}
+	client := createTestClient(t, mock.URL)
+	outputFile := filepath.Join(t.TempDir(), "db.db")
+	_, err := client.Export(context.Background(), outputFile, ExportOptions{WithMetadata: true})
+	require.NoError(t, err)
+	return outputFile
+}
+
+func TestVerifyExport(t *testing.T) {
+	outputFile := exportForVerify(t)
+
+	check, err := VerifyExport(outputFile)
+	require.NoError(t, err)
+	require.True(t, check.Valid(), check.Problems)
+	require.Equal(t, ExportCheck{
+		PageSize:        4096,
+		Pages:           12,
+		WAL:             true,
+		Frames:          25,
+		Metadata:        true,
+		Generation:      1,
+		DurableFrameNum: 25,
+	}, check)
+}
+
+func TestVerifyExport_Checkpointed(t *testing.T) {
+	outputFile := exportForVerify(t)
+	_, err := checkpointWAL(outputFile)
+	require.NoError(t, err)
+
+	check, err := VerifyExport(outputFile)
+	require.NoError(t, err)
+	require.True(t, check.Valid(), check.Problems)
+	require.False(t, check.WAL)
+	require.Equal(t, 10, check.Pages)
+}
+
+func TestVerifyExport_Problems(t *testing.T) {
+	corruptAt := func(file string, offset int64, data []byte) func(t *testing.T, outputFile string) {
+		return func(t *testing.T, outputFile string) {
+			f, err := os.OpenFile(outputFile+file, os.O_RDWR, 0)
+			require.NoError(t, err)
+			defer f.Close()
+			_, err = f.WriteAt(data, offset)
+			require.NoError(t, err)
+		}
+	}
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, outputFile string)
+		problem string
+	}{
+		{
+			name:    "header string",
+			corrupt: corruptAt("", 0, []byte("MySQL")),
+			problem: `database file is not a SQLite database: invalid header string "MySQLe format 3\x00"`,
+		},
+		{
+			name:    "page size",
+			corrupt: corruptAt("", 16, []byte{0x10, 0x01}),
+			problem: "database header: invalid page size 4097",
+		},
+		{
+			name:    "page count",
+			corrupt: corruptAt("", 28, []byte{0, 0, 0, 13}),
+			problem: "database header records 13 pages, but the file has 12",
+		},
+		{
+			name: "file size",
+			corrupt: func(t *testing.T, outputFile string) {
+				require.NoError(t, os.Truncate(outputFile, 12*walPageSize-100))
+			},
+			problem: "database file size 49052 is not a multiple of the page size 4096",
+		},
+		{
+			name:    "WAL magic",
+			corrupt: corruptAt("-wal", 0, []byte{0, 0, 0, 0}),
+			problem: "invalid WAL header: magic number 0x0",
+		},
+		{
+			name:    "WAL frame checksum",
+			corrupt: corruptAt("-wal", walHeaderSize+6*walFrameSize+500, []byte{0xff, 0xff}),
+			problem: "WAL frame 7: checksum mismatch",
+		},
+		{
+			name: "truncated WAL frame",
+			corrupt: func(t *testing.T, outputFile string) {
+				require.NoError(t, os.Truncate(outputFile+"-wal", walHeaderSize+3*walFrameSize-10))
+			},
+			problem: "WAL frame 3 is truncated",
+		},
+		{
+			name: "WAL truncated at a frame boundary",
+			corrupt: func(t *testing.T, outputFile string) {
+				require.NoError(t, os.Truncate(outputFile+"-wal", walHeaderSize+20*walFrameSize))
+			},
+			problem: "WAL has 20 frames, but the metadata records 25",
+		},
+		{
+			name: "metadata hash",
+			corrupt: func(t *testing.T, outputFile string) {
+				metadata := `{"hash":1,"version":0,"durable_frame_num":25,"generation":1}`
+				require.NoError(t, os.WriteFile(outputFile+"-info", []byte(metadata), 0o644))
+			},
+			problem: "hash mismatch",
+		},
+		{
+			name: "incomplete export",
+			corrupt: func(t *testing.T, outputFile string) {
+				require.NoError(t, (&exportState{Generation: 1}).save(outputFile))
+			},
+			problem: "the export is incomplete",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFile := exportForVerify(t)
+			tt.corrupt(t, outputFile)
+
+			check, err := VerifyExport(outputFile)
+			require.NoError(t, err)
+			require.False(t, check.Valid())
+			require.Len(t, check.Problems, 1, check.Problems)
+			require.Contains(t, check.Problems[0], tt.problem)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package turso
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// walMagic identifies a WAL file whose checksums are computed on
+// little-endian words, which is what exports write.
+const walMagic = 0x377f0682
+
+// walChecksum extends the running WAL checksum s0, s1 with data, whose length
+// must be a multiple of 8.
+func walChecksum(s0, s1 uint32, data []byte) (uint32, uint32) {
+	for i := 0; i < len(data); i += 8 {
+		s0 += binary.LittleEndian.Uint32(data[i:i+4]) + s1
+		s1 += binary.LittleEndian.Uint32(data[i+4:i+8]) + s0
+	}
+	return s0, s1
+}
+
+// walState is the position at the end of the valid frames of a WAL file.
+type walState struct {
+	Salt     [2]uint32
+	Checksum [2]uint32
+	Frames   int
+}
+
+// readWALState reads the WAL file at path and returns the state after its
+// last valid frame. Frames after the first one with a wrong salt or checksum
+// are ignored, as SQLite does. ok is false when the file is missing or its
+// header is not valid.
+func readWALState(path string) (state walState, ok bool, err error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return walState{}, false, nil
+	}
+	if err != nil {
+		return walState{}, false, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, walHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return walState{}, false, nil
+		}
+		return walState{}, false, fmt.Errorf("failed to read WAL header: %w", err)
+	}
+	if binary.BigEndian.Uint32(header[0:4]) != walMagic || binary.BigEndian.Uint32(header[8:12]) != walPageSize {
+		return walState{}, false, nil
+	}
+	s0, s1 := walChecksum(0, 0, header[:24])
+	if s0 != binary.BigEndian.Uint32(header[24:28]) || s1 != binary.BigEndian.Uint32(header[28:32]) {
+		return walState{}, false, nil
+	}
+	state = walState{
+		Salt:     [2]uint32{binary.BigEndian.Uint32(header[16:20]), binary.BigEndian.Uint32(header[20:24])},
+		Checksum: [2]uint32{s0, s1},
+	}
+
+	frame := make([]byte, walFrameSize)
+	for {
+		if _, err := io.ReadFull(file, frame); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return state, true, nil
+			}
+			return walState{}, false, fmt.Errorf("failed to read WAL frame: %w", err)
+		}
+		if binary.BigEndian.Uint32(frame[8:12]) != state.Salt[0] || binary.BigEndian.Uint32(frame[12:16]) != state.Salt[1] {
+			return state, true, nil
+		}
+		f0, f1 := walChecksum(state.Checksum[0], state.Checksum[1], frame[:8])
+		f0, f1 = walChecksum(f0, f1, frame[24:])
+		if f0 != binary.BigEndian.Uint32(frame[16:20]) || f1 != binary.BigEndian.Uint32(frame[20:24]) {
+			return state, true, nil
+		}
+		state.Checksum = [2]uint32{f0, f1}
+		state.Frames++
+	}
+}
+
+// walChecksumBigEndian is walChecksum for WAL files whose checksums are
+// computed on big-endian words.
+func walChecksumBigEndian(s0, s1 uint32, data []byte) (uint32, uint32) {
+	for i := 0; i < len(data); i += 8 {
+		s0 += binary.BigEndian.Uint32(data[i:i+4]) + s1
+		s1 += binary.BigEndian.Uint32(data[i+4:i+8]) + s0
+	}
+	return s0, s1
+}
+
+// walScan describes a WAL file checked by scanWAL.
+type walScan struct {
+	PageSize int
+	Frames   int
+	// CommittedFrames is the number of frames up to the last commit frame,
+	// and DBPages the size of the database it commits, in pages.
+	CommittedFrames int
+	DBPages         uint32
+	// Pages maps the pages written by committed frames to the offset of
+	// their latest version in the file.
+	Pages map[uint32]int64
+}
+
+// scanWAL checks the header of a WAL file and the salts and checksums of all
+// its frames. Unlike SQLite, which ignores the frames after an invalid one, it
+// fails on the first invalid frame.
+func scanWAL(wal io.Reader) (walScan, error) {
+	header := make([]byte, walHeaderSize)
+	if _, err := io.ReadFull(wal, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return walScan{}, fmt.Errorf("WAL header is truncated")
+		}
+		return walScan{}, fmt.Errorf("failed to read WAL header: %w", err)
+	}
+	checksum := walChecksum
+	switch magic := binary.BigEndian.Uint32(header[0:4]); magic {
+	case walMagic:
+	case walMagic | 1:
+		checksum = walChecksumBigEndian
+	default:
+		return walScan{}, fmt.Errorf("invalid WAL header: magic number %#x", magic)
+	}
+	scan := walScan{PageSize: int(binary.BigEndian.Uint32(header[8:12])), Pages: map[uint32]int64{}}
+	if !validPageSize(scan.PageSize) {
+		return walScan{}, fmt.Errorf("invalid WAL header: page size %d", scan.PageSize)
+	}
+	s0, s1 := checksum(0, 0, header[:24])
+	if s0 != binary.BigEndian.Uint32(header[24:28]) || s1 != binary.BigEndian.Uint32(header[28:32]) {
+		return walScan{}, fmt.Errorf("invalid WAL header: checksum mismatch")
+	}
+
+	frameSize := 24 + scan.PageSize
+	pages := map[uint32]int64{}
+	frame := make([]byte, frameSize)
+	for frameNo := 1; ; frameNo++ {
+		if _, err := io.ReadFull(wal, frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				return scan, nil
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return walScan{}, fmt.Errorf("WAL frame %d is truncated", frameNo)
+			}
+			return walScan{}, fmt.Errorf("failed to read WAL frame %d: %w", frameNo, err)
+		}
+		if !bytes.Equal(frame[8:16], header[16:24]) {
+			return walScan{}, fmt.Errorf("WAL frame %d: salt mismatch", frameNo)
+		}
+		s0, s1 = checksum(s0, s1, frame[:8])
+		s0, s1 = checksum(s0, s1, frame[24:])
+		if s0 != binary.BigEndian.Uint32(frame[16:20]) || s1 != binary.BigEndian.Uint32(frame[20:24]) {
+			return walScan{}, fmt.Errorf("WAL frame %d: checksum mismatch", frameNo)
+		}
+		pgno := binary.BigEndian.Uint32(frame[0:4])
+		if pgno == 0 {
+			return walScan{}, fmt.Errorf("WAL frame %d: invalid page number 0", frameNo)
+		}
+		scan.Frames = frameNo
+		pages[pgno] = int64(walHeaderSize) + int64(frameNo-1)*int64(frameSize)
+		if commit := binary.BigEndian.Uint32(frame[4:8]); commit != 0 {
+			for p, offset := range pages {
+				scan.Pages[p] = offset
+			}
+			clear(pages)
+			scan.DBPages = commit
+			scan.CommittedFrames = frameNo
+		}
+	}
+}
+
+// checkpointWAL applies the committed frames of dbFile-wal to dbFile, removes
+// the WAL and switches the database to the rollback journal, so that dbFile is
+// a complete database on its own. It returns the number of frames applied.
+//
+// The WAL is checked with scanWAL first, and nothing is written to dbFile when
+// it is not valid.
+func checkpointWAL(dbFile string) (int, error) {
+	walFile := dbFile + "-wal"
+	wal, err := os.Open(walFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	defer wal.Close()
+	scan, err := scanWAL(wal)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := os.OpenFile(dbFile, os.O_RDWR, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer db.Close()
+	dbHeader := make([]byte, 100)
+	if _, err := io.ReadFull(db, dbHeader); err != nil {
+		return 0, fmt.Errorf("failed to read database header: %w", err)
+	}
+	pageSize := sqlitePageSize(dbHeader)
+	if pageSize != scan.PageSize {
+		return 0, fmt.Errorf("database page size is %d, but the WAL page size is %d", pageSize, scan.PageSize)
+	}
+
+	page := make([]byte, pageSize)
+	for pgno, offset := range scan.Pages {
+		if pgno > scan.DBPages {
+			continue
+		}
+		if _, err := wal.ReadAt(page, offset+24); err != nil {
+			return 0, fmt.Errorf("failed to read page %d from WAL: %w", pgno, err)
+		}
+		if _, err := db.WriteAt(page, int64(pgno-1)*int64(pageSize)); err != nil {
+			return 0, fmt.Errorf("failed to write page %d: %w", pgno, err)
+		}
+	}
+	if scan.DBPages > 0 {
+		if err := db.Truncate(int64(scan.DBPages) * int64(pageSize)); err != nil {
+			return 0, fmt.Errorf("failed to truncate database file: %w", err)
+		}
+	}
+
+	if _, err := db.ReadAt(dbHeader, 0); err != nil {
+		return 0, fmt.Errorf("failed to read database header: %w", err)
+	}
+	// Use the rollback journal, and make the in-header database size valid
+	// by setting the version-valid-for number to the change counter
+	dbHeader[18], dbHeader[19] = 1, 1
+	stat, err := db.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat database file: %w", err)
+	}
+	binary.BigEndian.PutUint32(dbHeader[28:32], uint32(stat.Size()/int64(pageSize)))
+	copy(dbHeader[92:96], dbHeader[24:28])
+	if _, err := db.WriteAt(dbHeader, 0); err != nil {
+		return 0, fmt.Errorf("failed to write database header: %w", err)
+	}
+	if err := db.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync database file: %w", err)
+	}
+
+	wal.Close()
+	if err := os.Remove(walFile); err != nil {
+		return 0, fmt.Errorf("failed to remove WAL file: %w", err)
+	}
+	_ = os.Remove(dbFile + "-shm")
+	return scan.CommittedFrames, nil
+}
+
+// sqlitePageSize returns the page size recorded in the header of a database.
+func sqlitePageSize(header []byte) int {
+	pageSize := int(binary.BigEndian.Uint16(header[16:18]))
+	if pageSize == 1 {
+		return 65536
+	}
+	return pageSize
+}
+
+// validPageSize reports whether pageSize is a power of two between 512 and
+// 65536, as SQLite requires.
+func validPageSize(pageSize int) bool {
+	return pageSize >= 512 && pageSize <= 65536 && pageSize&(pageSize-1) == 0
+}